@@ -2,14 +2,24 @@ package amount
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"math/big"
 	"strconv"
+	"strings"
+	"unicode"
 )
 
 const (
+	// NanoTHRYLOS is the number of base units in one THRYLOS. It is the one base unit
+	// constant; config and the ledger derive theirs from it.
 	NanoTHRYLOS = 1e9
 )
 
+// ErrOverflow is returned when the result of an operation on amounts does not fit an
+// Amount.
+var ErrOverflow = errors.New("amount overflow")
+
 type Unit int
 
 const (
@@ -40,6 +50,18 @@ func (u Unit) String() string {
 	}
 }
 
+// units maps the symbols accepted by ParseAmount to their unit. uTHR is accepted for
+// μTHR.
+var units = map[string]Unit{
+	"MTHR": MegaTHR,
+	"kTHR": KiloTHR,
+	"THR":  THR,
+	"mTHR": MilliTHR,
+	"μTHR": MicroTHR,
+	"uTHR": MicroTHR,
+	"nTHR": NanoTHR,
+}
+
 // Amount represents the atomic unit in THRYLOS blockchain.
 // Each unit equals to 1e-9 of a THRYLOS.
 type Amount int64
@@ -62,6 +84,71 @@ func NewAmount(f float64) (Amount, error) {
 	return round(f * float64(NanoTHRYLOS)), nil
 }
 
+// ParseAmount parses a decimal number followed by an optional unit symbol, such as
+// "1.5 kTHR" or "250nTHR". The number is in THR when there is no unit. Parsing is
+// exact: amounts with digits below a base unit or that do not fit an Amount are
+// rejected.
+func ParseAmount(str string) (Amount, error) {
+	str = strings.TrimSpace(str)
+	end := strings.IndexFunc(str, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.' && r != '-' && r != '+'
+	})
+	number, symbol := str, ""
+	if end >= 0 {
+		number, symbol = str[:end], strings.TrimSpace(str[end:])
+	}
+	u := THR
+	if symbol != "" {
+		var ok bool
+		if u, ok = units[symbol]; !ok {
+			return 0, fmt.Errorf("invalid THRYLOS amount %q: unknown unit %q", str, symbol)
+		}
+	}
+	if !isDecimal(number) {
+		return 0, fmt.Errorf("invalid THRYLOS amount %q", str)
+	}
+
+	value, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, fmt.Errorf("invalid THRYLOS amount %q", str)
+	}
+	value.Mul(value, new(big.Rat).SetFrac(unitScale(u)))
+	if !value.IsInt() {
+		return 0, fmt.Errorf("invalid THRYLOS amount %q: below the base unit", str)
+	}
+	if !value.Num().IsInt64() {
+		return 0, fmt.Errorf("%w: %q", ErrOverflow, str)
+	}
+	return Amount(value.Num().Int64()), nil
+}
+
+// isDecimal reports whether s is an optionally signed decimal number with at least
+// one digit and at most one decimal point.
+func isDecimal(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	if strings.Count(s, ".") > 1 || strings.Trim(s, ".") == "" {
+		return false
+	}
+	for _, r := range s {
+		if r != '.' && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// unitScale returns the number of base units in one u as a fraction.
+func unitScale(u Unit) (num, denom *big.Int) {
+	exp := int64(u) + 9
+	num, denom = big.NewInt(1), big.NewInt(1)
+	if exp >= 0 {
+		num.Exp(big.NewInt(10), big.NewInt(exp), nil)
+	} else {
+		denom.Exp(big.NewInt(10), big.NewInt(-exp), nil)
+	}
+	return num, denom
+}
+
 func FromString(str string) (Amount, error) {
 	f, err := strconv.ParseFloat(str, 64)
 	if err != nil {
@@ -82,10 +169,17 @@ func (a Amount) ToNanoTHR() int64 {
 	return int64(a)
 }
 
+// Format returns the exact decimal value of a in unit u followed by the unit symbol,
+// without trailing zeros, such as "1.5 kTHR".
 func (a Amount) Format(u Unit) string {
-	units := " " + u.String()
-	formatted := strconv.FormatFloat(a.ToUnit(u), 'f', -int(u+9), 64)
-	return formatted + units
+	num, denom := unitScale(u)
+	value := new(big.Rat).SetFrac(big.NewInt(int64(a)), big.NewInt(1))
+	value.Quo(value, new(big.Rat).SetFrac(num, denom))
+	formatted := value.FloatString(max(int(u)+9, 0))
+	if strings.Contains(formatted, ".") {
+		formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	}
+	return formatted + " " + u.String()
 }
 
 // String is the equivalent of calling Format with AmountTHR.
@@ -96,3 +190,48 @@ func (a Amount) String() string {
 func (a Amount) MulF64(f float64) Amount {
 	return round(float64(a) * f)
 }
+
+// Add returns a + b, or ErrOverflow when the sum does not fit an Amount.
+func (a Amount) Add(b Amount) (Amount, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, fmt.Errorf("%w: %d + %d", ErrOverflow, a, b)
+	}
+	return sum, nil
+}
+
+// Sub returns a - b, or ErrOverflow when the difference does not fit an Amount.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	diff := a - b
+	if (b > 0 && diff > a) || (b < 0 && diff < a) {
+		return 0, fmt.Errorf("%w: %d - %d", ErrOverflow, a, b)
+	}
+	return diff, nil
+}
+
+// MulDiv returns a * mul / div rounded toward zero. The product is not bounded, so
+// proportions of large amounts are exact; ErrOverflow is returned when the result does
+// not fit an Amount.
+func (a Amount) MulDiv(mul, div int64) (Amount, error) {
+	if div == 0 {
+		return 0, errors.New("amount divided by zero")
+	}
+	result := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(mul))
+	result.Quo(result, big.NewInt(div))
+	if !result.IsInt64() {
+		return 0, fmt.Errorf("%w: %d * %d / %d", ErrOverflow, a, mul, div)
+	}
+	return Amount(result.Int64()), nil
+}
+
+// Sum adds amounts, returning ErrOverflow when the total does not fit an Amount.
+func Sum(amounts ...Amount) (Amount, error) {
+	var total Amount
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
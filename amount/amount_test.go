@@ -0,0 +1,87 @@
+package amount
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+	}{
+		{"1.5 kTHR", 1_500 * NanoTHRYLOS},
+		{"1.5kTHR", 1_500 * NanoTHRYLOS},
+		{"2", 2 * NanoTHRYLOS},
+		{"0.000000001 THR", 1},
+		{"250 nTHR", 250},
+		{"3 μTHR", 3_000},
+		{"3 uTHR", 3_000},
+		{"0.25 MTHR", 250_000 * NanoTHRYLOS},
+		{"-1.5 mTHR", -1_500_000},
+		{"9223372036.854775807 THR", math.MaxInt64},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.in)
+		require.NoError(t, err, tt.in)
+		require.Equal(t, tt.want, got, tt.in)
+	}
+
+	for _, in := range []string{"", "THR", "1.5 kthr", "1e3 THR", "1..5", "0.5 nTHR", "1.0000000001"} {
+		_, err := ParseAmount(in)
+		require.Error(t, err, in)
+	}
+	_, err := ParseAmount("9223372036.854775808")
+	require.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestFormat(t *testing.T) {
+	require.Equal(t, "1.5 kTHR", Amount(1_500*NanoTHRYLOS).Format(KiloTHR))
+	require.Equal(t, "1500 THR", Amount(1_500*NanoTHRYLOS).String())
+	require.Equal(t, "0.00099 THR", Amount(990_000).String())
+	require.Equal(t, "-0.000000001 THR", Amount(-1).String())
+	require.Equal(t, "9223372036.854775807 THR", Amount(math.MaxInt64).String())
+
+	for _, a := range []Amount{0, 1, 123_456_789_012, math.MaxInt64, math.MinInt64 + 1} {
+		for _, u := range []Unit{MegaTHR, KiloTHR, THR, MilliTHR, MicroTHR, NanoTHR} {
+			parsed, err := ParseAmount(a.Format(u))
+			require.NoError(t, err, a.Format(u))
+			require.Equal(t, a, parsed)
+		}
+	}
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	sum, err := Amount(math.MaxInt64 - 1).Add(1)
+	require.NoError(t, err)
+	require.Equal(t, Amount(math.MaxInt64), sum)
+	_, err = sum.Add(1)
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = Amount(math.MinInt64).Add(-1)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	diff, err := Amount(5).Sub(8)
+	require.NoError(t, err)
+	require.Equal(t, Amount(-3), diff)
+	_, err = Amount(math.MinInt64).Sub(1)
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = Amount(math.MaxInt64).Sub(-1)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	// The product exceeds int64 but the result fits
+	share, err := Amount(math.MaxInt64).MulDiv(3, 4)
+	require.NoError(t, err)
+	require.Equal(t, Amount(math.MaxInt64/4*3+2), share)
+	_, err = Amount(math.MaxInt64).MulDiv(4, 3)
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = Amount(1).MulDiv(1, 0)
+	require.Error(t, err)
+
+	total, err := Sum(1, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, Amount(6), total)
+	_, err = Sum(math.MaxInt64, 1)
+	require.ErrorIs(t, err, ErrOverflow)
+}
@@ -69,7 +69,7 @@ func (m *Manager) NotifyBalanceUpdate(address string, balance amount.Amount) {
 }
 
 type cachedBalance struct {
-	value     amount.Amount
+	value     types.Balance
 	timestamp time.Time
 }
 
@@ -102,7 +102,9 @@ func (q *BalanceUpdateQueue) balanceUpdateWorker() {
 	}
 }
 
-func (m *Manager) GetBalance(address string) (amount.Amount, error) {
+// GetBalance returns the amounts address can spend in the next block and the amounts
+// still held by a height or time lock.
+func (m *Manager) GetBalance(address string) (types.Balance, error) {
 	// Check cache first
 	if cached, ok := m.cache.Load(address); ok {
 		cachedBal := cached.(cachedBalance)
@@ -124,24 +126,24 @@ func (m *Manager) GetBalance(address string) (amount.Amount, error) {
 	// Wait for response
 	response := <-responseCh
 	if response.Error != nil {
-		return 0, response.Error
+		return types.Balance{}, response.Error
 	}
 
-	utxos, ok := response.Data.([]types.UTXO)
+	utxoResponse, ok := response.Data.(types.UTXOResponse)
 	if !ok {
-		return 0, fmt.Errorf("invalid UTXO response format")
+		return types.Balance{}, fmt.Errorf("invalid UTXO response format")
+	}
+	if utxoResponse.Error != nil {
+		return types.Balance{}, utxoResponse.Error
 	}
 
-	var total amount.Amount
-	for _, utxo := range utxos {
-		if !utxo.IsSpent {
-			total += utxo.Amount
-		}
+	balance, err := types.NewBalance(utxoResponse.UTXOs, utxoResponse.Height, time.Now().Unix())
+	if err != nil {
+		return types.Balance{}, fmt.Errorf("balance of %s: %v", address, err)
 	}
 
-	if total == 0 {
-		initialBalanceThrylos := 70.0
-		initialBalanceNano, _ := amount.NewAmount(initialBalanceThrylos)
+	if balance.Total() == 0 {
+		initialBalanceNano := amount.Amount(70 * amount.NanoTHRYLOS)
 
 		newUtxo := types.UTXO{
 			OwnerAddress:  address,
@@ -162,15 +164,15 @@ func (m *Manager) GetBalance(address string) (amount.Amount, error) {
 		})
 
 		if response := <-addUTXOResponse; response.Error != nil {
-			return 0, response.Error
+			return types.Balance{}, response.Error
 		}
 
-		total = initialBalanceNano
+		balance.Spendable = initialBalanceNano
 	}
 
 	// Update cache
 	m.cache.Store(address, cachedBalance{
-		value:     total,
+		value:     balance,
 		timestamp: time.Now(),
 	})
 
@@ -179,12 +181,12 @@ func (m *Manager) GetBalance(address string) (amount.Amount, error) {
 		Type: types.UpdateState,
 		Data: types.UpdateStateRequest{
 			Address: address,
-			Balance: total,
+			Balance: balance.Total(),
 		},
 		ResponseCh: make(chan types.Response),
 	})
 
-	return total, nil
+	return balance, nil
 }
 
 func (m *Manager) AddPendingBalanceUpdate(address string, balance int64) {
@@ -241,8 +243,8 @@ func (m *Manager) HandleBalanceUpdate(address string) {
 		log.Printf("Failed to send balance update for %s: %v", address, err)
 	} else {
 		balance, _ := m.GetBalance(address)
-		log.Printf("Successfully sent balance update for %s. Current balance: %d nanoTHRYLOS",
-			address, balance)
+		log.Printf("Successfully sent balance update for %s. Current balance: %d nanoTHRYLOS spendable, %d locked",
+			address, balance.Spendable, balance.Locked)
 	}
 }
 
@@ -253,7 +255,7 @@ func (m *Manager) ProcessBalanceUpdateQueue() {
 			log.Printf("Error processing balance update for %s: %v", request.Address, err)
 			continue
 		}
-		m.NotifyBalanceUpdate(request.Address, balance)
+		m.NotifyBalanceUpdate(request.Address, balance.Total())
 	}
 }
 
@@ -271,7 +273,7 @@ func (m *Manager) UpdateBalanceAsync(address string) {
 			}
 
 			if err := m.SendBalanceUpdate(address); err == nil {
-				log.Printf("Balance updated successfully for %s: %d", address, balance)
+				log.Printf("Balance updated successfully for %s: %d spendable, %d locked", address, balance.Spendable, balance.Locked)
 				return
 			}
 			retries++
@@ -292,10 +294,9 @@ func (m *Manager) Stop() {
 
 // Utility functions
 func FormatBalance(balanceNano int64) string {
-	balanceThrylos := float64(balanceNano) / config.NanoPerThrylos
-	return fmt.Sprintf("%d nanoTHRYLOS (%.7f THRYLOS)", balanceNano, balanceThrylos)
+	return fmt.Sprintf("%d nanoTHRYLOS (%s)", balanceNano, amount.Amount(balanceNano))
 }
 
 func ThrylosToNano(thrylos float64) int64 {
-	return int64(thrylos * 1e9)
+	return int64(thrylos * config.NanoPerThrylos)
 }
@@ -21,6 +21,7 @@ func NewBlock(index int64, prevHash hash.Hash, transactions []*types.Transaction
 		PrevHash:           prevHash,
 		Transactions:       transactions,
 		ValidatorPublicKey: validatorPublicKey,
+		Version:            types.BlockVersion,
 	}
 
 	if err := InitializeVerkleTree(block); err != nil {
@@ -40,6 +41,7 @@ func NewGenesisBlock() *types.Block {
 		VerkleRoot: []byte{}, // Or some predefined value, since it's a special case.
 		PrevHash:   hash.NullHash(),
 		Hash:       hash.NullHash(),
+		Version:    types.BlockVersion,
 	}
 	ComputeBlockHash(block)
 	return block
@@ -67,15 +69,10 @@ func Verify(b *types.Block) error {
 	return nil
 }
 
+// SerializeForSigning returns the bytes the block hash is computed over: the block header
+// without its signature. Transactions are covered through the Verkle root.
 func SerializeForSigning(b *types.Block) ([]byte, error) {
-	// Create a copy to avoid modifying the original
-	blockCopy := *b
-	blockCopy.Hash = hash.NullHash()
-	blockCopy.Signature = nil
-	blockCopy.Salt = nil
-
-	// Marshal the block to bytes
-	blockBytes, err := blockCopy.Marshal()
+	blockBytes, err := b.Header().SigningBytes()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal block for signing: %v", err)
 	}
@@ -83,23 +80,13 @@ func SerializeForSigning(b *types.Block) ([]byte, error) {
 }
 
 // InitializeVerkleTree initializes the Verkle Tree lazily and calculates its root.
+// Transactions are keyed by their hash so their inclusion can be proven later.
 func InitializeVerkleTree(b *types.Block) error {
 	if len(b.Transactions) == 0 {
 		return nil
 	}
-	// Pre-allocate slice for better memory efficiency
-	txData := make([][]byte, 0, len(b.Transactions))
-
-	// Marshal transactions
-	for _, tx := range b.Transactions {
-		txByte, err := tx.Marshal()
-		if err != nil {
-			return fmt.Errorf("failed to serialize transaction: %v", err)
-		}
-		txData = append(txData, txByte)
-	}
 
-	tree, err := NewVerkleTree(txData)
+	tree, err := newTransactionTree(b.Transactions)
 	if err != nil {
 		return fmt.Errorf("failed to create Verkle tree: %v", err)
 	}
@@ -109,6 +96,13 @@ func InitializeVerkleTree(b *types.Block) error {
 	return nil
 }
 
+// SignBlock computes the block hash and signs it with the validator's private key.
+// VerifySignedBlock checks the signature against the same hash.
+func SignBlock(b *types.Block, privateKey crypto.PrivateKey) {
+	ComputeBlockHash(b)
+	b.Signature = privateKey.Sign(b.Hash.Bytes())
+}
+
 func ComputeBlockHash(b *types.Block) {
 	blockByte, err := SerializeForSigning(b) // Pass the block parameter
 	if err != nil {
@@ -0,0 +1,140 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// Consensus rule violations. VerifySignedBlock wraps them in a *BlockRuleError, so
+// callers can test for a rule with errors.Is.
+var (
+	ErrBlockTimestampTooOld   = errors.New("block timestamp does not exceed the median time of previous blocks")
+	ErrBlockTimestampInFuture = errors.New("block timestamp is too far in the future")
+	ErrBlockTooLarge          = errors.New("block exceeds the maximum size")
+	ErrTooManyTransactions    = errors.New("block exceeds the maximum transaction count")
+)
+
+// BlockRuleError reports which consensus rule a block violates.
+type BlockRuleError struct {
+	Height int64
+	Rule   error // One of the Err* rule errors, or types.ErrMemoTooLarge
+	Detail string
+}
+
+func (e *BlockRuleError) Error() string {
+	return fmt.Sprintf("block %d: %v: %s", e.Height, e.Rule, e.Detail)
+}
+
+func (e *BlockRuleError) Unwrap() error {
+	return e.Rule
+}
+
+// maxBlockTimeDrift returns the configured future drift for block timestamps, or the
+// default when none is configured.
+func maxBlockTimeDrift(configured time.Duration) time.Duration {
+	if configured > 0 {
+		return configured
+	}
+	return config.MaxBlockTimeDriftSeconds * time.Second
+}
+
+// checkBlockRules enforces the transaction count, size, memo size and timestamp rules. The
+// median time rule needs the parent block, so it is skipped for blocks whose parent
+// is unknown; those are rejected later when they are linked into the chain. The future
+// drift rule only applies to blocks above the current tip: blocks at or below it are
+// history received while syncing or importing, which the local clock cannot judge.
+func (bc *BlockchainImpl) checkBlockRules(block *types.Block) error {
+	if len(block.Transactions) > config.MaxBlockTransactions {
+		return &BlockRuleError{
+			Height: block.Index,
+			Rule:   ErrTooManyTransactions,
+			Detail: fmt.Sprintf("%d transactions, limit %d", len(block.Transactions), config.MaxBlockTransactions),
+		}
+	}
+
+	for _, tx := range block.Transactions {
+		if err := tx.ValidateMemo(); err != nil {
+			return &BlockRuleError{
+				Height: block.Index,
+				Rule:   types.ErrMemoTooLarge,
+				Detail: fmt.Sprintf("transaction %s memo of %d bytes, limit %d", tx.ID, len(tx.Memo), config.MaxMemoSize),
+			}
+		}
+	}
+
+	data, err := block.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to serialize block %d: %v", block.Index, err)
+	}
+	if len(data) > config.MaxBlockSize {
+		return &BlockRuleError{
+			Height: block.Index,
+			Rule:   ErrBlockTooLarge,
+			Detail: fmt.Sprintf("%d bytes, limit %d", len(data), config.MaxBlockSize),
+		}
+	}
+
+	tip := bc.Blockchain.Blocks[len(bc.Blockchain.Blocks)-1]
+	if block.Index > tip.Index {
+		latest := time.Now().Add(maxBlockTimeDrift(bc.Blockchain.MaxBlockTimeDrift)).Unix()
+		if block.Timestamp > latest {
+			return &BlockRuleError{
+				Height: block.Index,
+				Rule:   ErrBlockTimestampInFuture,
+				Detail: fmt.Sprintf("timestamp %d, latest allowed %d", block.Timestamp, latest),
+			}
+		}
+	}
+
+	if block.Index == 0 {
+		return nil
+	}
+	if median, ok := bc.medianTimePast(block.PrevHash); ok && block.Timestamp <= median {
+		return &BlockRuleError{
+			Height: block.Index,
+			Rule:   ErrBlockTimestampTooOld,
+			Detail: fmt.Sprintf("timestamp %d, median time %d", block.Timestamp, median),
+		}
+	}
+	return nil
+}
+
+// medianTimePast returns the median timestamp of the last config.MedianTimeBlocks
+// blocks ending at the given block, following side branches where needed.
+func (bc *BlockchainImpl) medianTimePast(tipHash hash.Hash) (int64, bool) {
+	timestamps := make([]int64, 0, config.MedianTimeBlocks)
+	block := bc.findBlock(tipHash)
+	if block == nil {
+		return 0, false
+	}
+	for block != nil && len(timestamps) < config.MedianTimeBlocks {
+		timestamps = append(timestamps, block.Timestamp)
+		if block.Index == 0 {
+			break
+		}
+		block = bc.findBlock(block.PrevHash)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	return timestamps[len(timestamps)/2], true
+}
+
+// findBlock looks a block up on the canonical chain and on the side branches.
+func (bc *BlockchainImpl) findBlock(blockHash hash.Hash) *types.Block {
+	if height := bc.canonicalHeight(blockHash); height >= 0 {
+		return bc.Blockchain.Blocks[height]
+	}
+	for _, fork := range bc.Blockchain.Forks {
+		for _, block := range fork.Blocks {
+			if block.Hash.Equal(blockHash) {
+				return block
+			}
+		}
+	}
+	return nil
+}
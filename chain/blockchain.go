@@ -1,11 +1,7 @@
 package chain
 
 import (
-	"bytes"
-	"database/sql"
-	"encoding/gob"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -17,6 +13,7 @@ import (
 	"time"
 
 	thrylos "github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/config"
 	"github.com/thrylos-labs/thrylos/crypto"
 	"github.com/thrylos-labs/thrylos/crypto/hash"
 	"github.com/thrylos-labs/thrylos/network"
@@ -31,19 +28,37 @@ type BlockchainImpl struct {
 	// modernProcessor *processor.ModernProcessor
 	txPool types.TxPool // Not *types.TxPool
 	// dagManager      *processor.DAGManager
+	sigVerifier *SignatureVerifier
 }
 
 func NewBlockchain(config *types.BlockchainConfig) (*BlockchainImpl, types.Store, error) {
 	// Initialize the database
-	database, err := store.NewDatabase(config.DataDir)
+	var database *store.Database
+	var err error
+	if config.InMemory {
+		database, err = store.NewInMemoryDatabase()
+	} else {
+		database, err = store.NewDatabase(config.DataDir)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to initialize the blockchain database: %v", err)
 	}
 
+	bc, storeInstance, err := NewBlockchainWithDatabase(config, database)
+	if err != nil {
+		database.Close()
+		return nil, nil, err
+	}
+	return bc, storeInstance, nil
+}
+
+// NewBlockchainWithDatabase opens the chain held in database, or creates it when the
+// database is empty. The database is left open when the chain cannot be opened; it is
+// closed by closing the returned store.
+func NewBlockchainWithDatabase(config *types.BlockchainConfig, database *store.Database) (*BlockchainImpl, types.Store, error) {
 	// Create the store instance
 	storeInstance, err := store.NewStore(database, config.AESKey)
 	if err != nil {
-		database.Close() // Clean up if store creation fails
 		return nil, nil, fmt.Errorf("failed to create store: %v", err)
 	}
 
@@ -52,6 +67,44 @@ func NewBlockchain(config *types.BlockchainConfig) (*BlockchainImpl, types.Store
 
 	log.Println("BlockchainDB created")
 
+	// Roll back a block whose commit was interrupted before reading the tip
+	if height, recovered, err := storeInstance.RecoverIncompleteBlock(); err != nil {
+		return nil, nil, fmt.Errorf("failed to recover partially applied block: %v", err)
+	} else if recovered {
+		log.Printf("Reverted partially applied block at height %d", height)
+	}
+
+	// Reload the persisted chain if the database already holds blocks
+	lastIndex, err := storeInstance.GetLastBlockNumber()
+	if err != nil && !errors.Is(err, store.ErrNoBlocks) {
+		return nil, nil, fmt.Errorf("failed to read the last block number: %v", err)
+	}
+	hasBlocks := err == nil
+	if config.Genesis != nil {
+		chainState, err := loadGenesisChain(config, storeInstance, lastIndex, hasBlocks)
+		if err != nil {
+			return nil, nil, err
+		}
+		bc, err := newBlockchainImpl(chainState, database)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Printf("Blockchain %s ready with %d blocks", chainState.ChainID, len(chainState.Blocks))
+		return bc, storeInstance, nil
+	}
+	if hasBlocks {
+		chainState, err := loadBlockchain(config, storeInstance, lastIndex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load the persisted blockchain: %v", err)
+		}
+		bc, err := newBlockchainImpl(chainState, database)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Printf("Loaded persisted blockchain with %d blocks", len(chainState.Blocks))
+		return bc, storeInstance, nil
+	}
+
 	// Create the genesis block
 	genesis := NewGenesisBlock()
 	log.Println("Genesis block created")
@@ -80,9 +133,6 @@ func NewBlockchain(config *types.BlockchainConfig) (*BlockchainImpl, types.Store
 	}
 
 	pubKey := privKey.PublicKey()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate genesis account key pair: %v", err)
-	}
 	log.Println("Genesis account key pair generated successfully")
 
 	// Create genesis transaction
@@ -104,40 +154,37 @@ func NewBlockchain(config *types.BlockchainConfig) (*BlockchainImpl, types.Store
 
 	genesis.Transactions = []*types.Transaction{utils.ConvertToSharedTransaction(genesisTx)}
 
+	// Commit the genesis block to its transaction
+	if err := InitializeVerkleTree(genesis); err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize genesis Verkle tree: %v", err)
+	}
+	ComputeBlockHash(genesis)
+
 	stateNetwork := network.NewDefaultNetwork()
 	// stateManager := state.NewStateManager(stateNetwork, 4)
 
 	log.Println("Genesis account private key stored and verified successfully")
 
 	// Create initial blockchain instance
-	temp := &BlockchainImpl{
-		Blockchain: &types.Blockchain{
-			Blocks:              []*types.Block{genesis},
-			Genesis:             genesis,
-			Stakeholders:        stakeholdersMap,
-			Database:            storeInstance, // Use storeInstance instead of database.Blockchain
-			PublicKeyMap:        publicKeyMap,
-			UTXOs:               utxoMap,
-			Forks:               make([]*types.Fork, 0),
-			GenesisAccount:      privKey,
-			PendingTransactions: make([]*thrylos.Transaction, 0),
-			ActiveValidators:    make([]string, 0),
-			StateNetwork:        stateNetwork,
-			TestMode:            config.TestMode,
-		},
-	}
-
-	// Create the propagator
-	propagator := &types.TransactionPropagator{
-		Blockchain: temp,
-		Mu:         sync.RWMutex{},
+	temp, err := newBlockchainImpl(&types.Blockchain{
+		Blocks:              []*types.Block{genesis},
+		Genesis:             genesis,
+		Stakeholders:        stakeholdersMap,
+		Database:            storeInstance, // Use storeInstance instead of database.Blockchain
+		PublicKeyMap:        publicKeyMap,
+		UTXOs:               utxoMap,
+		Forks:               make([]*types.Fork, 0),
+		MaxBlockTimeDrift:   maxBlockTimeDrift(config.MaxBlockTimeDrift),
+		GenesisAccount:      privKey,
+		PendingTransactions: make([]*thrylos.Transaction, 0),
+		ActiveValidators:    make([]string, 0),
+		StateNetwork:        stateNetwork,
+		TestMode:            config.TestMode,
+	}, database)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	temp.TransactionPropagator = propagator
-
-	// Create the transaction pool
-	temp.txPool = NewTxPool(database, temp)
-
 	// Add the blockchain public key to the publicKeyMap
 	publicKeyMap[addr.String()] = &pubKey
 	log.Println("Genesis account public key added to publicKeyMap")
@@ -153,7 +200,7 @@ func NewBlockchain(config *types.BlockchainConfig) (*BlockchainImpl, types.Store
 	// log.Printf("Total ActiveValidators: %d", len(blockchain.ActiveValidators))
 
 	// Save genesis block
-	if err := database.Blockchain.SaveBlock(genesis); err != nil {
+	if err := commitGenesisBlock(database.Blockchain, genesis); err != nil {
 		return nil, nil, fmt.Errorf("failed to add genesis block to the database: %v", err)
 	}
 
@@ -161,14 +208,6 @@ func NewBlockchain(config *types.BlockchainConfig) (*BlockchainImpl, types.Store
 
 	log.Println("NewBlockchain initialization completed successfully")
 
-	// Add shutdown handler for clean termination
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-		<-c
-		log.Println("Stopping blockchain...")
-	}()
-
 	// if !config.DisableBackground {
 	// 	// Start block creation routine
 	// 	go func() {
@@ -208,6 +247,55 @@ func NewBlockchain(config *types.BlockchainConfig) (*BlockchainImpl, types.Store
 	return temp, storeInstance, nil
 }
 
+// newBlockchainImpl wires the transaction propagator, signature verifier and pool
+// around the chain state.
+func newBlockchainImpl(chainState *types.Blockchain, database *store.Database) (*BlockchainImpl, error) {
+	bc := &BlockchainImpl{
+		Blockchain: chainState,
+	}
+
+	// Create the propagator
+	bc.TransactionPropagator = &types.TransactionPropagator{
+		Blockchain: bc,
+		Mu:         sync.RWMutex{},
+	}
+
+	// Signatures checked when a transaction enters the pool are not checked again in its block
+	verifier, err := NewSignatureVerifier(config.SignatureVerifyWorkers, config.VerifiedSignatureCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	bc.sigVerifier = verifier
+
+	// Create the transaction pool and restore the transactions pending before a restart
+	pool := NewTxPool(database.Blockchain, bc).(*txPoolImpl)
+	if _, err := pool.reload(); err != nil {
+		log.Printf("Failed to restore the transaction pool: %v", err)
+	}
+	bc.txPool = pool
+
+	// Add shutdown handler for clean termination
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		<-c
+		log.Println("Stopping blockchain...")
+	}()
+
+	return bc, nil
+}
+
+// SignatureVerifier returns the verifier that checks transaction signatures for the
+// pool and for incoming blocks.
+func (bc *BlockchainImpl) SignatureVerifier() *SignatureVerifier {
+	return bc.sigVerifier
+}
+
+// TxPool returns the pool of transactions waiting to be included in a block.
+func (bc *BlockchainImpl) TxPool() types.TxPool {
+	return bc.txPool
+}
+
 // // // ensuring that no blocks have been altered or inserted maliciously.
 func (bc *BlockchainImpl) CheckChainIntegrity() bool {
 	for i := 1; i < len(bc.Blockchain.Blocks); i++ {
@@ -251,33 +339,17 @@ func (bc *BlockchainImpl) Status() string {
 
 // Block functions
 func (bc *BlockchainImpl) GetLastBlock() (*types.Block, int, error) {
-	// Query the last block data and index
-	blockData, err := bc.Blockchain.Database.GetLastBlockData()
+	lastBlock, err := bc.Blockchain.Database.GetLastBlock()
 	if err != nil {
-		if err == sql.ErrNoRows {
-			// Handle no rows returned, which means the blockchain is empty
+		if errors.Is(err, store.ErrNoBlocks) {
+			// The blockchain is empty
 			return nil, 0, nil
 		}
 		return nil, 0, err
 	}
 
-	// Get the last block index
-	lastIndex, err := bc.Blockchain.Database.GetLastBlockIndex()
-	if err != nil {
-		return nil, 0, err
-	}
-
-	// Deserialize the block
-	var lastBlock types.Block
-	buffer := bytes.NewBuffer(blockData)
-	decoder := gob.NewDecoder(buffer)
-	err = decoder.Decode(&lastBlock)
-	if err != nil {
-		return nil, 0, err
-	}
-
 	// Return the block along with its index
-	return &lastBlock, lastIndex, nil
+	return lastBlock, int(lastBlock.Index), nil
 }
 
 func (bc *BlockchainImpl) GetBlockCount() int {
@@ -287,16 +359,11 @@ func (bc *BlockchainImpl) GetBlockCount() int {
 }
 
 func (bc *BlockchainImpl) GetBlock(blockNumber int) (*types.Block, error) {
-	blockData, err := bc.Blockchain.Database.RetrieveBlock(blockNumber)
+	block, err := bc.Blockchain.Database.GetBlock(uint32(blockNumber))
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve block data: %v", err)
 	}
-
-	var block types.Block
-	if err := json.Unmarshal(blockData, &block); err != nil { // Deserialize here
-		return nil, fmt.Errorf("failed to deserialize block: %v", err)
-	}
-	return &block, nil
+	return block, nil
 }
 
 // TO DO FIND WHERE VerifyTransaction IS AND SimulateValidatorSigning
@@ -304,59 +371,15 @@ func (bc *BlockchainImpl) AddBlock(transactions []*thrylos.Transaction, validato
 	bc.Blockchain.Mu.Lock()
 	defer bc.Blockchain.Mu.Unlock()
 
-	// Handle potential forks.
 	prevHashObj, err := hash.FromBytes(prevHash)
 	if err != nil {
 		return false, fmt.Errorf("invalid previous hash: %v", err)
 	}
 
+	// Locally produced blocks always extend the canonical tip; blocks for other
+	// branches arrive already signed through ProcessBlock
 	if len(bc.Blockchain.Blocks) > 0 && !bc.Blockchain.Blocks[len(bc.Blockchain.Blocks)-1].Hash.Equal(prevHashObj) {
-		var selectedFork *types.Fork
-		for _, fork := range bc.Blockchain.Forks {
-			if fork.Blocks != nil && len(fork.Blocks) > 0 {
-				if fork.Blocks[len(fork.Blocks)-1].Hash.Equal(prevHashObj) {
-					selectedFork = fork
-					break
-				}
-			}
-		}
-
-		// Create unsigned block for the fork
-		unsignedBlock, err := bc.CreateUnsignedBlock(transactions, validator)
-		if err != nil {
-			return false, fmt.Errorf("failed to create unsigned block: %v", err)
-		}
-
-		// Simulate validator signing
-		signedBlock, err := bc.SimulateValidatorSigning(unsignedBlock)
-		if err != nil {
-			return false, fmt.Errorf("failed to simulate block signing: %v", err)
-		}
-
-		// Verify the signed block
-		if err := bc.VerifySignedBlock(signedBlock); err != nil {
-			return false, fmt.Errorf("invalid signed block: %v", err)
-		}
-
-		blockData, err := json.Marshal(signedBlock)
-		if err != nil {
-			return false, fmt.Errorf("failed to serialize new block: %v", err)
-		}
-
-		blockNumber := len(bc.Blockchain.Blocks)
-		if selectedFork != nil {
-			selectedFork.Blocks = append(selectedFork.Blocks, signedBlock)
-			blockNumber = len(selectedFork.Blocks) - 1
-		} else {
-			bc.Blockchain.Blocks = append(bc.Blockchain.Blocks, signedBlock)
-			blockNumber = len(bc.Blockchain.Blocks) - 1
-		}
-
-		if err := bc.Blockchain.Database.StoreBlock(blockData, blockNumber); err != nil {
-			return false, fmt.Errorf("failed to store block in database: %v", err)
-		}
-
-		return true, nil
+		return false, fmt.Errorf("previous hash %x is not the canonical tip", prevHash)
 	}
 
 	// Verify transactions.
@@ -379,56 +402,11 @@ func (bc *BlockchainImpl) AddBlock(transactions []*thrylos.Transaction, validato
 		return false, fmt.Errorf("failed to simulate block signing: %v", err)
 	}
 
-	// Verify the signed block
-	if err := bc.VerifySignedBlock(signedBlock); err != nil {
-		return false, fmt.Errorf("invalid signed block: %v", err)
-	}
-
-	// Update UTXO set
-	// Update UTXO set
-	for _, tx := range signedBlock.Transactions {
-		// Remove spent UTXOs
-		for _, input := range tx.Inputs {
-			utxoKey := fmt.Sprintf("%s:%d", input.ID, input.Index)
-			delete(bc.Blockchain.UTXOs, utxoKey)
-		}
-		// Add new UTXOs
-		for index, output := range tx.Outputs {
-			utxoKey := fmt.Sprintf("%s:%d", tx.ID, index)
-			// Convert the types.UTXO to thrylos.UTXO with correct protobuf fields
-			thrylosUTXO := &thrylos.UTXO{
-				TransactionId: tx.ID,
-				Index:         int32(index),
-				OwnerAddress:  output.OwnerAddress,
-				Amount:        int64(output.Amount), // Cast amount.Amount to int64
-				IsSpent:       false,
-			}
-			bc.Blockchain.UTXOs[utxoKey] = []*thrylos.UTXO{thrylosUTXO}
-		}
+	// Locally produced blocks are checked like blocks received from other validators
+	if err := bc.processBlock(signedBlock); err != nil {
+		return false, err
 	}
 
-	// Serialize and store the block
-	blockData, err := json.Marshal(signedBlock)
-	if err != nil {
-		return false, fmt.Errorf("failed to serialize new block: %v", err)
-	}
-
-	blockNumber := len(bc.Blockchain.Blocks)
-	if err := bc.Blockchain.Database.StoreBlock(blockData, blockNumber); err != nil {
-		return false, fmt.Errorf("failed to store block in database: %v", err)
-	}
-
-	// Update the blockchain with the new block
-	bc.Blockchain.Blocks = append(bc.Blockchain.Blocks, signedBlock)
-	bc.Blockchain.LastTimestamp = signedBlock.Timestamp
-
-	if bc.Blockchain.OnNewBlock != nil {
-		bc.Blockchain.OnNewBlock(signedBlock)
-	}
-
-	// Update balances for affected addresses
-	bc.updateBalancesForBlock(signedBlock)
-
 	return true, nil
 }
 
@@ -456,7 +434,11 @@ func (bc *BlockchainImpl) GetBlockByID(id string) (*types.Block, error) { // Cha
 		return nil, fmt.Errorf("invalid hash bytes: %v", err)
 	}
 
-	// Iterate over blocks and find by hash
+	// Look the hash up in the store index, falling back to the in-memory blocks for
+	// chains stored before the index existed
+	if block, err := bc.Blockchain.Database.GetBlockByHash(idHash); err == nil {
+		return block, nil
+	}
 	for _, block := range bc.Blockchain.Blocks {
 		if block.Hash.Equal(idHash) { // Use the Equal method from Hash type
 			log.Printf("Block found by hash: Index=%d, Transactions=%v", block.Index, block.Transactions)
@@ -34,7 +34,10 @@ func generateBech32Address(publicKey *mldsa44.PublicKey) (string, error) {
 	return bech32Address, nil
 }
 
-// not sure if needed
+// GetChainID returns the chain ID fixed by the genesis file
 func (bc *BlockchainImpl) GetChainID() string {
+	if bc.Blockchain.ChainID != "" {
+		return bc.Blockchain.ChainID
+	}
 	return "tl1" // Mainnet (adjust as per your chain)
 }
@@ -74,7 +74,18 @@ import (
 // 	return signature, nil
 // }
 
+// VerifySignedBlock checks the block against the consensus rules, its hash, its Verkle
+// root and the signature of its validator, which must be in the active validator set.
+// Rule violations are returned as *BlockRuleError.
 func (bc *BlockchainImpl) VerifySignedBlock(signedBlock *types.Block) error {
+	if err := bc.checkBlockRules(signedBlock); err != nil {
+		return err
+	}
+
+	if !isActiveValidator(bc.Blockchain, signedBlock.Validator) {
+		return fmt.Errorf("%s is not an active validator", signedBlock.Validator)
+	}
+
 	// Store the original hash
 	originalHash := signedBlock.Hash
 
@@ -91,6 +102,11 @@ func (bc *BlockchainImpl) VerifySignedBlock(signedBlock *types.Block) error {
 		return errors.New("invalid block hash")
 	}
 
+	// The hash covers the Verkle root, which must in turn commit to the transactions
+	if err := verifyVerkleRoot(signedBlock); err != nil {
+		return err
+	}
+
 	publicKey, err := bc.GetValidatorPublicKey(signedBlock.Validator)
 	if err != nil {
 		log.Printf("Failed to get validator public key: %v", err)
@@ -171,14 +187,21 @@ func (bc *BlockchainImpl) CreateUnsignedBlock(transactions []*thrylos.Transactio
 		}
 	}
 
+	// Stay above the median time of the previous blocks when the clock lags behind them
+	timestamp := time.Now().Unix()
+	if median, ok := bc.medianTimePast(prevBlock.Hash); ok && timestamp <= median {
+		timestamp = median + 1
+	}
+
 	// Create new block
 	newBlock := &types.Block{
 		Index:        nextIndex,
-		Timestamp:    time.Now().Unix(),
+		Timestamp:    timestamp,
 		Transactions: sharedTransactions,
 		Validator:    validator,
 		PrevHash:     prevBlock.Hash,
 		Hash:         hash.NullHash(), // Initialize with null hash
+		Version:      types.BlockVersion,
 	}
 
 	// Initialize Verkle tree
@@ -0,0 +1,198 @@
+package chain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/address"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// ChainExportVersion is the version of the export stream format.
+const ChainExportVersion uint32 = 1
+
+// maxExportRecordSize bounds a single record of an export stream so a corrupt length
+// prefix cannot make the importer allocate arbitrary amounts of memory.
+const maxExportRecordSize = 64 << 20
+
+// ChainExportHeader is the first record of an export stream. It identifies the chain the
+// blocks belong to and carries the public keys of the validators that signed them, so a
+// node that never saw those validators can verify the blocks.
+type ChainExportHeader struct {
+	Version     uint32    `cbor:"1,keyasint"`
+	ChainID     string    `cbor:"2,keyasint"`
+	GenesisHash hash.Hash `cbor:"3,keyasint"`
+	Height      int64     `cbor:"4,keyasint"`
+	PublicKeys  [][]byte  `cbor:"5,keyasint,omitempty"`
+}
+
+// ExportChain writes the canonical chain to w as a stream of length-prefixed CBOR records:
+// a ChainExportHeader followed by every block from genesis to the tip. It returns the
+// number of blocks written.
+func (bc *BlockchainImpl) ExportChain(w io.Writer) (int, error) {
+	bc.Blockchain.Mu.RLock()
+	defer bc.Blockchain.Mu.RUnlock()
+
+	blocks := bc.Blockchain.Blocks
+	header := ChainExportHeader{
+		Version:     ChainExportVersion,
+		ChainID:     bc.GetChainID(),
+		GenesisHash: bc.Blockchain.Genesis.Hash,
+		Height:      blocks[len(blocks)-1].Index,
+	}
+
+	seen := make(map[string]bool)
+	for _, block := range blocks[1:] {
+		if seen[block.Validator] {
+			continue
+		}
+		seen[block.Validator] = true
+		validatorAddr, err := address.FromString(block.Validator)
+		if err != nil {
+			return 0, fmt.Errorf("invalid validator address in block %d: %v", block.Index, err)
+		}
+		pubKey, err := bc.Blockchain.Database.GetPublicKey(*validatorAddr)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get public key of validator %s: %v", block.Validator, err)
+		}
+		header.PublicKeys = append(header.PublicKeys, pubKey.Bytes())
+	}
+
+	headerData, err := cbor.Marshal(&header)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode export header: %v", err)
+	}
+	if err := writeExportRecord(w, headerData); err != nil {
+		return 0, err
+	}
+
+	for i, block := range blocks {
+		blockData, err := block.Marshal()
+		if err != nil {
+			return i, fmt.Errorf("failed to encode block %d: %v", block.Index, err)
+		}
+		if err := writeExportRecord(w, blockData); err != nil {
+			return i, err
+		}
+	}
+	return len(blocks), nil
+}
+
+// ImportChain reads an export stream written by ExportChain and adds its blocks to the
+// chain. The stream must come from the same chain, and every block is checked and
+// connected like any block received from a peer. Blocks the chain already
+// holds are skipped, so an interrupted import can be resumed. It returns the number of
+// blocks added.
+func (bc *BlockchainImpl) ImportChain(r io.Reader) (int, error) {
+	headerData, err := readExportRecord(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read export header: %v", err)
+	}
+	var header ChainExportHeader
+	if err := cbor.Unmarshal(headerData, &header); err != nil {
+		return 0, fmt.Errorf("failed to decode export header: %v", err)
+	}
+	if header.Version != ChainExportVersion {
+		return 0, fmt.Errorf("unsupported export version %d", header.Version)
+	}
+	if header.ChainID != bc.GetChainID() {
+		return 0, fmt.Errorf("export is for chain %s, not %s", header.ChainID, bc.GetChainID())
+	}
+	if !header.GenesisHash.Equal(bc.Blockchain.Genesis.Hash) {
+		return 0, fmt.Errorf("export genesis hash %s does not match %s", header.GenesisHash.String(), bc.Blockchain.Genesis.Hash.String())
+	}
+
+	// Public keys are bound to validator addresses, so they can be stored as they are
+	for _, keyData := range header.PublicKeys {
+		pubKey, err := crypto.NewPublicKeyFromBytes(keyData)
+		if err != nil {
+			return 0, fmt.Errorf("invalid validator public key in export: %v", err)
+		}
+		if err := bc.Blockchain.Database.SavePublicKey(pubKey); err != nil {
+			return 0, fmt.Errorf("failed to store validator public key: %v", err)
+		}
+	}
+
+	imported := 0
+	for {
+		blockData, err := readExportRecord(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, fmt.Errorf("failed to read block: %v", err)
+		}
+		var block types.Block
+		if err := block.Unmarshal(blockData); err != nil {
+			return imported, fmt.Errorf("failed to decode block: %v", err)
+		}
+
+		added, err := bc.importBlock(&block)
+		if err != nil {
+			return imported, fmt.Errorf("block %d rejected: %v", block.Index, err)
+		}
+		if added {
+			imported++
+		}
+	}
+
+	log.Printf("Imported %d blocks, chain height is %d", imported, len(bc.Blockchain.Blocks)-1)
+	return imported, nil
+}
+
+// importBlock connects a block with processBlock, under the same lock as the check
+// that it extends the tip. It reports false for blocks already on the canonical chain.
+func (bc *BlockchainImpl) importBlock(block *types.Block) (bool, error) {
+	bc.Blockchain.Mu.Lock()
+	defer bc.Blockchain.Mu.Unlock()
+
+	if bc.canonicalHeight(block.Hash) >= 0 {
+		return false, nil
+	}
+	tip := bc.Blockchain.Blocks[len(bc.Blockchain.Blocks)-1]
+	if !block.PrevHash.Equal(tip.Hash) {
+		return false, fmt.Errorf("block does not extend the chain tip %d", tip.Index)
+	}
+	if err := bc.processBlock(block); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func writeExportRecord(w io.Writer, data []byte) error {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	if _, err := w.Write(length[:]); err != nil {
+		return fmt.Errorf("failed to write record length: %v", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write record: %v", err)
+	}
+	return nil
+}
+
+// readExportRecord reads one record, returning io.EOF at the clean end of the stream.
+func readExportRecord(r io.Reader) ([]byte, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read record length: %v", err)
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if size > maxExportRecordSize {
+		return nil, fmt.Errorf("record of %d bytes exceeds the limit of %d bytes", size, maxExportRecordSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("truncated record: %v", err)
+	}
+	return data, nil
+}
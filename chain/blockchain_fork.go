@@ -0,0 +1,381 @@
+package chain
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	thrylos "github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// FinalityDepth is the number of blocks after which a canonical block is final.
+// Branches forking off below a final block are never adopted.
+const FinalityDepth = 64
+
+// ProcessBlock accepts a block signed by any validator. Blocks extending the canonical
+// tip are connected directly, blocks building on an older block or on a side branch
+// are kept in Forks, and the chain reorganizes when a side branch becomes heavier
+// than the canonical branch it competes with.
+func (bc *BlockchainImpl) ProcessBlock(block *types.Block) error {
+	bc.Blockchain.Mu.Lock()
+	defer bc.Blockchain.Mu.Unlock()
+	return bc.processBlock(block)
+}
+
+// processBlock is ProcessBlock for callers holding the chain lock.
+func (bc *BlockchainImpl) processBlock(block *types.Block) error {
+	if bc.hasBlock(block.Hash) {
+		return fmt.Errorf("block %s is already known", block.Hash.String())
+	}
+
+	if err := bc.VerifySignedBlock(block); err != nil {
+		return fmt.Errorf("invalid signed block: %w", err)
+	}
+	if err := bc.sigVerifier.VerifyBlock(block, bc.GetChainID()); err != nil {
+		return fmt.Errorf("block %d has an invalid transaction signature: %w", block.Index, err)
+	}
+	// Only the genesis block creates outputs without inputs. Spends are checked against
+	// the UTXO set when the block is connected, on the tip or during a reorganization.
+	for _, tx := range block.Transactions {
+		if len(tx.Inputs) == 0 {
+			return fmt.Errorf("block %d: transaction %s spends no inputs", block.Index, tx.ID)
+		}
+	}
+
+	tip := bc.Blockchain.Blocks[len(bc.Blockchain.Blocks)-1]
+	if block.PrevHash.Equal(tip.Hash) {
+		if block.Index != tip.Index+1 {
+			return fmt.Errorf("block index %d does not follow the tip index %d", block.Index, tip.Index)
+		}
+		if err := checkBlockSpends(bc.Blockchain.UTXOs, block); err != nil {
+			return fmt.Errorf("block %d has an invalid spend: %w", block.Index, err)
+		}
+		return bc.connectBlock(block)
+	}
+
+	fork, err := bc.addToFork(block)
+	if err != nil {
+		return err
+	}
+	return bc.resolveFork(fork)
+}
+
+// connectBlock appends a verified block to the canonical chain, applies its UTXO
+// changes and commits the block, its UTXO changes and its undo record in one step.
+func (bc *BlockchainImpl) connectBlock(block *types.Block) error {
+	undo := applyBlockToUTXOs(bc.Blockchain.UTXOs, block)
+	if err := applyBlockStaking(bc.Blockchain, block, undo); err != nil {
+		revertBlockUndo(bc.Blockchain.UTXOs, undo)
+		return fmt.Errorf("block %d: %v", block.Index, err)
+	}
+
+	if err := bc.Blockchain.Database.CommitBlock(block, undo.Spent, undo.Created, undo); err != nil {
+		revertBlockStaking(bc.Blockchain, block)
+		revertBlockUndo(bc.Blockchain.UTXOs, undo)
+		return fmt.Errorf("failed to store block in database: %v", err)
+	}
+
+	// Update the blockchain with the new block
+	bc.Blockchain.Blocks = append(bc.Blockchain.Blocks, block)
+	bc.Blockchain.LastTimestamp = block.Timestamp
+
+	if bc.Blockchain.OnNewBlock != nil {
+		bc.Blockchain.OnNewBlock(block)
+	}
+
+	// Update balances for affected addresses
+	bc.updateBalancesForBlock(block)
+	bc.removeMinedTransactions(block)
+	return nil
+}
+
+// removeMinedTransactions drops the transactions of a connected block from the pool.
+// The pool never reads the chain while holding its own lock, so this is safe to call
+// with the chain lock held.
+func (bc *BlockchainImpl) removeMinedTransactions(block *types.Block) {
+	if bc.txPool == nil {
+		return
+	}
+	if err := bc.txPool.RemoveBlockTransactions(block); err != nil {
+		log.Printf("Failed to remove the transactions of block %d from the pool: %v", block.Index, err)
+	}
+}
+
+// addToFork records a block that does not extend the canonical tip, either by
+// extending an existing side branch or by opening a new one.
+func (bc *BlockchainImpl) addToFork(block *types.Block) (*types.Fork, error) {
+	// Extend or branch off an existing side branch
+	for _, fork := range bc.Blockchain.Forks {
+		for i, forkBlock := range fork.Blocks {
+			if !forkBlock.Hash.Equal(block.PrevHash) {
+				continue
+			}
+			if block.Index != forkBlock.Index+1 {
+				return nil, fmt.Errorf("block index %d does not follow its parent index %d", block.Index, forkBlock.Index)
+			}
+			if i == len(fork.Blocks)-1 {
+				fork.Blocks = append(fork.Blocks, block)
+				return fork, nil
+			}
+			branch := &types.Fork{
+				Index:  fork.Index,
+				Blocks: append(append([]*types.Block{}, fork.Blocks[:i+1]...), block),
+			}
+			bc.Blockchain.Forks = append(bc.Blockchain.Forks, branch)
+			return branch, nil
+		}
+	}
+
+	// Branch off the canonical chain
+	parentHeight := bc.canonicalHeight(block.PrevHash)
+	if parentHeight < 0 {
+		return nil, fmt.Errorf("unknown parent block %s", block.PrevHash.String())
+	}
+	if block.Index != int64(parentHeight)+1 {
+		return nil, fmt.Errorf("block index %d does not follow its parent index %d", block.Index, parentHeight)
+	}
+	tipHeight := len(bc.Blockchain.Blocks) - 1
+	if tipHeight-parentHeight > FinalityDepth {
+		return nil, fmt.Errorf("block %d conflicts with finalized block %d", block.Index, parentHeight+1)
+	}
+
+	fork := &types.Fork{
+		Index:  parentHeight + 1,
+		Blocks: []*types.Block{block},
+	}
+	bc.Blockchain.Forks = append(bc.Blockchain.Forks, fork)
+	log.Printf("Block %d (%s) opened a side branch at height %d", block.Index, block.Hash.String(), fork.Index)
+	return fork, nil
+}
+
+// resolveFork applies the fork-choice rule: the branch with the highest accumulated
+// validator stake wins, ties keep the canonical branch, and branches forking off
+// below a finalized block are never adopted.
+func (bc *BlockchainImpl) resolveFork(fork *types.Fork) error {
+	tipHeight := len(bc.Blockchain.Blocks) - 1
+	if tipHeight-fork.Index >= FinalityDepth {
+		return nil
+	}
+
+	forkWeight := bc.branchWeight(fork.Blocks)
+	canonicalWeight := bc.branchWeight(bc.Blockchain.Blocks[fork.Index:])
+	if forkWeight.Cmp(canonicalWeight) <= 0 {
+		log.Printf("Side branch at height %d kept: weight %d, canonical weight %d", fork.Index, forkWeight, canonicalWeight)
+		return nil
+	}
+
+	log.Printf("Reorganizing at height %d: branch weight %d exceeds canonical weight %d", fork.Index, forkWeight, canonicalWeight)
+	return bc.reorganize(fork)
+}
+
+// reorganize makes the side branch canonical. Canonical blocks above the fork point
+// are disconnected using their undo records and kept as a side branch, then the
+// branch blocks are connected. If a branch block spends unavailable outputs the
+// previous canonical chain is restored and the branch is dropped. If the store cannot
+// be switched to the branch, the chain and the store stay on the previous branch and
+// the branch is kept as a side branch.
+func (bc *BlockchainImpl) reorganize(fork *types.Fork) error {
+	detached := append([]*types.Block{}, bc.Blockchain.Blocks[fork.Index:]...)
+
+	// Load every undo record first so a missing one leaves the chain untouched
+	undos := make([]*types.BlockUndo, len(detached))
+	for i, block := range detached {
+		undo, err := bc.Blockchain.Database.GetBlockUndo(block.Hash)
+		if err != nil {
+			return fmt.Errorf("cannot disconnect block %d: %v", block.Index, err)
+		}
+		undos[i] = undo
+	}
+
+	// Disconnect canonical blocks from the tip down to the fork point
+	for i := len(detached) - 1; i >= 0; i-- {
+		revertBlockStaking(bc.Blockchain, detached[i])
+		revertBlockUndo(bc.Blockchain.UTXOs, undos[i])
+	}
+	bc.Blockchain.Blocks = bc.Blockchain.Blocks[:fork.Index]
+
+	branchUndos, err := connectBranch(bc.Blockchain, fork.Blocks)
+	if err != nil {
+		// Restore the previous canonical chain
+		if _, restoreErr := connectBranch(bc.Blockchain, detached); restoreErr != nil {
+			return fmt.Errorf("failed to restore canonical chain after invalid branch: %v", restoreErr)
+		}
+		bc.removeFork(fork)
+		return fmt.Errorf("side branch at height %d rejected: %v", fork.Index, err)
+	}
+
+	if err := switchStoredBranch(bc.Blockchain.Database, detached, undos, fork.Blocks, branchUndos); err != nil {
+		// The store is back on the previous canonical branch, so follow it in memory
+		for i := len(fork.Blocks) - 1; i >= 0; i-- {
+			revertBlockStaking(bc.Blockchain, fork.Blocks[i])
+			revertBlockUndo(bc.Blockchain.UTXOs, branchUndos[i])
+		}
+		bc.Blockchain.Blocks = bc.Blockchain.Blocks[:fork.Index]
+		if _, restoreErr := connectBranch(bc.Blockchain, detached); restoreErr != nil {
+			return fmt.Errorf("failed to restore canonical chain after %v: %v", err, restoreErr)
+		}
+		return err
+	}
+
+	// The previous canonical blocks become the side branch
+	fork.Blocks = detached
+	bc.Blockchain.LastTimestamp = bc.Blockchain.Blocks[len(bc.Blockchain.Blocks)-1].Timestamp
+
+	for _, undo := range branchUndos {
+		block := bc.Blockchain.Blocks[undo.Height]
+		if bc.Blockchain.OnNewBlock != nil {
+			bc.Blockchain.OnNewBlock(block)
+		}
+		bc.updateBalancesForBlock(block)
+		bc.removeMinedTransactions(block)
+	}
+
+	log.Printf("Reorganization complete: new tip %d (%s)", len(bc.Blockchain.Blocks)-1,
+		bc.Blockchain.Blocks[len(bc.Blockchain.Blocks)-1].Hash.String())
+	return nil
+}
+
+// switchStoredBranch disconnects the detached blocks from the store, from the tip down,
+// and commits the branch blocks. If a write fails, the blocks already switched are
+// switched back so the store stays on the previous canonical branch.
+func switchStoredBranch(db types.Store, detached []*types.Block, detachedUndos []*types.BlockUndo, branch []*types.Block, branchUndos []*types.BlockUndo) error {
+	disconnected, committed := 0, 0
+	restore := func(cause error) error {
+		for i := committed - 1; i >= 0; i-- {
+			if err := db.DisconnectBlock(branchUndos[i]); err != nil {
+				return fmt.Errorf("%v (restoring the store failed: %v)", cause, err)
+			}
+		}
+		for i := len(detached) - disconnected; i < len(detached); i++ {
+			undo := detachedUndos[i]
+			if err := db.CommitBlock(detached[i], undo.Spent, undo.Created, undo); err != nil {
+				return fmt.Errorf("%v (restoring the store failed: %v)", cause, err)
+			}
+		}
+		return cause
+	}
+
+	for i := len(detached) - 1; i >= 0; i-- {
+		if err := db.DisconnectBlock(detachedUndos[i]); err != nil {
+			return restore(fmt.Errorf("failed to disconnect block %d during reorganization: %v", detached[i].Index, err))
+		}
+		disconnected++
+	}
+	for i, block := range branch {
+		undo := branchUndos[i]
+		if err := db.CommitBlock(block, undo.Spent, undo.Created, undo); err != nil {
+			return restore(fmt.Errorf("failed to store block %d during reorganization: %v", block.Index, err))
+		}
+		committed++
+	}
+	return nil
+}
+
+// connectBranch applies blocks on top of the in-memory chain. If a block spends an
+// output that is not available or carries an invalid staking transaction, the blocks
+// already applied are disconnected again.
+func connectBranch(chain *types.Blockchain, blocks []*types.Block) ([]*types.BlockUndo, error) {
+	undos := make([]*types.BlockUndo, 0, len(blocks))
+	rollback := func() {
+		for i := len(undos) - 1; i >= 0; i-- {
+			revertBlockStaking(chain, blocks[i])
+			revertBlockUndo(chain.UTXOs, undos[i])
+		}
+		chain.Blocks = chain.Blocks[:len(chain.Blocks)-len(undos)]
+	}
+	for _, block := range blocks {
+		if err := checkBlockSpends(chain.UTXOs, block); err != nil {
+			rollback()
+			return nil, fmt.Errorf("block %d has an invalid spend: %w", block.Index, err)
+		}
+		undo := applyBlockToUTXOs(chain.UTXOs, block)
+		if err := applyBlockStaking(chain, block, undo); err != nil {
+			revertBlockUndo(chain.UTXOs, undo)
+			rollback()
+			return nil, fmt.Errorf("block %d: %v", block.Index, err)
+		}
+		undos = append(undos, undo)
+		chain.Blocks = append(chain.Blocks, block)
+	}
+	return undos, nil
+}
+
+// checkBlockSpends verifies the spends of every transaction of the block in order with
+// verifyTransactionSpends: inputs must be unspent, either in the UTXO set or created
+// earlier in the same block, unlocked, owned by the sender and spent only once, and
+// outputs plus fee must not exceed them. The checks run on a scratch view holding the
+// outputs the block spends, so utxos is not modified.
+func checkBlockSpends(utxos map[string][]*thrylos.UTXO, block *types.Block) error {
+	view := make(map[string][]*thrylos.UTXO)
+	for _, tx := range block.Transactions {
+		for _, input := range tx.Inputs {
+			utxoKey := fmt.Sprintf("%s:%d", input.TransactionID, input.Index)
+			if outputs, ok := utxos[utxoKey]; ok {
+				view[utxoKey] = outputs
+			}
+		}
+	}
+	undo := &types.BlockUndo{BlockHash: block.Hash, Height: block.Index}
+	for _, tx := range block.Transactions {
+		if err := verifyTransactionSpends(view, block, tx); err != nil {
+			return fmt.Errorf("transaction %s: %w", tx.ID, err)
+		}
+		applyTransactionToUTXOs(view, tx, undo)
+	}
+	return nil
+}
+
+// checkOutputLock returns an error wrapping types.ErrOutputLocked when the output
+// spent by input is still locked at the height and timestamp of block.
+func checkOutputLock(output *thrylos.UTXO, input types.UTXO, block *types.Block) error {
+	record := ConvertProtoUTXOToShared(output)
+	record.TransactionID = input.TransactionID
+	record.Index = input.Index
+	return record.CheckLock(block.Index, block.Timestamp)
+}
+
+// branchWeight sums the stake bonded to the validators that produced the blocks. Every
+// block weighs at least 1 so that, among validators without stake, the longer branch wins.
+func (bc *BlockchainImpl) branchWeight(blocks []*types.Block) *big.Int {
+	weight := new(big.Int)
+	for _, block := range blocks {
+		stake := bc.Blockchain.BondedStake[block.Validator]
+		if stake < 1 {
+			stake = 1
+		}
+		weight.Add(weight, big.NewInt(stake.ToNanoTHR()))
+	}
+	return weight
+}
+
+// canonicalHeight returns the height of the canonical block with the given hash, or -1.
+func (bc *BlockchainImpl) canonicalHeight(blockHash hash.Hash) int {
+	for i := len(bc.Blockchain.Blocks) - 1; i >= 0; i-- {
+		if bc.Blockchain.Blocks[i].Hash.Equal(blockHash) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (bc *BlockchainImpl) hasBlock(blockHash hash.Hash) bool {
+	return bc.findBlock(blockHash) != nil
+}
+
+func (bc *BlockchainImpl) removeFork(fork *types.Fork) {
+	for i, f := range bc.Blockchain.Forks {
+		if f == fork {
+			bc.Blockchain.Forks = append(bc.Blockchain.Forks[:i], bc.Blockchain.Forks[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetForks returns the side branches currently tracked next to the canonical chain.
+func (bc *BlockchainImpl) GetForks() []*types.Fork {
+	bc.Blockchain.Mu.RLock()
+	defer bc.Blockchain.Mu.RUnlock()
+	return bc.Blockchain.Forks
+}
@@ -0,0 +1,51 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// GetHeader returns the header of the block at the given height.
+func (bc *BlockchainImpl) GetHeader(blockNumber int) (*types.BlockHeader, error) {
+	if blockNumber < 0 {
+		return nil, fmt.Errorf("invalid block number %d", blockNumber)
+	}
+	header, err := bc.Blockchain.Database.GetHeader(uint32(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve header: %v", err)
+	}
+	return header, nil
+}
+
+// GetHeaderRange returns the headers from start to end inclusive, so peers and light
+// clients can follow the chain of hashes without downloading block bodies.
+func (bc *BlockchainImpl) GetHeaderRange(start, end int) ([]*types.BlockHeader, error) {
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid header range %d-%d", start, end)
+	}
+	headers, err := bc.Blockchain.Database.GetHeaderRange(uint32(start), uint32(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve headers: %v", err)
+	}
+	return headers, nil
+}
+
+// VerifyHeaderChain checks that consecutive headers are linked by their hashes. The first
+// header's PrevHash is left to the caller, who anchors it to a trusted header.
+func VerifyHeaderChain(headers []*types.BlockHeader) error {
+	for i := 1; i < len(headers); i++ {
+		prev, current := headers[i-1], headers[i]
+		if current.Index != prev.Index+1 {
+			return fmt.Errorf("header %d does not follow header %d", current.Index, prev.Index)
+		}
+		prevHash, err := prev.Hash()
+		if err != nil {
+			return fmt.Errorf("failed to hash header %d: %v", prev.Index, err)
+		}
+		if !current.PrevHash.Equal(prevHash) {
+			return fmt.Errorf("header %d does not link to header %d", current.Index, prev.Index)
+		}
+	}
+	return nil
+}
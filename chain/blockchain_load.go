@@ -0,0 +1,235 @@
+package chain
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	thrylos "github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/network"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// loadBlockchain rebuilds the in-memory chain state from the blocks persisted in the store.
+// Blocks are read from genesis up to lastIndex and replayed to recover the UTXO set, the
+// stakeholder balances are summed from it and the public keys are read back from disk.
+func loadBlockchain(config *types.BlockchainConfig, storeInstance types.Store, lastIndex int) (*types.Blockchain, error) {
+	blocks := make([]*types.Block, 0, lastIndex+1)
+	for i := 0; i <= lastIndex; i++ {
+		block, err := storeInstance.GetBlock(uint32(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load block %d: %v", i, err)
+		}
+		if i > 0 && !block.PrevHash.Equal(blocks[i-1].Hash) {
+			return nil, fmt.Errorf("block %d does not link to block %d", i, i-1)
+		}
+		blocks = append(blocks, block)
+	}
+	genesis := blocks[0]
+
+	utxoMap := make(map[string][]*thrylos.UTXO)
+	undos := make([]*types.BlockUndo, len(blocks))
+	for i, block := range blocks {
+		undos[i] = applyBlockToUTXOs(utxoMap, block)
+	}
+
+	stakeholdersMap, err := stakeholdersFromUTXOs(utxoMap)
+	if err != nil {
+		return nil, err
+	}
+
+	storedKeys, err := storeInstance.GetAllPublicKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load public keys: %v", err)
+	}
+	publicKeyMap := make(map[string]*crypto.PublicKey, len(storedKeys))
+	for addr, pubKey := range storedKeys {
+		pubKey := pubKey
+		publicKeyMap[addr] = &pubKey
+	}
+	log.Printf("Rebuilt state from %d blocks: %d UTXOs, %d stakeholders, %d public keys",
+		len(blocks), len(utxoMap), len(stakeholdersMap), len(publicKeyMap))
+
+	chainState := &types.Blockchain{
+		Blocks:              blocks,
+		Genesis:             genesis,
+		Stakeholders:        stakeholdersMap,
+		Database:            storeInstance,
+		PublicKeyMap:        publicKeyMap,
+		UTXOs:               utxoMap,
+		Forks:               make([]*types.Fork, 0),
+		LastTimestamp:       blocks[len(blocks)-1].Timestamp,
+		MaxBlockTimeDrift:   maxBlockTimeDrift(config.MaxBlockTimeDrift),
+		GenesisAccount:      config.GenesisAccount,
+		PendingTransactions: make([]*thrylos.Transaction, 0),
+		ActiveValidators:    make([]string, 0),
+		StateNetwork:        network.NewDefaultNetwork(),
+		TestMode:            config.TestMode,
+	}
+
+	// Staking transactions are checked against the genesis validator set and minimum
+	// stake. Genesis validators start with the stake locked in the genesis block.
+	if config.Genesis != nil {
+		chainState.MinStakeForValidator = big.NewInt(config.Genesis.Params.MinimumStakeAmount)
+		for _, v := range config.Genesis.Validators {
+			chainState.ActiveValidators = append(chainState.ActiveValidators, v.Address)
+			if err := bondStake(chainState, v.Address, v.Address, amount.Amount(v.Stake)); err != nil {
+				return nil, fmt.Errorf("genesis validator %s: %v", v.Address, err)
+			}
+		}
+	}
+	for i, block := range blocks {
+		if err := applyBlockStaking(chainState, block, undos[i]); err != nil {
+			return nil, fmt.Errorf("failed to replay block %d: %v", block.Index, err)
+		}
+	}
+	return chainState, nil
+}
+
+// stakeholdersFromUTXOs sums the unspent outputs of every address, the balances
+// connectBlock keeps in Stakeholders as blocks are added.
+func stakeholdersFromUTXOs(utxos map[string][]*thrylos.UTXO) (map[string]int64, error) {
+	balances := make(map[string]amount.Amount)
+	for utxoKey, outputs := range utxos {
+		for _, output := range outputs {
+			balance, err := balances[output.OwnerAddress].Add(amount.Amount(output.Amount))
+			if err != nil {
+				return nil, fmt.Errorf("balance of %s at output %s: %w", output.OwnerAddress, utxoKey, err)
+			}
+			balances[output.OwnerAddress] = balance
+		}
+	}
+	stakeholders := make(map[string]int64, len(balances))
+	for owner, balance := range balances {
+		stakeholders[owner] = int64(balance)
+	}
+	return stakeholders, nil
+}
+
+// commitGenesisBlock stores the genesis block together with the outputs it creates.
+func commitGenesisBlock(s types.Store, genesis *types.Block) error {
+	undo := applyBlockToUTXOs(make(map[string][]*thrylos.UTXO), genesis)
+	return s.CommitBlock(genesis, undo.Spent, undo.Created, undo)
+}
+
+// applyBlockToUTXOs removes the outputs spent by the block's transactions from the
+// UTXO set and adds the outputs they create. The returned undo record describes the
+// changes so they can be reverted with revertBlockUndo.
+func applyBlockToUTXOs(utxos map[string][]*thrylos.UTXO, block *types.Block) *types.BlockUndo {
+	undo := &types.BlockUndo{
+		BlockHash: block.Hash,
+		Height:    block.Index,
+	}
+	for _, tx := range block.Transactions {
+		applyTransactionToUTXOs(utxos, tx, undo)
+	}
+	return undo
+}
+
+// applyTransactionToUTXOs spends the inputs and adds the outputs of a transaction,
+// recording both in undo.
+func applyTransactionToUTXOs(utxos map[string][]*thrylos.UTXO, tx *types.Transaction, undo *types.BlockUndo) {
+	// Remove spent UTXOs
+	for _, input := range tx.Inputs {
+		utxoKey := fmt.Sprintf("%s:%d", input.TransactionID, input.Index)
+		for _, spent := range utxos[utxoKey] {
+			// Stored entries do not always carry their outpoint, so take it from the input
+			record := ConvertProtoUTXOToShared(spent)
+			record.TransactionID = input.TransactionID
+			record.Index = input.Index
+			undo.Spent = append(undo.Spent, record)
+		}
+		delete(utxos, utxoKey)
+	}
+	// Add new UTXOs
+	for index, output := range tx.Outputs {
+		created := types.UTXO{
+			TransactionID:   tx.ID,
+			Index:           index,
+			OwnerAddress:    output.OwnerAddress,
+			Amount:          output.Amount,
+			LockUntilHeight: output.LockUntilHeight,
+			LockUntilTime:   output.LockUntilTime,
+		}
+		undo.Created = append(undo.Created, created)
+		utxos[fmt.Sprintf("%s:%d", tx.ID, index)] = []*thrylos.UTXO{sharedUTXOToProto(created)}
+	}
+}
+
+// revertBlockUndo restores the UTXO set to its state before the block was applied.
+// Spent outputs are restored first so outputs created and spent within the same
+// block are removed again with the created ones.
+func revertBlockUndo(utxos map[string][]*thrylos.UTXO, undo *types.BlockUndo) {
+	for _, spent := range undo.Spent {
+		utxoKey := fmt.Sprintf("%s:%d", spent.TransactionID, spent.Index)
+		utxos[utxoKey] = append(utxos[utxoKey], sharedUTXOToProto(spent))
+	}
+	for _, created := range undo.Created {
+		delete(utxos, fmt.Sprintf("%s:%d", created.TransactionID, created.Index))
+	}
+}
+
+func sharedUTXOToProto(utxo types.UTXO) *thrylos.UTXO {
+	return &thrylos.UTXO{
+		TransactionId:   utxo.TransactionID,
+		Index:           int32(utxo.Index),
+		OwnerAddress:    utxo.OwnerAddress,
+		Amount:          int64(utxo.Amount),
+		IsSpent:         false,
+		LockUntilHeight: utxo.LockUntilHeight,
+		LockUntilTime:   utxo.LockUntilTime,
+	}
+}
+
+// loadGenesisChain opens a chain described by a genesis file. An empty database is
+// initialised with the deterministic genesis block, while an existing one must hold
+// a block 0 whose hash matches the genesis file.
+func loadGenesisChain(config *types.BlockchainConfig, storeInstance types.Store, lastIndex int, hasBlocks bool) (*types.Blockchain, error) {
+	genesis := config.Genesis
+	expectedHash, err := GenesisHash(genesis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute genesis hash: %v", err)
+	}
+
+	validatorKeys, err := GenesisValidatorKeys(genesis)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasBlocks {
+		genesisBlock, err := NewGenesisBlockFromConfig(genesis)
+		if err != nil {
+			return nil, err
+		}
+		if err := commitGenesisBlock(storeInstance, genesisBlock); err != nil {
+			return nil, fmt.Errorf("failed to add genesis block to the database: %v", err)
+		}
+		for _, pubKey := range validatorKeys {
+			if err := storeInstance.SavePublicKey(pubKey); err != nil {
+				return nil, fmt.Errorf("failed to store genesis validator public key: %v", err)
+			}
+		}
+		lastIndex = 0
+		log.Printf("Genesis block for chain %s created with hash %s", genesis.ChainID, expectedHash.String())
+	}
+
+	chainState, err := loadBlockchain(config, storeInstance, lastIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the persisted blockchain: %v", err)
+	}
+
+	if !chainState.Genesis.Hash.Equal(expectedHash) {
+		return nil, fmt.Errorf("genesis hash mismatch: database has %s, genesis file for chain %s expects %s",
+			chainState.Genesis.Hash.String(), genesis.ChainID, expectedHash.String())
+	}
+
+	chainState.ChainID = genesis.ChainID
+	for _, v := range genesis.Validators {
+		pubKey := validatorKeys[v.Address]
+		chainState.PublicKeyMap[v.Address] = &pubKey
+	}
+
+	return chainState, nil
+}
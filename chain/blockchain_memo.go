@@ -0,0 +1,28 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// GetTransactionsByMemo returns a page of the confirmed transactions in s carrying
+// exactly memo, in chain order.
+func GetTransactionsByMemo(s types.Store, memo []byte, offset, limit int) ([]*types.Transaction, error) {
+	locations, err := s.GetTransactionsByMemo(memo, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*types.Transaction, 0, len(locations))
+	for _, loc := range locations {
+		block, err := s.GetBlock(uint32(loc.BlockHeight))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read block %d: %v", loc.BlockHeight, err)
+		}
+		if loc.Position >= len(block.Transactions) || block.Transactions[loc.Position].ID != loc.TxID {
+			return nil, fmt.Errorf("memo index entry for transaction %s is stale", loc.TxID)
+		}
+		txs = append(txs, block.Transactions[loc.Position])
+	}
+	return txs, nil
+}
@@ -0,0 +1,302 @@
+package chain
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// applyBlockStaking processes the typed transactions of a block whose UTXO changes
+// are recorded in undo. If a transaction is invalid the staking changes made by the
+// block are reverted and the error is returned.
+func applyBlockStaking(state *types.Blockchain, block *types.Block, undo *types.BlockUndo) error {
+	spent := make(map[string]types.UTXO, len(undo.Spent))
+	for _, utxo := range undo.Spent {
+		spent[fmt.Sprintf("%s:%d", utxo.TransactionID, utxo.Index)] = utxo
+	}
+	for i, tx := range block.Transactions {
+		if err := applyStakingTransaction(state, tx, spent); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				revertStakingTransaction(state, block.Transactions[j])
+			}
+			return fmt.Errorf("%s transaction %s: %v", tx.Type, tx.ID, err)
+		}
+	}
+	return nil
+}
+
+// revertBlockStaking undoes the staking changes made by applyBlockStaking.
+func revertBlockStaking(state *types.Blockchain, block *types.Block) {
+	for i := len(block.Transactions) - 1; i >= 0; i-- {
+		revertStakingTransaction(state, block.Transactions[i])
+	}
+}
+
+// applyStakingTransaction validates a transaction against the staking state and
+// applies it. spent holds the outputs consumed by the block, keyed "txid:index".
+func applyStakingTransaction(state *types.Blockchain, tx *types.Transaction, spent map[string]types.UTXO) error {
+	if err := tx.ValidatePayload(); err != nil {
+		return err
+	}
+	sender := tx.SenderAddress.String()
+
+	switch tx.Type {
+	case types.TransactionTypeStake:
+		var p types.StakePayload
+		if err := tx.DecodePayload(&p); err != nil {
+			return err
+		}
+		paid, err := stakingPoolOutputs(tx)
+		if err != nil {
+			return err
+		}
+		if paid != p.Amount {
+			return fmt.Errorf("pays %d to the staking pool, payload stakes %d", paid, p.Amount)
+		}
+		if err := bondStake(state, sender, sender, p.Amount); err != nil {
+			return err
+		}
+
+	case types.TransactionTypeDelegate:
+		var p types.DelegatePayload
+		if err := tx.DecodePayload(&p); err != nil {
+			return err
+		}
+		if !isActiveValidator(state, p.Validator) {
+			return fmt.Errorf("%s is not an active validator", p.Validator)
+		}
+		paid, err := stakingPoolOutputs(tx)
+		if err != nil {
+			return err
+		}
+		if paid != p.Amount {
+			return fmt.Errorf("pays %d to the staking pool, payload delegates %d", paid, p.Amount)
+		}
+		if err := bondStake(state, sender, p.Validator, p.Amount); err != nil {
+			return err
+		}
+
+	case types.TransactionTypeUnstake:
+		var p types.UnstakePayload
+		if err := tx.DecodePayload(&p); err != nil {
+			return err
+		}
+		validator := unstakeValidator(tx, p)
+		if bonded := state.Delegations[sender][validator]; bonded < p.Amount {
+			return fmt.Errorf("%s has %d bonded to %s, cannot release %d", sender, bonded, validator, p.Amount)
+		}
+		for _, output := range tx.Outputs {
+			if output.OwnerAddress != types.StakingPoolAddress && output.OwnerAddress != sender {
+				return fmt.Errorf("released stake can only be paid to the sender, not %s", output.OwnerAddress)
+			}
+		}
+		drawn, err := stakingPoolDrawn(tx, spent)
+		if err != nil {
+			return err
+		}
+		if drawn != p.Amount {
+			return fmt.Errorf("draws %d from the staking pool, payload releases %d", drawn, p.Amount)
+		}
+		if err := bondStake(state, sender, validator, -p.Amount); err != nil {
+			return err
+		}
+
+	case types.TransactionTypeRegisterValidator:
+		var p types.RegisterValidatorPayload
+		if err := tx.DecodePayload(&p); err != nil {
+			return err
+		}
+		if isActiveValidator(state, sender) {
+			return fmt.Errorf("%s is already an active validator", sender)
+		}
+		minStake := minValidatorStake(state)
+		if own := state.Delegations[sender][sender]; own < minStake {
+			return fmt.Errorf("%s has %d bonded, registration requires %d", sender, own, minStake)
+		}
+		pubKey, err := crypto.NewPublicKeyFromBytes(p.PublicKey)
+		if err != nil {
+			return fmt.Errorf("invalid validator public key: %v", err)
+		}
+		if state.Database != nil {
+			if err := state.Database.SavePublicKey(pubKey); err != nil {
+				return fmt.Errorf("failed to save validator public key: %v", err)
+			}
+		}
+		state.ActiveValidators = append(state.ActiveValidators, sender)
+		if state.PublicKeyMap == nil {
+			state.PublicKeyMap = make(map[string]*crypto.PublicKey)
+		}
+		if _, ok := state.PublicKeyMap[sender]; !ok {
+			state.PublicKeyMap[sender] = &pubKey
+		}
+	}
+	return nil
+}
+
+// revertStakingTransaction undoes a transaction applied by applyStakingTransaction.
+// It restores stake totals that held before the transaction was applied, so the
+// bondStake calls cannot fail.
+func revertStakingTransaction(state *types.Blockchain, tx *types.Transaction) {
+	sender := tx.SenderAddress.String()
+	switch tx.Type {
+	case types.TransactionTypeStake:
+		var p types.StakePayload
+		if tx.DecodePayload(&p) == nil {
+			_ = bondStake(state, sender, sender, -p.Amount)
+		}
+	case types.TransactionTypeDelegate:
+		var p types.DelegatePayload
+		if tx.DecodePayload(&p) == nil {
+			_ = bondStake(state, sender, p.Validator, -p.Amount)
+		}
+	case types.TransactionTypeUnstake:
+		var p types.UnstakePayload
+		if tx.DecodePayload(&p) == nil {
+			_ = bondStake(state, sender, unstakeValidator(tx, p), p.Amount)
+		}
+	case types.TransactionTypeRegisterValidator:
+		for i := len(state.ActiveValidators) - 1; i >= 0; i-- {
+			if state.ActiveValidators[i] == sender {
+				state.ActiveValidators = append(state.ActiveValidators[:i], state.ActiveValidators[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// bondStake adds delta, which may be negative, to the stake delegator bonded to
+// validator. Entries that drop to zero are removed. If either total would overflow
+// or drop below zero an error is returned and the state is left unchanged.
+func bondStake(state *types.Blockchain, delegator, validator string, delta amount.Amount) error {
+	bonded, err := state.BondedStake[validator].Add(delta)
+	if err != nil {
+		return fmt.Errorf("stake bonded to %s: %w", validator, err)
+	}
+	delegated, err := state.Delegations[delegator][validator].Add(delta)
+	if err != nil {
+		return fmt.Errorf("stake %s bonded to %s: %w", delegator, validator, err)
+	}
+	if bonded < 0 || delegated < 0 {
+		return fmt.Errorf("%s has %d bonded to %s, cannot release %d", delegator, state.Delegations[delegator][validator], validator, -delta)
+	}
+
+	if state.BondedStake == nil {
+		state.BondedStake = make(map[string]amount.Amount)
+	}
+	if state.Delegations == nil {
+		state.Delegations = make(map[string]map[string]amount.Amount)
+	}
+	if state.Delegations[delegator] == nil {
+		state.Delegations[delegator] = make(map[string]amount.Amount)
+	}
+
+	if bonded == 0 {
+		delete(state.BondedStake, validator)
+	} else {
+		state.BondedStake[validator] = bonded
+	}
+	if delegated == 0 {
+		delete(state.Delegations[delegator], validator)
+		if len(state.Delegations[delegator]) == 0 {
+			delete(state.Delegations, delegator)
+		}
+	} else {
+		state.Delegations[delegator][validator] = delegated
+	}
+	return nil
+}
+
+func unstakeValidator(tx *types.Transaction, p types.UnstakePayload) string {
+	if p.Validator == "" {
+		return tx.SenderAddress.String()
+	}
+	return p.Validator
+}
+
+// stakingPoolDrawn returns the value an unstake transaction takes out of the staking
+// pool: the pool outputs it spends minus the change it returns to the pool. Inputs
+// that are not pool outputs must belong to the sender, for example to pay the fee.
+func stakingPoolDrawn(tx *types.Transaction, spent map[string]types.UTXO) (amount.Amount, error) {
+	sender := tx.SenderAddress.String()
+	var fromPool, toPool amount.Amount
+	for _, input := range tx.Inputs {
+		utxoKey := fmt.Sprintf("%s:%d", input.TransactionID, input.Index)
+		utxo, ok := spent[utxoKey]
+		if !ok {
+			return 0, fmt.Errorf("input %s is not spent by the block", utxoKey)
+		}
+		switch utxo.OwnerAddress {
+		case types.StakingPoolAddress:
+			var err error
+			if fromPool, err = fromPool.Add(utxo.Amount); err != nil {
+				return 0, fmt.Errorf("staking pool inputs: %w", err)
+			}
+		case sender:
+		default:
+			return 0, fmt.Errorf("input %s is owned by %s, not the sender or the staking pool", utxoKey, utxo.OwnerAddress)
+		}
+	}
+	for _, output := range tx.Outputs {
+		if output.OwnerAddress != types.StakingPoolAddress {
+			continue
+		}
+		var err error
+		if toPool, err = toPool.Add(output.Amount); err != nil {
+			return 0, fmt.Errorf("staking pool change: %w", err)
+		}
+	}
+	if toPool > fromPool {
+		return 0, fmt.Errorf("returns %d to the staking pool, more than the %d it spends from it", toPool, fromPool)
+	}
+	return fromPool - toPool, nil
+}
+
+// stakingPoolOutputs returns the value a transaction pays to the staking pool.
+func stakingPoolOutputs(tx *types.Transaction) (amount.Amount, error) {
+	var total amount.Amount
+	for _, output := range tx.Outputs {
+		if output.OwnerAddress != types.StakingPoolAddress {
+			continue
+		}
+		var err error
+		if total, err = total.Add(output.Amount); err != nil {
+			return 0, fmt.Errorf("staking pool outputs: %w", err)
+		}
+	}
+	return total, nil
+}
+
+func isActiveValidator(state *types.Blockchain, address string) bool {
+	for _, validator := range state.ActiveValidators {
+		if validator == address {
+			return true
+		}
+	}
+	return false
+}
+
+// minValidatorStake returns the stake a validator must bond to register.
+func minValidatorStake(state *types.Blockchain) amount.Amount {
+	if state.MinStakeForValidator != nil && state.MinStakeForValidator.Cmp(big.NewInt(0)) > 0 {
+		return amount.Amount(state.MinStakeForValidator.Int64())
+	}
+	return config.MinimumStakeAmount
+}
+
+// GetBondedStake returns the stake bonded on-chain to a validator, including delegations.
+func (bc *BlockchainImpl) GetBondedStake(validator string) amount.Amount {
+	bc.Blockchain.Mu.RLock()
+	defer bc.Blockchain.Mu.RUnlock()
+	return bc.Blockchain.BondedStake[validator]
+}
+
+// GetDelegation returns the stake delegator has bonded to validator.
+func (bc *BlockchainImpl) GetDelegation(delegator, validator string) amount.Amount {
+	bc.Blockchain.Mu.RLock()
+	defer bc.Blockchain.Mu.RUnlock()
+	return bc.Blockchain.Delegations[delegator][validator]
+}
@@ -1,7 +1,6 @@
 package chain
 
 import (
-	"bytes"
 	"log"
 
 	"github.com/thrylos-labs/thrylos/types"
@@ -342,7 +341,8 @@ import (
 // 	return nil
 // }
 
-// // // // Helper function to efficiently check salt uniqueness in all blocks
+// checkSaltInBlocks reports whether a pending or confirmed transaction already carries
+// salt. Confirmed salts are looked up in the store's salt index.
 func (bc *BlockchainImpl) checkSaltInBlocks(salt []byte) bool {
 	bc.Blockchain.Mu.RLock()
 	defer bc.Blockchain.Mu.RUnlock()
@@ -359,15 +359,8 @@ func (bc *BlockchainImpl) checkSaltInBlocks(salt []byte) bool {
 	}
 
 	// Check confirmed blocks
-	for _, block := range bc.Blockchain.Blocks {
-		for _, tx := range block.Transactions {
-			if bytes.Equal(tx.Salt, salt) {
-				return true
-			}
-		}
-	}
-
-	return false
+	_, err := bc.Blockchain.Database.GetTransactionLocationBySalt(salt)
+	return err == nil
 }
 
 // // func SharedToThrylos(tx *shared.Transaction) *thrylos.Transaction {
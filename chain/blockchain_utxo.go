@@ -3,6 +3,7 @@ package chain
 import (
 	"fmt"
 	"log"
+	"time"
 
 	thrylos "github.com/thrylos-labs/thrylos"
 	"github.com/thrylos-labs/thrylos/amount"
@@ -20,10 +21,12 @@ func (bc *BlockchainImpl) addUTXO(utxo types.UTXO) error {
 
 	// Create a proto UTXO first
 	protoUTXO := &thrylos.UTXO{
-		TransactionId: utxo.TransactionID,
-		Index:         int32(utxo.Index),
-		OwnerAddress:  utxo.OwnerAddress,
-		Amount:        int64(utxo.Amount), // You might need to convert Amount to int64
+		TransactionId:   utxo.TransactionID,
+		Index:           int32(utxo.Index),
+		OwnerAddress:    utxo.OwnerAddress,
+		Amount:          int64(utxo.Amount), // You might need to convert Amount to int64
+		LockUntilHeight: utxo.LockUntilHeight,
+		LockUntilTime:   utxo.LockUntilTime,
 	}
 
 	bc.Blockchain.UTXOs[utxoKey] = append(bc.Blockchain.UTXOs[utxoKey], protoUTXO)
@@ -89,12 +92,44 @@ func (bc *BlockchainImpl) GetBalance(address string) (amount.Amount, error) {
 
 	for _, utxo := range utxos {
 		if !utxo.IsSpent {
-			balance = balance + utxo.Amount // Or use appropriate method like Add()
+			if balance, err = balance.Add(utxo.Amount); err != nil {
+				return amount.Amount(0), fmt.Errorf("balance of %s: %v", address, err)
+			}
 		}
 	}
 	return balance, nil
 }
 
+// GetBalanceDetails splits the balance of an address into the amount it can spend in
+// the next block and the amount still held by height or time locks.
+func (bc *BlockchainImpl) GetBalanceDetails(address string) (types.Balance, error) {
+	utxos, err := bc.Blockchain.Database.GetUTXOsForAddress(address)
+	if err != nil {
+		return types.Balance{}, err
+	}
+	bc.Blockchain.Mu.RLock()
+	nextHeight := int64(len(bc.Blockchain.Blocks))
+	bc.Blockchain.Mu.RUnlock()
+	return types.NewBalance(utxos, nextHeight, time.Now().Unix())
+}
+
+// BalanceResponse builds the gRPC balance response for an address, including its
+// spendable and locked amounts.
+func (bc *BlockchainImpl) BalanceResponse(address string) (*thrylos.BalanceResponse, error) {
+	balance, err := bc.GetBalanceDetails(address)
+	if err != nil {
+		return nil, err
+	}
+	total := balance.Total()
+	return &thrylos.BalanceResponse{
+		Balance:           total.ToNanoTHR(),
+		BalanceThrylos:    total.ToTHRYLOS(),
+		BlockchainAddress: address,
+		SpendableBalance:  balance.Spendable.ToNanoTHR(),
+		LockedBalance:     balance.Locked.ToNanoTHR(),
+	}, nil
+}
+
 // // Function to convert Blockchain UTXOs to a format usable in shared validation logic
 func (bc *BlockchainImpl) convertUTXOsToRequiredFormat() map[string][]types.UTXO {
 	result := make(map[string][]types.UTXO)
@@ -102,10 +137,12 @@ func (bc *BlockchainImpl) convertUTXOsToRequiredFormat() map[string][]types.UTXO
 		sharedUtxos := make([]types.UTXO, len(utxos))
 		for i, utxo := range utxos {
 			sharedUtxos[i] = types.UTXO{
-				TransactionID: utxo.TransactionId,
-				Index:         int(utxo.Index),
-				OwnerAddress:  utxo.OwnerAddress,
-				Amount:        amount.Amount(int64(utxo.Amount)), // Cast int64 to amount.Amount
+				TransactionID:   utxo.TransactionId,
+				Index:           int(utxo.Index),
+				OwnerAddress:    utxo.OwnerAddress,
+				Amount:          amount.Amount(int64(utxo.Amount)), // Cast int64 to amount.Amount
+				LockUntilHeight: utxo.LockUntilHeight,
+				LockUntilTime:   utxo.LockUntilTime,
 			}
 		}
 		result[key] = sharedUtxos
@@ -116,10 +153,12 @@ func (bc *BlockchainImpl) convertUTXOsToRequiredFormat() map[string][]types.UTXO
 // // // ConvertProtoUTXOToShared converts a Protobuf-generated UTXO to your shared UTXO type.
 func ConvertProtoUTXOToShared(protoUTXO *thrylos.UTXO) types.UTXO {
 	return types.UTXO{
-		ID:            protoUTXO.GetTransactionId(), // Assuming you have corresponding fields
-		TransactionID: protoUTXO.GetTransactionId(),
-		Index:         int(protoUTXO.GetIndex()), // Convert from int32 to int if necessary
-		OwnerAddress:  protoUTXO.GetOwnerAddress(),
-		Amount:        amount.Amount(int64(protoUTXO.GetAmount())), // Convert from int64 to int if necessary
+		ID:              protoUTXO.GetTransactionId(), // Assuming you have corresponding fields
+		TransactionID:   protoUTXO.GetTransactionId(),
+		Index:           int(protoUTXO.GetIndex()), // Convert from int32 to int if necessary
+		OwnerAddress:    protoUTXO.GetOwnerAddress(),
+		Amount:          amount.Amount(int64(protoUTXO.GetAmount())), // Convert from int64 to int if necessary
+		LockUntilHeight: protoUTXO.GetLockUntilHeight(),
+		LockUntilTime:   protoUTXO.GetLockUntilTime(),
 	}
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/btcsuite/btcutil/bech32"
 	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/thrylos-labs/thrylos/config"
 	"github.com/thrylos-labs/thrylos/crypto"
 	"github.com/thrylos-labs/thrylos/crypto/address"
 	"github.com/thrylos-labs/thrylos/shared"
@@ -248,15 +249,9 @@ func (bc *BlockchainImpl) GetValidatorPublicKey(validatorAddress string) (*mldsa
 		return nil, fmt.Errorf("failed to get public key for validator %s: %v", validatorAddress, err)
 	}
 
-	// Convert crypto.PublicKey to bytes
-	pubKeyBytes, err := pubKey.Marshal()
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal public key for validator %s: %v", validatorAddress, err)
-	}
-
-	// Create and unmarshal into MLDSA44 public key
+	// Create and unmarshal the packed key bytes into MLDSA44 public key
 	mldsa44PubKey := new(mldsa44.PublicKey)
-	err = mldsa44PubKey.UnmarshalBinary(pubKeyBytes)
+	err = mldsa44PubKey.UnmarshalBinary(pubKey.Bytes())
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert to MLDSA44 public key for validator %s: %v", validatorAddress, err)
 	}
@@ -300,7 +295,7 @@ func (bc *BlockchainImpl) UpdateActiveValidators(count int) {
 	}
 	validators := make([]validatorStake, 0)
 
-	minValidatorStake := int64(40 * 1e7) // 40 THRYLOS minimum for validators
+	minValidatorStake := int64(40 * config.NanoPerThrylos) // 40 THRYLOS minimum for validators
 
 	for addr, stake := range bc.Blockchain.Stakeholders {
 		if stake >= minValidatorStake { // Using fixed minimum validator stake
@@ -508,21 +503,10 @@ func (bc *BlockchainImpl) SimulateValidatorSigning(unsignedBlock *types.Block) (
 	unsignedBlock.Validator = bech32Address
 	log.Printf("Updated block validator to Bech32 address: %s", bech32Address)
 
-	// Generate the block hash
-	ComputeBlockHash(unsignedBlock)
-	log.Printf("Signing block hash: %x", unsignedBlock.Hash)
-
-	// Get the block bytes for signing
-	blockBytes, err := SerializeForSigning(unsignedBlock)
-	if err != nil {
-		return nil, fmt.Errorf("failed to serialize block for signing: %v", err)
-	}
-
 	// Sign the block hash using the private key
-	signature := privateKey.Sign(blockBytes)
-
-	// Store the signature directly since it's already a crypto.Signature
-	unsignedBlock.Signature = signature
+	SignBlock(unsignedBlock, privateKey)
+	signature := unsignedBlock.Signature
+	log.Printf("Signed block hash: %x", unsignedBlock.Hash)
 
 	log.Printf("Block signed successfully for validator: %s", unsignedBlock.Validator)
 	log.Printf("Signature: %x", signature.Bytes())
@@ -0,0 +1,243 @@
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/crypto/address"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/shared"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// Checks reported by VerifyChain when a block is inconsistent
+const (
+	CheckMissingBlock         = "missing_block"
+	CheckLink                 = "link"
+	CheckHash                 = "hash"
+	CheckBlockSignature       = "block_signature"
+	CheckVerkleRoot           = "verkle_root"
+	CheckTransactionSignature = "transaction_signature"
+	CheckUTXOSpend            = "utxo_spend"
+)
+
+// ChainVerificationReport is the result of VerifyChain, meant to be printed as JSON.
+type ChainVerificationReport struct {
+	Valid         bool                      `json:"valid"`
+	TipHeight     int64                     `json:"tipHeight"`
+	BlocksChecked int                       `json:"blocksChecked"`
+	Failure       *ChainVerificationFailure `json:"failure,omitempty"`
+}
+
+// ChainVerificationFailure describes the first inconsistency found in the chain.
+type ChainVerificationFailure struct {
+	Height        int64  `json:"height"`
+	Check         string `json:"check"`
+	TransactionID string `json:"transactionId,omitempty"`
+	Reason        string `json:"reason"`
+}
+
+// VerifyChain walks the persisted chain from genesis and re-checks every block: its link
+// to the parent, its hash, the validator signature, the Verkle root and the signature and
+// spends of each transaction. Transaction signatures are checked for chainID. It stops
+// at the first inconsistent height.
+func VerifyChain(s types.Store, chainID string) (*ChainVerificationReport, error) {
+	lastIndex, err := s.GetLastBlockNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the last block number: %v", err)
+	}
+
+	report := &ChainVerificationReport{TipHeight: int64(lastIndex)}
+	fail := func(height int64, check, txID string, reason error) (*ChainVerificationReport, error) {
+		report.Failure = &ChainVerificationFailure{
+			Height:        height,
+			Check:         check,
+			TransactionID: txID,
+			Reason:        reason.Error(),
+		}
+		return report, nil
+	}
+
+	utxos := make(map[string][]*thrylos.UTXO)
+	var prev *types.Block
+	for height := 0; height <= lastIndex; height++ {
+		block, err := s.GetBlock(uint32(height))
+		if err != nil {
+			return fail(int64(height), CheckMissingBlock, "", err)
+		}
+
+		if err := verifyBlockLink(block, prev, int64(height)); err != nil {
+			return fail(block.Index, CheckLink, "", err)
+		}
+
+		blockCopy := *block
+		ComputeBlockHash(&blockCopy)
+		if !blockCopy.Hash.Equal(block.Hash) {
+			return fail(block.Index, CheckHash, "", fmt.Errorf("stored hash %s does not match computed hash %s", block.Hash.String(), blockCopy.Hash.String()))
+		}
+
+		// The genesis block is trusted through its hash and carries no validator signature
+		if height > 0 {
+			if err := verifyBlockSignature(s, block); err != nil {
+				return fail(block.Index, CheckBlockSignature, "", err)
+			}
+		}
+
+		if err := verifyVerkleRoot(block); err != nil {
+			return fail(block.Index, CheckVerkleRoot, "", err)
+		}
+
+		undo := &types.BlockUndo{BlockHash: block.Hash, Height: block.Index}
+		for _, tx := range block.Transactions {
+			if height > 0 {
+				if err := verifyTransactionSignature(tx, chainID); err != nil {
+					return fail(block.Index, CheckTransactionSignature, tx.ID, err)
+				}
+				if err := verifyTransactionSpends(utxos, block, tx); err != nil {
+					return fail(block.Index, CheckUTXOSpend, tx.ID, err)
+				}
+			}
+			applyTransactionToUTXOs(utxos, tx, undo)
+		}
+
+		prev = block
+		report.BlocksChecked++
+	}
+
+	report.Valid = true
+	return report, nil
+}
+
+func verifyBlockLink(block, prev *types.Block, height int64) error {
+	if block.Index != height {
+		return fmt.Errorf("block stored at height %d has index %d", height, block.Index)
+	}
+	if prev == nil {
+		if !block.PrevHash.Equal(hash.NullHash()) {
+			return errors.New("genesis block has a previous hash")
+		}
+		return nil
+	}
+	if !block.PrevHash.Equal(prev.Hash) {
+		return fmt.Errorf("previous hash %s does not match block %d hash %s", block.PrevHash.String(), prev.Index, prev.Hash.String())
+	}
+	return nil
+}
+
+// verifyBlockSignature checks the block signature against ValidatorPublicKey, falling
+// back to the stored key of the validator address when the block does not embed it.
+func verifyBlockSignature(s types.Store, block *types.Block) error {
+	if block.Signature == nil {
+		return errors.New("block is not signed")
+	}
+
+	pubKey := block.ValidatorPublicKey
+	if pubKey == nil {
+		validatorAddr, err := address.FromString(block.Validator)
+		if err != nil {
+			return fmt.Errorf("invalid validator address %s: %v", block.Validator, err)
+		}
+		pubKey, err = s.GetPublicKey(*validatorAddr)
+		if err != nil {
+			return fmt.Errorf("no public key for validator %s: %v", block.Validator, err)
+		}
+	} else if block.Validator != "" {
+		keyAddr, err := pubKey.Address()
+		if err != nil {
+			return fmt.Errorf("invalid validator public key: %v", err)
+		}
+		if keyAddr.String() != block.Validator {
+			return fmt.Errorf("validator public key belongs to %s, not %s", keyAddr.String(), block.Validator)
+		}
+	}
+
+	if err := block.Signature.Verify(&pubKey, block.Hash.Bytes()); err != nil {
+		return fmt.Errorf("invalid block signature: %v", err)
+	}
+	return nil
+}
+
+func verifyVerkleRoot(block *types.Block) error {
+	if len(block.Transactions) == 0 {
+		if len(block.VerkleRoot) != 0 {
+			return errors.New("block without transactions has a Verkle root")
+		}
+		return nil
+	}
+	tree, err := newTransactionTree(block.Transactions)
+	if err != nil {
+		return fmt.Errorf("failed to create Verkle tree: %v", err)
+	}
+	commitment := tree.Commitment().BytesUncompressedTrusted()
+	if !bytes.Equal(commitment[:], block.VerkleRoot) {
+		return errors.New("Verkle root does not match the block transactions")
+	}
+	return nil
+}
+
+func verifyTransactionSignature(tx *types.Transaction, chainID string) error {
+	if tx.Multisig == nil && (tx.Signature == nil || tx.SenderPublicKey == nil) {
+		return errors.New("transaction is not signed")
+	}
+	if err := shared.VerifyTransactionSignature(tx, chainID); err != nil {
+		return fmt.Errorf("invalid transaction signature: %v", err)
+	}
+	return nil
+}
+
+// verifyTransactionSpends checks that the transaction spends at least one input, that
+// every input is unspent, unlocked in block and owned by the sender, that every output
+// pays a positive amount and that the outputs and the fee do not exceed the inputs. Only the genesis block creates outputs
+// without inputs. Unstake transactions spend staking pool outputs instead; the stake
+// they release is checked when the block is applied.
+func verifyTransactionSpends(utxos map[string][]*thrylos.UTXO, block *types.Block, tx *types.Transaction) error {
+	if len(tx.Inputs) == 0 {
+		return errors.New("transaction spends no inputs")
+	}
+	var inputSum amount.Amount
+	for _, input := range tx.Inputs {
+		utxoKey := fmt.Sprintf("%s:%d", input.TransactionID, input.Index)
+		spent := utxos[utxoKey]
+		if len(spent) == 0 {
+			return fmt.Errorf("output %s does not exist or is already spent", utxoKey)
+		}
+		owner := spent[0].OwnerAddress
+		if tx.Type == types.TransactionTypeUnstake && owner == types.StakingPoolAddress {
+			owner = tx.SenderAddress.String()
+		}
+		if owner != tx.SenderAddress.String() {
+			return fmt.Errorf("output %s is owned by %s, not the sender %s", utxoKey, spent[0].OwnerAddress, tx.SenderAddress.String())
+		}
+		if err := checkOutputLock(spent[0], input, block); err != nil {
+			return err
+		}
+		var err error
+		if inputSum, err = inputSum.Add(amount.Amount(spent[0].Amount)); err != nil {
+			return fmt.Errorf("inputs of transaction %s: %w", tx.ID, err)
+		}
+	}
+	// A negative output would offset a larger one and create coins
+	for i, output := range tx.Outputs {
+		if output.Amount <= 0 {
+			return fmt.Errorf("output %d has a non-positive amount %d", i, output.Amount)
+		}
+	}
+	outputSum, err := types.SumUTXOs(tx.Outputs)
+	if err != nil {
+		return fmt.Errorf("outputs of transaction %s: %w", tx.ID, err)
+	}
+	if tx.GasFee < 0 {
+		return fmt.Errorf("negative fee %d", tx.GasFee)
+	}
+	spent, err := outputSum.Add(amount.Amount(tx.GasFee))
+	if err != nil {
+		return fmt.Errorf("outputs of transaction %s: %w", tx.ID, err)
+	}
+	if spent > inputSum {
+		return fmt.Errorf("outputs of %d and fee of %d exceed inputs of %d", outputSum, tx.GasFee, inputSum)
+	}
+	return nil
+}
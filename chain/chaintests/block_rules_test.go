@@ -0,0 +1,98 @@
+package chaintests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func requireBlockRule(t *testing.T, err error, rule error) {
+	t.Helper()
+	require.Error(t, err)
+	require.True(t, errors.Is(err, rule), "expected %v, got %v", rule, err)
+	var ruleErr *chain.BlockRuleError
+	require.True(t, errors.As(err, &ruleErr))
+}
+
+func TestBlockTimestampRules(t *testing.T) {
+	bc, _ := newForkTestBlockchain(t)
+	validator := newTestValidator(t, bc, 100)
+
+	parent := bc.Blockchain.Genesis
+	for i := 0; i < 3; i++ {
+		block := newSignedBlock(t, parent, validator)
+		require.NoError(t, bc.ProcessBlock(block))
+		parent = block
+	}
+
+	// The median of genesis and the three blocks above it is the timestamp of block 2
+	stale := &types.Block{
+		Index:     parent.Index + 1,
+		Timestamp: bc.Blockchain.Blocks[2].Timestamp,
+		PrevHash:  parent.Hash,
+		Validator: validator.address,
+	}
+	require.NoError(t, chain.InitializeVerkleTree(stale))
+	chain.SignBlock(stale, validator.key)
+	requireBlockRule(t, bc.ProcessBlock(stale), chain.ErrBlockTimestampTooOld)
+
+	future := &types.Block{
+		Index:     parent.Index + 1,
+		Timestamp: time.Now().Add(config.MaxBlockTimeDriftSeconds*time.Second + time.Minute).Unix(),
+		PrevHash:  parent.Hash,
+		Validator: validator.address,
+	}
+	require.NoError(t, chain.InitializeVerkleTree(future))
+	chain.SignBlock(future, validator.key)
+	requireBlockRule(t, bc.ProcessBlock(future), chain.ErrBlockTimestampInFuture)
+
+	require.Len(t, bc.Blockchain.Blocks, 4, "Rejected blocks must not be connected")
+
+	// Blocks at or below the tip are history being synced, which the local clock does
+	// not judge
+	historic := &types.Block{
+		Index:     1,
+		Timestamp: future.Timestamp,
+		PrevHash:  bc.Blockchain.Genesis.Hash,
+		Validator: validator.address,
+	}
+	require.NoError(t, chain.InitializeVerkleTree(historic))
+	chain.SignBlock(historic, validator.key)
+	require.NoError(t, bc.ProcessBlock(historic))
+	require.Len(t, bc.GetForks(), 1)
+	require.NoError(t, bc.ProcessBlock(newSignedBlock(t, parent, validator)))
+}
+
+func TestBlockSizeRules(t *testing.T) {
+	bc, _ := newForkTestBlockchain(t)
+	genesis := bc.Blockchain.Genesis
+
+	txs := make([]*types.Transaction, config.MaxBlockTransactions+1)
+	for i := range txs {
+		txs[i] = &types.Transaction{ID: "tx"}
+	}
+	crowded := &types.Block{
+		Index:        1,
+		Timestamp:    genesis.Timestamp + 1,
+		PrevHash:     genesis.Hash,
+		Transactions: txs,
+	}
+	requireBlockRule(t, bc.VerifySignedBlock(crowded), chain.ErrTooManyTransactions)
+
+	large := &types.Block{
+		Index:     1,
+		Timestamp: genesis.Timestamp + 1,
+		PrevHash:  genesis.Hash,
+		Transactions: []*types.Transaction{{
+			ID:      "large-tx",
+			Outputs: []types.UTXO{{OwnerAddress: strings.Repeat("x", config.MaxBlockSize), Amount: 1}},
+		}},
+	}
+	requireBlockRule(t, bc.VerifySignedBlock(large), chain.ErrBlockTooLarge)
+}
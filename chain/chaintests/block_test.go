@@ -1,12 +1,8 @@
 package chaintests
 
 import (
-	"fmt"
-	"io/ioutil"
 	"os"
-	"path/filepath"
 	"testing"
-	"time"
 
 	"github.com/thrylos-labs/thrylos/chain"
 	"github.com/thrylos-labs/thrylos/crypto"
@@ -21,34 +17,6 @@ func TestGenesisBlockCreation(t *testing.T) {
 	os.Setenv("GENESIS_ACCOUNT", genesisAccount)
 	defer os.Unsetenv("GENESIS_ACCOUNT")
 
-	// Create a temporary directory for blockchain data with a unique suffix
-	tempDir, err := ioutil.TempDir("", fmt.Sprintf("blockchain_test_%d", time.Now().UnixNano()))
-	if err != nil {
-		t.Fatalf("Failed to create temporary directory: %v", err)
-	}
-
-	// Cleanup function
-	cleanup := func() {
-		time.Sleep(100 * time.Millisecond)
-		lockFile := filepath.Join(tempDir, "LOCK")
-		if err := os.Remove(lockFile); err != nil && !os.IsNotExist(err) {
-			t.Logf("Warning: Failed to remove lock file: %v", err)
-		}
-		manifestFiles, err := filepath.Glob(filepath.Join(tempDir, "MANIFEST*"))
-		if err == nil {
-			for _, f := range manifestFiles {
-				if err := os.Remove(f); err != nil {
-					t.Logf("Warning: Failed to remove manifest file %s: %v", f, err)
-				}
-			}
-		}
-		time.Sleep(100 * time.Millisecond)
-		if err := os.RemoveAll(tempDir); err != nil {
-			t.Logf("Warning: Failed to remove temp directory: %v", err)
-		}
-	}
-	defer cleanup()
-
 	// Generate a dummy AES key for testing
 	aesKey, err := encryption.GenerateAESKey()
 	if err != nil {
@@ -68,7 +36,7 @@ func TestGenesisBlockCreation(t *testing.T) {
 
 	// Initialize blockchain with correct config type
 	config := &types.BlockchainConfig{
-		DataDir:           tempDir,
+		InMemory:          true,
 		AESKey:            aesKey,
 		GenesisAccount:    priv,
 		TestMode:          true,
@@ -77,17 +45,9 @@ func TestGenesisBlockCreation(t *testing.T) {
 
 	blockchain, store, err := chain.NewBlockchain(config)
 	if err != nil {
-		cleanup()
 		t.Fatalf("Failed to initialize blockchain: %v", err)
 	}
-
-	// Ensure store is closed before cleanup
-	if closer, ok := store.(interface{ Close() }); ok {
-		defer func() {
-			closer.Close()
-			time.Sleep(100 * time.Millisecond)
-		}()
-	}
+	defer store.(interface{ Close() error }).Close()
 
 	// Verify the genesis block
 	if blockchain == nil || blockchain.Blockchain == nil {
@@ -1,11 +1,8 @@
 package chaintests
 
 import (
-	"fmt"
 	"log"
-	"os"
 	"testing"
-	"time"
 
 	"github.com/thrylos-labs/thrylos/chain"
 
@@ -23,12 +20,6 @@ func TestNewBlockchain(t *testing.T) {
 		log.Printf("Note: .env.dev file not found, using default test values")
 	}
 
-	// Create a unique temporary directory for the test
-	tempDir, err := os.MkdirTemp("", fmt.Sprintf("blockchain_test_%d", time.Now().UnixNano()))
-	require.NoError(t, err, "Failed to create temporary directory")
-
-	// Clean up the temporary directory after the test
-
 	// Generate test keys
 	priv, err := crypto.NewPrivateKey()
 	require.NoError(t, err, "Failed to generate private key for genesis account")
@@ -38,7 +29,7 @@ func TestNewBlockchain(t *testing.T) {
 
 	// Initialize blockchain config
 	config := &types.BlockchainConfig{
-		DataDir:           tempDir,
+		InMemory:          true,
 		AESKey:            aesKey,
 		GenesisAccount:    priv,
 		TestMode:          true,
@@ -57,7 +48,7 @@ func TestNewBlockchain(t *testing.T) {
 	require.NotEmpty(t, blockchain.Blockchain.Blocks, "Blockchain should have at least one block")
 	require.Equal(t, blockchain.Blockchain.Genesis, blockchain.Blockchain.Blocks[0], "First block should be genesis block")
 
-	// Add more robust cleanup
+	// Close the store when the test ends
 	defer func() {
 		// First close the blockchain store if it exists
 		if blockchainStore != nil {
@@ -67,11 +58,6 @@ func TestNewBlockchain(t *testing.T) {
 				}
 			}
 		}
-
-		// Clean up the temporary directory
-		if err := os.RemoveAll(tempDir); err != nil {
-			t.Logf("Warning: Failed to remove temp directory: %v", err)
-		}
 	}()
 
 	// Verify genesis block structure
@@ -122,3 +108,76 @@ func TestSignature(t *testing.T) {
 
 	t.Log("MLDSA44 signature verification succeeded")
 }
+
+func TestNewBlockchainReloadsPersistedChain(t *testing.T) {
+	database := newTestDatabase(t)
+	priv, err := crypto.NewPrivateKey()
+	require.NoError(t, err, "Failed to generate private key for genesis account")
+
+	aesKey, err := encryption.GenerateAESKey()
+	require.NoError(t, err, "Failed to generate AES key")
+
+	config := &types.BlockchainConfig{
+		AESKey:            aesKey,
+		GenesisAccount:    priv,
+		TestMode:          true,
+		DisableBackground: true,
+	}
+
+	first, _, err := chain.NewBlockchainWithDatabase(config, database)
+	require.NoError(t, err, "Failed to create blockchain")
+	genesis := first.Blockchain.Genesis
+
+	// Opening the same database again must reuse the persisted genesis
+	second, _, err := chain.NewBlockchainWithDatabase(config, database)
+	require.NoError(t, err, "Failed to reload blockchain")
+
+	require.Len(t, second.Blockchain.Blocks, 1, "Reloaded chain should only contain genesis")
+	require.Equal(t, genesis.Hash, second.Blockchain.Genesis.Hash, "Genesis block should not be recreated")
+	require.Equal(t, genesis.Timestamp, second.Blockchain.Genesis.Timestamp)
+	require.Equal(t, first.Blockchain.Stakeholders, second.Blockchain.Stakeholders, "Stakeholders should be rebuilt from genesis")
+	require.Len(t, second.Blockchain.UTXOs, len(first.Blockchain.UTXOs), "UTXO set should be rebuilt from the blocks")
+}
+
+func TestNewBlockchainReloadsBlocksAfterGenesis(t *testing.T) {
+	database := newTestDatabase(t)
+	priv, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	aesKey, err := encryption.GenerateAESKey()
+	require.NoError(t, err)
+	config := &types.BlockchainConfig{
+		AESKey:            aesKey,
+		GenesisAccount:    priv,
+		TestMode:          true,
+		DisableBackground: true,
+	}
+
+	first, _, err := chain.NewBlockchainWithDatabase(config, database)
+	require.NoError(t, err)
+	validator := newTestValidator(t, first, 100)
+	genesisTx := first.Blockchain.Genesis.Transactions[0]
+	supply := genesisTx.Outputs[0].Amount
+	ownerAddr, err := priv.PublicKey().Address()
+	require.NoError(t, err)
+
+	// Move part of the genesis output to a new address and mine it in block 1
+	recipient := "tl1reloadrecipient"
+	transfer := newTransfer(t, priv, first.GetChainID(), "reload-transfer",
+		[]types.UTXO{{TransactionID: genesisTx.ID, Index: 0, OwnerAddress: ownerAddr.String(), Amount: supply}},
+		types.UTXO{OwnerAddress: recipient, Amount: 250},
+		types.UTXO{OwnerAddress: ownerAddr.String(), Amount: supply - 250},
+	)
+	b1 := newSignedBlock(t, first.Blockchain.Genesis, validator, transfer)
+	require.NoError(t, first.ProcessBlock(b1))
+	require.Equal(t, int64(250), first.Blockchain.Stakeholders[recipient])
+
+	second, _, err := chain.NewBlockchainWithDatabase(config, database)
+	require.NoError(t, err)
+
+	require.Len(t, second.Blockchain.Blocks, 2)
+	require.True(t, second.Blockchain.Blocks[1].Hash.Equal(b1.Hash))
+	require.Equal(t, first.Blockchain.Stakeholders, second.Blockchain.Stakeholders, "Stakeholders should be rebuilt from the replayed UTXO set")
+	require.Equal(t, int64(supply-250), second.Blockchain.Stakeholders[ownerAddr.String()])
+	require.NotContains(t, second.Blockchain.UTXOs, genesisTx.ID+":0")
+	require.Contains(t, second.Blockchain.UTXOs, "reload-transfer:0")
+}
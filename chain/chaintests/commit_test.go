@@ -0,0 +1,72 @@
+package chaintests
+
+import (
+	"testing"
+
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/encryption"
+	"github.com/thrylos-labs/thrylos/store"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func TestInterruptedBlockCommitIsRolledBack(t *testing.T) {
+	database := newTestDatabase(t)
+	priv, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	aesKey, err := encryption.GenerateAESKey()
+	require.NoError(t, err)
+	config := &types.BlockchainConfig{
+		AESKey:            aesKey,
+		GenesisAccount:    priv,
+		TestMode:          true,
+		DisableBackground: true,
+	}
+
+	bc, blockchainStore, err := chain.NewBlockchainWithDatabase(config, database)
+	require.NoError(t, err)
+	validator := newTestValidator(t, bc, 100)
+
+	funding := genesisOutput(bc)
+	block := newSignedBlock(t, bc.Blockchain.Genesis, validator, newTransfer(t, priv, bc.GetChainID(), "committed-tx",
+		[]types.UTXO{funding},
+		types.UTXO{OwnerAddress: "tl1committed", Amount: 7},
+		types.UTXO{OwnerAddress: funding.OwnerAddress, Amount: funding.Amount - 7},
+	))
+	require.NoError(t, bc.ProcessBlock(block))
+
+	// The block, its indexes and its outputs are committed together
+	stored, err := blockchainStore.GetBlockByHash(block.Hash)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stored.Index)
+	utxos, err := blockchainStore.GetUTXOsForAddress("tl1committed")
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	require.Equal(t, "committed-tx", utxos[0].TransactionID)
+
+	// Leave the commit marker behind, as if the node stopped before the commit finished
+	marker, err := cbor.Marshal(map[int]interface{}{1: block.Index, 2: block.Hash})
+	require.NoError(t, err)
+	require.NoError(t, database.GetDB().Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(store.CommitMarkerKey), marker)
+	}))
+
+	reopened, reopenedStore, err := chain.NewBlockchainWithDatabase(config, database)
+	require.NoError(t, err)
+
+	require.Len(t, reopened.Blockchain.Blocks, 1, "Partially applied block should be rolled back")
+	_, err = reopenedStore.GetBlockByHash(block.Hash)
+	require.Error(t, err)
+	_, err = reopenedStore.GetTransactionLocation("committed-tx")
+	require.Error(t, err)
+	utxos, err = reopenedStore.GetUTXOsForAddress("tl1committed")
+	require.NoError(t, err)
+	require.Empty(t, utxos)
+
+	_, recovered, err := reopenedStore.RecoverIncompleteBlock()
+	require.NoError(t, err)
+	require.False(t, recovered, "The marker should be cleared after recovery")
+}
@@ -0,0 +1,75 @@
+package chaintests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/encryption"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func newGenesisTestBlockchain(t *testing.T, genesis *config.Genesis) (*chain.BlockchainImpl, types.Store) {
+	priv, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	aesKey, err := encryption.GenerateAESKey()
+	require.NoError(t, err)
+
+	bc, blockchainStore, err := chain.NewBlockchain(&types.BlockchainConfig{
+		InMemory:          true,
+		AESKey:            aesKey,
+		GenesisAccount:    priv,
+		TestMode:          true,
+		DisableBackground: true,
+		Genesis:           genesis,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { blockchainStore.(interface{ Close() error }).Close() })
+	return bc, blockchainStore
+}
+
+func TestExportImportChain(t *testing.T) {
+	genesis, validator := newTestGenesisWithValidator(t)
+	source, _ := newGenesisTestBlockchain(t, genesis)
+
+	b1 := newSignedBlock(t, source.Blockchain.Genesis, validator)
+	require.NoError(t, source.ProcessBlock(b1))
+	b2 := newSignedBlock(t, b1, validator)
+	require.NoError(t, source.ProcessBlock(b2))
+
+	var stream bytes.Buffer
+	exported, err := source.ExportChain(&stream)
+	require.NoError(t, err)
+	require.Equal(t, 3, exported)
+	data := stream.Bytes()
+
+	// The target node has never seen the validator; its key travels in the stream header
+	target, targetStore := newGenesisTestBlockchain(t, genesis)
+	imported, err := target.ImportChain(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, 2, imported)
+	require.Len(t, target.Blockchain.Blocks, 3)
+	require.True(t, target.Blockchain.Blocks[2].Hash.Equal(b2.Hash))
+	stored, err := targetStore.GetBlock(2)
+	require.NoError(t, err)
+	require.True(t, stored.Hash.Equal(b2.Hash))
+
+	// Importing the same stream again adds nothing
+	imported, err = target.ImportChain(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, 0, imported)
+
+	// A chain with a different genesis refuses the stream
+	other, _ := newGenesisTestBlockchain(t, newTestGenesis(t))
+	_, err = other.ImportChain(bytes.NewReader(data))
+	require.Error(t, err)
+
+	// A truncated stream is reported after the complete blocks
+	fresh, _ := newGenesisTestBlockchain(t, genesis)
+	imported, err = fresh.ImportChain(bytes.NewReader(data[:len(data)-10]))
+	require.Error(t, err)
+	require.Equal(t, 1, imported)
+}
@@ -0,0 +1,69 @@
+package chaintests
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func TestFeeEstimatorAndMinimumRelayFee(t *testing.T) {
+	bc, blockchainStore, genesisKey := newTestBlockchainWithGenesisKey(t)
+	genesis := bc.Blockchain.Genesis
+	genesisTx := genesis.Transactions[0]
+	validator := newTestValidator(t, bc, 100)
+	pool := chain.NewTxPool(blockchainStore, bc)
+
+	genesisAddr, err := genesisKey.PublicKey().Address()
+	require.NoError(t, err)
+	owner := genesisAddr.String()
+	supply := genesisTx.Outputs[0].Amount
+
+	transfer := func(id string, input types.UTXO, fee int) *types.Transaction {
+		salt := sha256.Sum256([]byte(id))
+		tx := &types.Transaction{
+			ID:              id,
+			Inputs:          []types.UTXO{input},
+			Outputs:         []types.UTXO{{OwnerAddress: owner, Amount: input.Amount - amount.Amount(fee)}},
+			GasFee:          fee,
+			Salt:            salt[:],
+			SenderAddress:   *genesisAddr,
+			SenderPublicKey: genesisKey.PublicKey(),
+		}
+		require.NoError(t, tx.Sign(genesisKey, bc.GetChainID()))
+		return tx
+	}
+
+	// A block paying 20 nanoTHRYLOS per byte sets the recent fee rates
+	confirmed := transfer("confirmed-fee", types.UTXO{TransactionID: genesisTx.ID, Index: 0, OwnerAddress: owner, Amount: supply}, 0)
+	data, err := confirmed.Marshal()
+	require.NoError(t, err)
+	confirmed = transfer(confirmed.ID, confirmed.Inputs[0], 20*(len(data)+8))
+	b1 := newSignedBlock(t, genesis, validator, confirmed)
+	require.NoError(t, bc.ProcessBlock(b1))
+
+	// The pool only accepts transactions paying the minimum relay fee
+	change := types.UTXO{TransactionID: confirmed.ID, Index: 0, OwnerAddress: owner, Amount: confirmed.Outputs[0].Amount}
+	err = pool.AddTransaction(transfer("free-ride", change, 0))
+	require.True(t, errors.Is(err, chain.ErrFeeTooLow), "Unexpected error: %v", err)
+	require.NoError(t, pool.AddTransaction(transfer("pending-fee", change, 100_000)))
+
+	estimate, err := chain.NewFeeEstimator(bc.Blockchain, pool).Estimate()
+	require.NoError(t, err)
+	require.Equal(t, float64(config.MinRelayFeePerByte), estimate.MinRelayFeePerByte)
+	require.GreaterOrEqual(t, estimate.Low.FeePerByte, estimate.MinRelayFeePerByte)
+	require.InDelta(t, 20, estimate.Medium.FeePerByte, 0.5)
+	require.LessOrEqual(t, estimate.Low.FeePerByte, estimate.Medium.FeePerByte)
+	require.LessOrEqual(t, estimate.Medium.FeePerByte, estimate.High.FeePerByte)
+
+	// With far less than a block pending, every level is expected in the next block
+	for _, suggestion := range []chain.FeeSuggestion{estimate.Low, estimate.Medium, estimate.High} {
+		require.Equal(t, b1.Index+1, suggestion.InclusionHeight)
+	}
+	require.Equal(t, 2*len(data), chain.FeeSuggestion{FeePerByte: 2}.FeeFor(len(data)))
+}
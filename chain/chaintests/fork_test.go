@@ -0,0 +1,322 @@
+package chaintests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/encryption"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/store"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+type testValidator struct {
+	key     crypto.PrivateKey
+	address string
+}
+
+func newTestValidator(t *testing.T, bc *chain.BlockchainImpl, stake amount.Amount) testValidator {
+	key, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	addr, err := key.PublicKey().Address()
+	require.NoError(t, err)
+	require.NoError(t, bc.Blockchain.Database.SavePublicKey(key.PublicKey()))
+	if bc.Blockchain.BondedStake == nil {
+		bc.Blockchain.BondedStake = make(map[string]amount.Amount)
+	}
+	bc.Blockchain.BondedStake[addr.String()] = stake
+	bc.Blockchain.ActiveValidators = append(bc.Blockchain.ActiveValidators, addr.String())
+	return testValidator{key: key, address: addr.String()}
+}
+
+func newSignedBlock(t *testing.T, parent *types.Block, v testValidator, txs ...*types.Transaction) *types.Block {
+	block := &types.Block{
+		Index:        parent.Index + 1,
+		Timestamp:    parent.Timestamp + 1,
+		PrevHash:     parent.Hash,
+		Transactions: txs,
+		Validator:    v.address,
+	}
+	require.NoError(t, chain.InitializeVerkleTree(block))
+	chain.SignBlock(block, v.key)
+	return block
+}
+
+// newTransfer returns a transaction from the owner of key spending inputs into outputs,
+// signed for chainID.
+func newTransfer(t *testing.T, key crypto.PrivateKey, chainID, id string, inputs []types.UTXO, outputs ...types.UTXO) *types.Transaction {
+	addr, err := key.PublicKey().Address()
+	require.NoError(t, err)
+	tx := &types.Transaction{
+		ID:              id,
+		Inputs:          inputs,
+		Outputs:         outputs,
+		SenderAddress:   *addr,
+		SenderPublicKey: key.PublicKey(),
+	}
+	require.NoError(t, tx.Sign(key, chainID))
+	return tx
+}
+
+// genesisOutput returns the output of the test chain genesis block, owned by the
+// genesis key.
+func genesisOutput(bc *chain.BlockchainImpl) types.UTXO {
+	genesisTx := bc.Blockchain.Genesis.Transactions[0]
+	output := genesisTx.Outputs[0]
+	output.TransactionID = genesisTx.ID
+	output.Index = 0
+	return output
+}
+
+func newForkTestBlockchain(t *testing.T) (*chain.BlockchainImpl, types.Store) {
+	bc, blockchainStore, _ := newTestBlockchainWithGenesisKey(t)
+	return bc, blockchainStore
+}
+
+// newTestBlockchainWithGenesisKey also returns the key owning the genesis output.
+func newTestBlockchainWithGenesisKey(t *testing.T) (*chain.BlockchainImpl, types.Store, crypto.PrivateKey) {
+	priv, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	aesKey, err := encryption.GenerateAESKey()
+	require.NoError(t, err)
+
+	bc, blockchainStore, err := chain.NewBlockchain(&types.BlockchainConfig{
+		InMemory:          true,
+		AESKey:            aesKey,
+		GenesisAccount:    priv,
+		TestMode:          true,
+		DisableBackground: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { blockchainStore.(interface{ Close() error }).Close() })
+	return bc, blockchainStore, priv
+}
+
+// newTestDatabase returns an in-memory database that is closed when the test ends.
+// Opening a chain on it again stands in for restarting the node.
+func newTestDatabase(t *testing.T) *store.Database {
+	database, err := store.NewInMemoryDatabase()
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func TestForkChoiceReorganizesToHeavierBranch(t *testing.T) {
+	bc, blockchainStore, genesisOwner := newTestBlockchainWithGenesisKey(t)
+	genesis := bc.Blockchain.Genesis
+	genesisTx := genesis.Transactions[0]
+	genesisKey := fmt.Sprintf("%s:0", genesisTx.ID)
+	require.Contains(t, bc.Blockchain.UTXOs, genesisKey)
+
+	validatorA := newTestValidator(t, bc, 100)
+	validatorB := newTestValidator(t, bc, 50)
+
+	// Blocks cannot pay out more than their transactions spend
+	overspend := newTransfer(t, genesisOwner, bc.GetChainID(), "overspend", []types.UTXO{genesisOutput(bc)},
+		types.UTXO{OwnerAddress: "tl1other", Amount: genesisTx.Outputs[0].Amount + 1})
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, genesis, validatorA, overspend)))
+	require.Len(t, bc.Blockchain.Blocks, 1)
+
+	// A1 spends the genesis output
+	ownerAddr, err := genesisOwner.PublicKey().Address()
+	require.NoError(t, err)
+	spendTx := &types.Transaction{
+		ID:              "spend-genesis",
+		Inputs:          []types.UTXO{{TransactionID: genesisTx.ID, Index: 0, OwnerAddress: genesisTx.Outputs[0].OwnerAddress, Amount: genesisTx.Outputs[0].Amount}},
+		Outputs:         []types.UTXO{{OwnerAddress: "tl1recipient", Amount: genesisTx.Outputs[0].Amount}},
+		SenderAddress:   *ownerAddr,
+		SenderPublicKey: genesisOwner.PublicKey(),
+	}
+	require.NoError(t, spendTx.Sign(genesisOwner, bc.GetChainID()))
+	a1 := newSignedBlock(t, genesis, validatorA, spendTx)
+	require.NoError(t, bc.ProcessBlock(a1))
+	require.Len(t, bc.Blockchain.Blocks, 2)
+	require.NotContains(t, bc.Blockchain.UTXOs, genesisKey)
+	require.Contains(t, bc.Blockchain.UTXOs, "spend-genesis:0")
+
+	// Side branches cannot create coins
+	mintTx := &types.Transaction{
+		ID:      "mint",
+		Outputs: []types.UTXO{{OwnerAddress: "tl1other", Amount: 10}},
+	}
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, genesis, validatorB, mintTx)))
+	require.Empty(t, bc.GetForks())
+
+	// A competing branch from validator B is tracked but not adopted while it is lighter
+	branchTx := newTransfer(t, genesisOwner, bc.GetChainID(), "branch-b", []types.UTXO{genesisOutput(bc)},
+		types.UTXO{OwnerAddress: "tl1other", Amount: genesisTx.Outputs[0].Amount})
+	b1 := newSignedBlock(t, genesis, validatorB, branchTx)
+	require.NoError(t, bc.ProcessBlock(b1))
+	require.Len(t, bc.GetForks(), 1)
+	require.True(t, bc.Blockchain.Blocks[1].Hash.Equal(a1.Hash))
+
+	// Equal weight keeps the canonical branch
+	b2 := newSignedBlock(t, b1, validatorB)
+	require.NoError(t, bc.ProcessBlock(b2))
+	require.True(t, bc.Blockchain.Blocks[1].Hash.Equal(a1.Hash))
+
+	// The heavier branch becomes canonical: the UTXO changes of A1 are undone and the
+	// genesis output is spent by B1 instead
+	b3 := newSignedBlock(t, b2, validatorB)
+	require.NoError(t, bc.ProcessBlock(b3))
+	require.Len(t, bc.Blockchain.Blocks, 4)
+	require.True(t, bc.Blockchain.Blocks[1].Hash.Equal(b1.Hash))
+	require.True(t, bc.Blockchain.Blocks[3].Hash.Equal(b3.Hash))
+	require.NotContains(t, bc.Blockchain.UTXOs, genesisKey)
+	require.NotContains(t, bc.Blockchain.UTXOs, "spend-genesis:0")
+	require.Contains(t, bc.Blockchain.UTXOs, "branch-b:0")
+
+	// The replaced canonical block is kept as a side branch
+	forks := bc.GetForks()
+	require.Len(t, forks, 1)
+	require.Equal(t, 1, forks[0].Index)
+	require.True(t, forks[0].Blocks[0].Hash.Equal(a1.Hash))
+
+	// The store follows the new canonical chain
+	stored, err := blockchainStore.GetBlock(1)
+	require.NoError(t, err)
+	require.True(t, stored.Hash.Equal(b1.Hash))
+	undo, err := blockchainStore.GetBlockUndo(b1.Hash)
+	require.NoError(t, err)
+	require.Len(t, undo.Created, 1)
+	require.Len(t, undo.Spent, 1)
+}
+
+func TestForkWithUnavailableSpendIsRejected(t *testing.T) {
+	bc, _ := newForkTestBlockchain(t)
+	genesis := bc.Blockchain.Genesis
+
+	validatorA := newTestValidator(t, bc, 100)
+	validatorC := newTestValidator(t, bc, 1000)
+
+	a1 := newSignedBlock(t, genesis, validatorA)
+	require.NoError(t, bc.ProcessBlock(a1))
+
+	// Heavier, but spends an output that does not exist
+	thief, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	thiefAddr, err := thief.PublicKey().Address()
+	require.NoError(t, err)
+	badTx := &types.Transaction{
+		ID:              "bad-spend",
+		Inputs:          []types.UTXO{{TransactionID: "missing", Index: 0}},
+		Outputs:         []types.UTXO{{OwnerAddress: "tl1thief", Amount: 10}},
+		SenderAddress:   *thiefAddr,
+		SenderPublicKey: thief.PublicKey(),
+	}
+	require.NoError(t, badTx.Sign(thief, bc.GetChainID()))
+	c1 := newSignedBlock(t, genesis, validatorC, badTx)
+	err = bc.ProcessBlock(c1)
+	require.Error(t, err)
+	require.True(t, bc.Blockchain.Blocks[1].Hash.Equal(a1.Hash), "Canonical chain must be restored")
+	require.Empty(t, bc.GetForks(), "Invalid branch must be dropped")
+
+	// Outputs can only be spent by their owner
+	theft := newTransfer(t, thief, bc.GetChainID(), "theft", []types.UTXO{genesisOutput(bc)},
+		types.UTXO{OwnerAddress: "tl1thief", Amount: genesisOutput(bc).Amount})
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, a1, validatorA, theft)))
+	require.Len(t, bc.Blockchain.Blocks, 2)
+
+	// Only active validators produce blocks
+	outsider := newTestValidator(t, bc, 1000)
+	bc.Blockchain.ActiveValidators = bc.Blockchain.ActiveValidators[:len(bc.Blockchain.ActiveValidators)-1]
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, a1, outsider)))
+
+	// Blocks without a known parent are refused
+	orphanParent := &types.Block{Index: 5, Hash: a1.PrevHash}
+	orphanParent.Hash[0] ^= 0xff
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, orphanParent, validatorA)))
+}
+
+func TestNegativeOutputsAreRejected(t *testing.T) {
+	bc, _, genesisOwner := newTestBlockchainWithGenesisKey(t)
+	genesis := bc.Blockchain.Genesis
+	validatorA := newTestValidator(t, bc, 100)
+	validatorB := newTestValidator(t, bc, 1000)
+
+	// The outputs balance the input, but the large one is paid for by a negative one
+	input := genesisOutput(bc)
+	mint := newTransfer(t, genesisOwner, bc.GetChainID(), "mint-negative", []types.UTXO{input},
+		types.UTXO{OwnerAddress: "tl1other", Amount: input.Amount + 1_000_000},
+		types.UTXO{OwnerAddress: "tl1other", Amount: -1_000_000})
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, genesis, validatorA, mint)))
+	require.Len(t, bc.Blockchain.Blocks, 1)
+
+	zero := newTransfer(t, genesisOwner, bc.GetChainID(), "zero-output", []types.UTXO{input},
+		types.UTXO{OwnerAddress: "tl1other", Amount: input.Amount},
+		types.UTXO{OwnerAddress: "tl1other", Amount: 0})
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, genesis, validatorA, zero)))
+
+	// A heavier side branch carrying the same spend is not adopted either
+	a1 := newSignedBlock(t, genesis, validatorA)
+	require.NoError(t, bc.ProcessBlock(a1))
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, genesis, validatorB, mint)))
+	require.True(t, bc.Blockchain.Blocks[1].Hash.Equal(a1.Hash))
+	require.Contains(t, bc.Blockchain.UTXOs, fmt.Sprintf("%s:0", input.TransactionID))
+}
+
+// failingCommitStore fails the commit of one block, as a full disk would.
+type failingCommitStore struct {
+	types.Store
+	failHash hash.Hash
+}
+
+func (s *failingCommitStore) CommitBlock(b *types.Block, spent []types.UTXO, created []types.UTXO, undo *types.BlockUndo) error {
+	if b.Hash.Equal(s.failHash) {
+		return fmt.Errorf("commit of block %d failed", b.Index)
+	}
+	return s.Store.CommitBlock(b, spent, created, undo)
+}
+
+func TestFailedReorganizationKeepsTheStoreOnTheCanonicalBranch(t *testing.T) {
+	bc, blockchainStore, genesisOwner := newTestBlockchainWithGenesisKey(t)
+	genesis := bc.Blockchain.Genesis
+	validatorA := newTestValidator(t, bc, 100)
+	validatorB := newTestValidator(t, bc, 50)
+
+	spendA := newTransfer(t, genesisOwner, bc.GetChainID(), "spend-a", []types.UTXO{genesisOutput(bc)},
+		types.UTXO{OwnerAddress: "tl1recipient", Amount: genesisOutput(bc).Amount})
+	a1 := newSignedBlock(t, genesis, validatorA, spendA)
+	require.NoError(t, bc.ProcessBlock(a1))
+
+	spendB := newTransfer(t, genesisOwner, bc.GetChainID(), "spend-b", []types.UTXO{genesisOutput(bc)},
+		types.UTXO{OwnerAddress: "tl1other", Amount: genesisOutput(bc).Amount})
+	b1 := newSignedBlock(t, genesis, validatorB, spendB)
+	require.NoError(t, bc.ProcessBlock(b1))
+	b2 := newSignedBlock(t, b1, validatorB)
+	require.NoError(t, bc.ProcessBlock(b2))
+
+	// The store fails halfway through the switch to the heavier branch
+	bc.Blockchain.Database = &failingCommitStore{Store: blockchainStore, failHash: b2.Hash}
+	b3 := newSignedBlock(t, b2, validatorB)
+	require.Error(t, bc.ProcessBlock(b3))
+
+	require.Len(t, bc.Blockchain.Blocks, 2)
+	require.True(t, bc.Blockchain.Blocks[1].Hash.Equal(a1.Hash))
+	require.Contains(t, bc.Blockchain.UTXOs, "spend-a:0")
+	require.NotContains(t, bc.Blockchain.UTXOs, "spend-b:0")
+
+	stored, err := blockchainStore.GetBlock(1)
+	require.NoError(t, err)
+	require.True(t, stored.Hash.Equal(a1.Hash))
+	_, err = blockchainStore.GetBlock(2)
+	require.Error(t, err)
+	location, err := blockchainStore.GetTransactionLocation("spend-a")
+	require.NoError(t, err)
+	require.True(t, location.BlockHash.Equal(a1.Hash))
+	utxos, err := blockchainStore.GetUTXOsForAddress("tl1recipient")
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	utxos, err = blockchainStore.GetUTXOsForAddress("tl1other")
+	require.NoError(t, err)
+	require.Empty(t, utxos)
+
+	// Once the store recovers the branch is adopted
+	bc.Blockchain.Database = blockchainStore
+	require.NoError(t, bc.ProcessBlock(newSignedBlock(t, b3, validatorB)))
+	require.True(t, bc.Blockchain.Blocks[1].Hash.Equal(b1.Hash))
+}
@@ -0,0 +1,139 @@
+package chaintests
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/encryption"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func newTestGenesis(t *testing.T) *config.Genesis {
+	genesis, _ := newTestGenesisWithValidator(t)
+	return genesis
+}
+
+// newTestGenesisWithValidator also returns the validator of the genesis.
+func newTestGenesisWithValidator(t *testing.T) (*config.Genesis, testValidator) {
+	validatorKey, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	validatorAddr, err := validatorKey.PublicKey().Address()
+	require.NoError(t, err)
+
+	holderKey, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	holderAddr, err := holderKey.PublicKey().Address()
+	require.NoError(t, err)
+
+	genesis := &config.Genesis{
+		ChainID:   "thrylos-testnet",
+		Timestamp: 1735689600,
+		Allocations: []config.GenesisAllocation{
+			{Address: holderAddr.String(), Amount: 1_000_000_000},
+		},
+		Validators: []config.GenesisValidator{{
+			Address:   validatorAddr.String(),
+			PublicKey: base64.StdEncoding.EncodeToString(validatorKey.PublicKey().Bytes()),
+			Stake:     400_000_000,
+		}},
+		Params: config.ProtocolParams{
+			MinimumStakeAmount: 400_000_000,
+			BlockTimeSeconds:   10,
+		},
+	}
+	return genesis, testValidator{key: validatorKey, address: validatorAddr.String()}
+}
+
+func TestGenesisHashIsDeterministic(t *testing.T) {
+	genesis := newTestGenesis(t)
+
+	first, err := chain.GenesisHash(genesis)
+	require.NoError(t, err)
+	second, err := chain.GenesisHash(genesis)
+	require.NoError(t, err)
+	require.Equal(t, first, second, "The same genesis file must always produce the same hash")
+
+	// Loading the document back from a JSON file gives the same hash
+	genesisPath := filepath.Join(t.TempDir(), "genesis.json")
+	data, err := json.Marshal(genesis)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(genesisPath, data, 0644))
+	loaded, err := config.LoadGenesisFromFile(genesisPath)
+	require.NoError(t, err)
+	fromFile, err := chain.GenesisHash(loaded)
+	require.NoError(t, err)
+	require.Equal(t, first, fromFile)
+
+	// Any change to the document changes the hash
+	genesis.Params.BlockTimeSeconds = 5
+	changed, err := chain.GenesisHash(genesis)
+	require.NoError(t, err)
+	require.NotEqual(t, first, changed)
+}
+
+func TestNewBlockchainWithGenesisFile(t *testing.T) {
+	database := newTestDatabase(t)
+	priv, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	aesKey, err := encryption.GenerateAESKey()
+	require.NoError(t, err)
+
+	genesis := newTestGenesis(t)
+	expectedHash, err := chain.GenesisHash(genesis)
+	require.NoError(t, err)
+
+	cfg := &types.BlockchainConfig{
+		AESKey:            aesKey,
+		GenesisAccount:    priv,
+		TestMode:          true,
+		DisableBackground: true,
+		Genesis:           genesis,
+	}
+
+	blockchain, _, err := chain.NewBlockchainWithDatabase(cfg, database)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, blockchain.Blockchain.Genesis.Hash)
+	require.Equal(t, genesis.Timestamp, blockchain.Blockchain.Genesis.Timestamp)
+	require.Equal(t, "thrylos-testnet", blockchain.GetChainID())
+	require.Equal(t, []string{genesis.Validators[0].Address}, blockchain.Blockchain.ActiveValidators)
+	require.Equal(t, genesis.Allocations[0].Amount, blockchain.Blockchain.Stakeholders[genesis.Allocations[0].Address])
+
+	// The validator stake is locked in the genesis block instead of being created from nothing
+	validator := genesis.Validators[0]
+	require.Equal(t, amount.Amount(validator.Stake), blockchain.GetBondedStake(validator.Address))
+	require.Zero(t, blockchain.Blockchain.Stakeholders[validator.Address])
+	poolOutput := blockchain.Blockchain.Genesis.Transactions[0].Outputs[1]
+	require.Equal(t, types.StakingPoolAddress, poolOutput.OwnerAddress)
+	require.Equal(t, validator.Stake, int64(poolOutput.Amount))
+
+	// A node started with a different genesis file must refuse the database
+	other := newTestGenesis(t)
+	cfg.Genesis = other
+	_, _, err = chain.NewBlockchainWithDatabase(cfg, database)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "genesis hash mismatch")
+
+	// The original genesis file reopens the chain
+	cfg.Genesis = genesis
+	reopened, _, err := chain.NewBlockchainWithDatabase(cfg, database)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, reopened.Blockchain.Genesis.Hash)
+	require.Equal(t, amount.Amount(validator.Stake), reopened.GetBondedStake(validator.Address))
+}
+
+func TestSampleGenesisFile(t *testing.T) {
+	genesis, err := config.LoadGenesisFromFile(filepath.Join("..", "..", "config", "genesis.toml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, genesis.Validators, "The sample network needs a validator to produce blocks")
+	keys, err := chain.GenesisValidatorKeys(genesis)
+	require.NoError(t, err)
+	require.Len(t, keys, len(genesis.Validators))
+}
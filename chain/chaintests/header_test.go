@@ -0,0 +1,40 @@
+package chaintests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/chain"
+)
+
+func TestBlockHeadersMatchBlockHashes(t *testing.T) {
+	bc, _ := newForkTestBlockchain(t)
+	validator := newTestValidator(t, bc, 100)
+
+	b1 := newSignedBlock(t, bc.Blockchain.Genesis, validator)
+	require.NoError(t, bc.ProcessBlock(b1))
+	b2 := newSignedBlock(t, b1, validator)
+	require.NoError(t, bc.ProcessBlock(b2))
+
+	header, err := bc.GetHeader(2)
+	require.NoError(t, err)
+	headerHash, err := header.Hash()
+	require.NoError(t, err)
+	require.True(t, headerHash.Equal(b2.Hash), "Header hash must be the block hash")
+	require.NotNil(t, header.Signature)
+
+	headers, err := bc.GetHeaderRange(0, 2)
+	require.NoError(t, err)
+	require.Len(t, headers, 3)
+	genesisHash, err := headers[0].Hash()
+	require.NoError(t, err)
+	require.True(t, genesisHash.Equal(bc.Blockchain.Genesis.Hash))
+	require.NoError(t, chain.VerifyHeaderChain(headers))
+
+	// Altering a header breaks the link to its successor
+	headers[1].Timestamp++
+	require.Error(t, chain.VerifyHeaderChain(headers))
+
+	_, err = bc.GetHeaderRange(2, 1)
+	require.Error(t, err)
+}
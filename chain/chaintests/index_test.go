@@ -0,0 +1,72 @@
+package chaintests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func TestStoreIndexesFollowCanonicalChain(t *testing.T) {
+	bc, blockchainStore, genesisOwner := newTestBlockchainWithGenesisKey(t)
+	genesis := bc.Blockchain.Genesis
+	validatorA := newTestValidator(t, bc, 100)
+	validatorB := newTestValidator(t, bc, 150)
+
+	// Both transactions pay 5 to tl1indexed, the second out of the change of the first
+	funding := genesisOutput(bc)
+	movedTx := newTransfer(t, genesisOwner, bc.GetChainID(), "moved-tx", []types.UTXO{funding},
+		types.UTXO{OwnerAddress: "tl1indexed", Amount: 5},
+		types.UTXO{OwnerAddress: funding.OwnerAddress, Amount: funding.Amount - 5})
+	branchTx := newTransfer(t, genesisOwner, bc.GetChainID(), "branch-tx",
+		[]types.UTXO{{TransactionID: "moved-tx", Index: 1, OwnerAddress: funding.OwnerAddress, Amount: funding.Amount - 5}},
+		types.UTXO{OwnerAddress: "tl1indexed", Amount: 5},
+		types.UTXO{OwnerAddress: funding.OwnerAddress, Amount: funding.Amount - 10})
+
+	a1 := newSignedBlock(t, genesis, validatorA)
+	require.NoError(t, bc.ProcessBlock(a1))
+	a2 := newSignedBlock(t, a1, validatorA, movedTx)
+	require.NoError(t, bc.ProcessBlock(a2))
+
+	block, err := blockchainStore.GetBlockByHash(a2.Hash)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), block.Index)
+
+	loc, err := blockchainStore.GetTransactionLocation("moved-tx")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), loc.BlockHeight)
+	require.Equal(t, 0, loc.Position)
+	require.True(t, loc.BlockHash.Equal(a2.Hash))
+
+	// The competing branch includes the same transaction one block earlier
+	b1 := newSignedBlock(t, genesis, validatorB, movedTx, branchTx)
+	require.NoError(t, bc.ProcessBlock(b1))
+	b2 := newSignedBlock(t, b1, validatorB)
+	require.NoError(t, bc.ProcessBlock(b2))
+	require.True(t, bc.Blockchain.Blocks[2].Hash.Equal(b2.Hash), "Heavier branch should be canonical")
+
+	_, err = blockchainStore.GetBlockByHash(a2.Hash)
+	require.Error(t, err, "Replaced blocks must leave the hash index")
+	block, err = blockchainStore.GetBlockByHash(b1.Hash)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), block.Index)
+
+	loc, err = blockchainStore.GetTransactionLocation("moved-tx")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), loc.BlockHeight)
+	require.True(t, loc.BlockHash.Equal(b1.Hash))
+
+	history, err := blockchainStore.GetAddressHistory("tl1indexed", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "moved-tx", history[0].TxID)
+	require.Equal(t, "branch-tx", history[1].TxID)
+
+	page, err := blockchainStore.GetAddressHistory("tl1indexed", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, "branch-tx", page[0].TxID)
+
+	_, err = blockchainStore.GetAddressHistory("tl1indexed", 0, 0)
+	require.Error(t, err)
+}
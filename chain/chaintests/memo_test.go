@@ -0,0 +1,72 @@
+package chaintests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func TestTransactionMemo(t *testing.T) {
+	bc, blockchainStore, genesisKey := newTestBlockchainWithGenesisKey(t)
+	genesisTx := bc.Blockchain.Genesis.Transactions[0]
+	validator := newTestValidator(t, bc, 100)
+	input := newPoolInput(t, genesisKey, genesisTx.ID)
+	input.Amount = genesisTx.Outputs[0].Amount
+	chainID := bc.GetChainID()
+
+	withMemo := func(fee int, memo []byte) *types.Transaction {
+		tx := newPoolTransfer(t, genesisKey, chainID, "memo-tx", input, fee)
+		tx.Memo = memo
+		require.NoError(t, tx.Sign(genesisKey, chainID))
+		return tx
+	}
+
+	tooLong := withMemo(100_000, bytes.Repeat([]byte{'m'}, config.MaxMemoSize+1))
+	require.ErrorIs(t, bc.TxPool().AddTransaction(tooLong), types.ErrMemoTooLarge)
+
+	// The memo counts towards the size the relay fee is charged on
+	plain := newPoolTransfer(t, genesisKey, chainID, "memo-tx", input, 1_000)
+	data, err := plain.Marshal()
+	require.NoError(t, err)
+	fee := chain.MinRelayFee(len(data)) + 100
+	memo := bytes.Repeat([]byte{'m'}, config.MaxMemoSize)
+	require.ErrorIs(t, bc.TxPool().AddTransaction(withMemo(fee, memo)), chain.ErrFeeTooLow)
+
+	// The memo is covered by the signature
+	tampered := withMemo(100_000, memo)
+	tampered.Memo = []byte("changed after signing")
+	require.Error(t, bc.TxPool().AddTransaction(tampered))
+
+	tx := withMemo(100_000, memo)
+	require.NoError(t, bc.TxPool().AddTransaction(tx))
+	block := newSignedBlock(t, bc.Blockchain.Genesis, validator, tx)
+	require.NoError(t, bc.ProcessBlock(block))
+
+	locations, err := blockchainStore.GetTransactionsByMemo(memo, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	require.Equal(t, "memo-tx", locations[0].TxID)
+	require.True(t, locations[0].BlockHash.Equal(block.Hash))
+
+	txs, err := chain.GetTransactionsByMemo(blockchainStore, memo, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, memo, txs[0].Memo)
+
+	locations, err = blockchainStore.GetTransactionsByMemo([]byte("other memo"), 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, locations)
+
+	// Blocks carrying an oversized memo break the consensus rules
+	oversized := &types.Transaction{
+		ID:      "oversized-memo",
+		Outputs: []types.UTXO{{OwnerAddress: "tl1memo", Amount: 5}},
+		Memo:    tooLong.Memo,
+	}
+	err = bc.ProcessBlock(newSignedBlock(t, block, validator, oversized))
+	require.ErrorIs(t, err, types.ErrMemoTooLarge)
+}
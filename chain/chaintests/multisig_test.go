@@ -0,0 +1,102 @@
+package chaintests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/shared"
+	"github.com/thrylos-labs/thrylos/types"
+	"github.com/thrylos-labs/thrylos/utils"
+)
+
+func TestMultisigOutputNeedsThresholdSignatures(t *testing.T) {
+	bc, blockchainStore, genesisKey := newTestBlockchainWithGenesisKey(t)
+	genesis := bc.Blockchain.Genesis
+	genesisTx := genesis.Transactions[0]
+	validator := newTestValidator(t, bc, 100)
+	chainID := bc.GetChainID()
+
+	keys := make([]crypto.PrivateKey, 3)
+	pubKeys := make([]crypto.PublicKey, 3)
+	for i := range keys {
+		key, err := crypto.NewPrivateKey()
+		require.NoError(t, err)
+		keys[i], pubKeys[i] = key, key.PublicKey()
+	}
+	policy, err := types.NewMultisigPolicy(2, pubKeys)
+	require.NoError(t, err)
+	treasury, err := policy.Address()
+	require.NoError(t, err)
+
+	// The address depends on the key set and threshold, not the key order
+	reversed, err := types.NewMultisigPolicy(2, []crypto.PublicKey{pubKeys[2], pubKeys[1], pubKeys[0]})
+	require.NoError(t, err)
+	reversedAddr, err := reversed.Address()
+	require.NoError(t, err)
+	require.Equal(t, treasury.String(), reversedAddr.String())
+	oneOfThree, err := crypto.MultisigAddress(1, pubKeys)
+	require.NoError(t, err)
+	require.NotEqual(t, treasury.String(), oneOfThree.String())
+
+	// Fund the treasury from the genesis account
+	genesisAddr, err := genesisKey.PublicKey().Address()
+	require.NoError(t, err)
+	supply := genesisTx.Outputs[0].Amount
+	fund := &types.Transaction{
+		ID:              "fund-treasury",
+		Inputs:          []types.UTXO{{TransactionID: genesisTx.ID, Index: 0, OwnerAddress: genesisAddr.String(), Amount: supply}},
+		Outputs:         []types.UTXO{{TransactionID: "fund-treasury", Index: 0, OwnerAddress: treasury.String(), Amount: supply}},
+		SenderAddress:   *genesisAddr,
+		SenderPublicKey: genesisKey.PublicKey(),
+	}
+	require.NoError(t, fund.Sign(genesisKey, chainID))
+
+	// Spend from the treasury, collecting partial signatures from two of the keys
+	spend := &types.Transaction{
+		ID:            "treasury-spend",
+		Inputs:        []types.UTXO{{TransactionID: "fund-treasury", Index: 0, OwnerAddress: treasury.String(), Amount: supply}},
+		Outputs:       []types.UTXO{{TransactionID: "treasury-spend", Index: 0, OwnerAddress: validator.address, Amount: supply}},
+		SenderAddress: *treasury,
+		Multisig:      policy,
+	}
+	outsider, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	_, err = spend.SignMultisig(outsider, chainID)
+	require.Error(t, err)
+
+	first, err := spend.SignMultisig(keys[2], chainID)
+	require.NoError(t, err)
+	require.NoError(t, spend.AddMultisigSignature(first, chainID))
+	require.Error(t, spend.AddMultisigSignature(first, chainID), "The same key cannot sign twice")
+	require.Equal(t, 1, spend.MultisigSignaturesNeeded())
+	require.Error(t, shared.VerifyTransactionSignature(spend, chainID))
+
+	second, err := spend.SignMultisig(keys[0], chainID)
+	require.NoError(t, err)
+	require.NoError(t, spend.AddMultisigSignature(second, chainID))
+	require.Equal(t, 0, spend.MultisigSignaturesNeeded())
+	require.NoError(t, shared.VerifyTransactionSignature(spend, chainID))
+	require.Error(t, shared.VerifyTransactionSignature(spend, chainID+"-other"))
+
+	// A policy that does not belong to the sender address is rejected
+	forged := *spend
+	forged.SenderAddress = *genesisAddr
+	require.Error(t, shared.VerifyTransactionSignature(&forged, chainID))
+
+	b1 := newSignedBlock(t, genesis, validator, fund)
+	require.NoError(t, bc.ProcessBlock(b1))
+	b2 := newSignedBlock(t, b1, validator, spend)
+	require.NoError(t, bc.ProcessBlock(b2))
+
+	// The multisig fields survive the store round trip and the chain verifies
+	report, err := chain.VerifyChain(blockchainStore, chainID)
+	require.NoError(t, err)
+	require.True(t, report.Valid, "Unexpected failure: %+v", report.Failure)
+
+	// The protobuf form carries the policy and signatures too
+	protoTx, err := chain.ConvertToThrylosTransaction(spend)
+	require.NoError(t, err)
+	require.NoError(t, shared.VerifyTransactionSignature(utils.ConvertToSharedTransaction(protoTx), chainID))
+}
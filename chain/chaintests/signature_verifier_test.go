@@ -0,0 +1,63 @@
+package chaintests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func TestSignatureVerifierBatchAndCache(t *testing.T) {
+	key, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	verifier, err := chain.NewSignatureVerifier(2, 16)
+	require.NoError(t, err)
+
+	txs := make([]*types.Transaction, 4)
+	for i := range txs {
+		txs[i] = newPoolTransfer(t, key, "tl1", fmt.Sprintf("batch-%d", i), newPoolInput(t, key, fmt.Sprintf("input-%d", i)), 10_000)
+	}
+	for _, err := range verifier.VerifyBatch(txs, "tl1") {
+		require.NoError(t, err)
+	}
+	require.Equal(t, chain.SignatureVerifierStats{Verified: 4}, verifier.Stats())
+
+	// Verified signatures are answered from the cache
+	require.NoError(t, verifier.VerifyBlock(&types.Block{Index: 1, Transactions: txs}, "tl1"))
+	require.Equal(t, chain.SignatureVerifierStats{Verified: 4, CacheHits: 4}, verifier.Stats())
+
+	// The cache does not vouch for another chain or for a changed transaction
+	require.Error(t, verifier.Verify(txs[0], "tl2"))
+	txs[1].Outputs[0].Amount--
+	err = verifier.VerifyBlock(&types.Block{Index: 1, Transactions: txs}, "tl1")
+	require.ErrorContains(t, err, "batch-1")
+	require.Equal(t, uint64(6), verifier.Stats().Verified)
+
+	// Transactions without inputs are checked too, except in the genesis block
+	mint := &types.Transaction{ID: "mint", Outputs: []types.UTXO{{OwnerAddress: "tl1other", Amount: 10}}}
+	require.ErrorContains(t, verifier.VerifyBlock(&types.Block{Index: 1, Transactions: []*types.Transaction{mint}}, "tl1"), "mint")
+	require.NoError(t, verifier.VerifyBlock(&types.Block{Transactions: []*types.Transaction{mint}}, "tl1"))
+}
+
+func TestPoolAdmissionSignatureIsNotVerifiedAgainInBlock(t *testing.T) {
+	bc, _, genesisKey := newTestBlockchainWithGenesisKey(t)
+	genesisTx := bc.Blockchain.Genesis.Transactions[0]
+	validator := newTestValidator(t, bc, 100)
+	input := newPoolInput(t, genesisKey, genesisTx.ID)
+	input.Amount = genesisTx.Outputs[0].Amount
+
+	tx := newPoolTransfer(t, genesisKey, bc.GetChainID(), "admitted", input, 100_000)
+	require.NoError(t, bc.TxPool().AddTransaction(tx))
+	require.Equal(t, chain.SignatureVerifierStats{Verified: 1}, bc.SignatureVerifier().Stats())
+
+	require.NoError(t, bc.ProcessBlock(newSignedBlock(t, bc.Blockchain.Genesis, validator, tx)))
+	require.Equal(t, chain.SignatureVerifierStats{Verified: 1, CacheHits: 1}, bc.SignatureVerifier().Stats())
+
+	// Unsigned transactions are refused by the pool
+	unsigned := newPoolTransfer(t, genesisKey, bc.GetChainID(), "unsigned", newPoolInput(t, genesisKey, "admitted"), 100_000)
+	unsigned.Signature = nil
+	require.Error(t, bc.TxPool().AddTransaction(unsigned))
+}
@@ -0,0 +1,169 @@
+package chaintests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func newTypedTransaction(t *testing.T, key crypto.PrivateKey, chainID, id string, txType types.TransactionType, payload interface{}, inputs, outputs []types.UTXO) *types.Transaction {
+	addr, err := key.PublicKey().Address()
+	require.NoError(t, err)
+	tx := &types.Transaction{
+		ID:              id,
+		Type:            txType,
+		Inputs:          inputs,
+		Outputs:         outputs,
+		SenderAddress:   *addr,
+		SenderPublicKey: key.PublicKey(),
+	}
+	if payload != nil {
+		require.NoError(t, tx.SetPayload(payload))
+	}
+	require.NoError(t, tx.Sign(key, chainID))
+	return tx
+}
+
+func TestStakingTransactionsUpdateLedger(t *testing.T) {
+	bc, _, genesisKey := newTestBlockchainWithGenesisKey(t)
+	genesisTx := bc.Blockchain.Genesis.Transactions[0]
+	supply := genesisTx.Outputs[0].Amount
+	validator := newTestValidator(t, bc, 100)
+
+	genesisAddr, err := genesisKey.PublicKey().Address()
+	require.NoError(t, err)
+	staker := genesisAddr.String()
+	delegatorKey, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	delegatorAddr, err := delegatorKey.PublicKey().Address()
+	require.NoError(t, err)
+	delegator := delegatorAddr.String()
+
+	minStake := amount.Amount(config.MinimumStakeAmount)
+	const delegated = amount.Amount(500)
+
+	// The genesis account bonds the minimum stake and funds the delegator
+	stake := newTypedTransaction(t, genesisKey, bc.GetChainID(), "stake-tx", types.TransactionTypeStake,
+		types.StakePayload{Amount: minStake},
+		[]types.UTXO{{TransactionID: genesisTx.ID, Index: 0, OwnerAddress: staker, Amount: supply}},
+		[]types.UTXO{
+			{OwnerAddress: types.StakingPoolAddress, Amount: minStake},
+			{OwnerAddress: delegator, Amount: delegated},
+			{OwnerAddress: staker, Amount: supply - minStake - delegated},
+		})
+	b1 := newSignedBlock(t, bc.Blockchain.Genesis, validator, stake)
+	require.NoError(t, bc.ProcessBlock(b1))
+	require.Equal(t, minStake, bc.GetBondedStake(staker))
+
+	// Delegating to an address that is not a validator is rejected
+	earlyDelegation := newTypedTransaction(t, delegatorKey, bc.GetChainID(), "early-delegate-tx", types.TransactionTypeDelegate,
+		types.DelegatePayload{Validator: staker, Amount: delegated},
+		[]types.UTXO{{TransactionID: "stake-tx", Index: 1, OwnerAddress: delegator, Amount: delegated}},
+		[]types.UTXO{{OwnerAddress: types.StakingPoolAddress, Amount: delegated}})
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, b1, validator, earlyDelegation)))
+	require.Len(t, bc.Blockchain.Blocks, 2)
+
+	_, err = bc.Blockchain.Database.GetPublicKey(*genesisAddr)
+	require.Error(t, err)
+	change := types.UTXO{TransactionID: "stake-tx", Index: 2, OwnerAddress: staker, Amount: supply - minStake - delegated}
+	register := newTypedTransaction(t, genesisKey, bc.GetChainID(), "register-tx", types.TransactionTypeRegisterValidator,
+		types.RegisterValidatorPayload{PublicKey: genesisKey.PublicKey().Bytes()},
+		[]types.UTXO{change}, []types.UTXO{{OwnerAddress: staker, Amount: change.Amount}})
+	b2 := newSignedBlock(t, b1, validator, register)
+	require.NoError(t, bc.ProcessBlock(b2))
+	require.Contains(t, bc.Blockchain.ActiveValidators, staker)
+	_, err = bc.Blockchain.Database.GetPublicKey(*genesisAddr)
+	require.NoError(t, err, "Registration must persist the validator key")
+
+	delegation := newTypedTransaction(t, delegatorKey, bc.GetChainID(), "delegate-tx", types.TransactionTypeDelegate,
+		types.DelegatePayload{Validator: staker, Amount: delegated},
+		[]types.UTXO{{TransactionID: "stake-tx", Index: 1, OwnerAddress: delegator, Amount: delegated}},
+		[]types.UTXO{{OwnerAddress: types.StakingPoolAddress, Amount: delegated}})
+	b3 := newSignedBlock(t, b2, validator, delegation)
+	require.NoError(t, bc.ProcessBlock(b3))
+	require.Equal(t, minStake+delegated, bc.GetBondedStake(staker))
+	require.Equal(t, delegated, bc.GetDelegation(delegator, staker))
+
+	// Releasing more than was delegated is rejected and leaves the chain untouched
+	overdrawn := newTypedTransaction(t, delegatorKey, bc.GetChainID(), "overdrawn-unstake-tx", types.TransactionTypeUnstake,
+		types.UnstakePayload{Validator: staker, Amount: delegated + 1},
+		[]types.UTXO{{TransactionID: "stake-tx", Index: 0, OwnerAddress: types.StakingPoolAddress, Amount: minStake}},
+		[]types.UTXO{
+			{OwnerAddress: delegator, Amount: delegated + 1},
+			{OwnerAddress: types.StakingPoolAddress, Amount: minStake - delegated - 1},
+		})
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, b3, validator, overdrawn)))
+	require.Len(t, bc.Blockchain.Blocks, 4)
+	require.Equal(t, delegated, bc.GetDelegation(delegator, staker))
+
+	// Releasing the right amount while keeping the rest of a larger pool output is rejected
+	keepsPool := newTypedTransaction(t, delegatorKey, bc.GetChainID(), "keeps-pool-unstake-tx", types.TransactionTypeUnstake,
+		types.UnstakePayload{Validator: staker, Amount: delegated},
+		[]types.UTXO{{TransactionID: "stake-tx", Index: 0, OwnerAddress: types.StakingPoolAddress, Amount: minStake}},
+		[]types.UTXO{{OwnerAddress: delegator, Amount: delegated}})
+	require.Error(t, bc.ProcessBlock(newSignedBlock(t, b3, validator, keepsPool)))
+	require.Len(t, bc.Blockchain.Blocks, 4)
+
+	unstake := newTypedTransaction(t, delegatorKey, bc.GetChainID(), "unstake-tx", types.TransactionTypeUnstake,
+		types.UnstakePayload{Validator: staker, Amount: delegated},
+		[]types.UTXO{{TransactionID: "delegate-tx", Index: 0, OwnerAddress: types.StakingPoolAddress, Amount: delegated}},
+		[]types.UTXO{{OwnerAddress: delegator, Amount: delegated}})
+	require.NoError(t, bc.ProcessBlock(newSignedBlock(t, b3, validator, unstake)))
+	require.Equal(t, minStake, bc.GetBondedStake(staker))
+	require.Zero(t, bc.GetDelegation(delegator, staker))
+}
+
+func TestDelegationOverflowIsRejected(t *testing.T) {
+	bc, _, genesisKey := newTestBlockchainWithGenesisKey(t)
+	genesisTx := bc.Blockchain.Genesis.Transactions[0]
+	supply := genesisTx.Outputs[0].Amount
+	producer := newTestValidator(t, bc, 100)
+	full := newTestValidator(t, bc, math.MaxInt64-100)
+
+	genesisAddr, err := genesisKey.PublicKey().Address()
+	require.NoError(t, err)
+	delegator := genesisAddr.String()
+
+	// Bonding more to a validator than its stake total can hold fails the block
+	delegation := newTypedTransaction(t, genesisKey, bc.GetChainID(), "overflow-delegate-tx", types.TransactionTypeDelegate,
+		types.DelegatePayload{Validator: full.address, Amount: 101},
+		[]types.UTXO{{TransactionID: genesisTx.ID, Index: 0, OwnerAddress: delegator, Amount: supply}},
+		[]types.UTXO{
+			{OwnerAddress: types.StakingPoolAddress, Amount: 101},
+			{OwnerAddress: delegator, Amount: supply - 101},
+		})
+	err = bc.ProcessBlock(newSignedBlock(t, bc.Blockchain.Genesis, producer, delegation))
+	require.ErrorContains(t, err, amount.ErrOverflow.Error())
+	require.Len(t, bc.Blockchain.Blocks, 1)
+	require.Equal(t, amount.Amount(math.MaxInt64-100), bc.GetBondedStake(full.address))
+	require.Zero(t, bc.GetDelegation(delegator, full.address))
+}
+
+func TestTransactionPayloadValidation(t *testing.T) {
+	tx := &types.Transaction{ID: "transfer", Payload: []byte{0x01}}
+	require.Error(t, tx.ValidatePayload(), "Transfers carry no payload")
+
+	tx = &types.Transaction{ID: "stake", Type: types.TransactionTypeStake}
+	require.Error(t, tx.ValidatePayload(), "Stake transactions need a payload")
+	require.NoError(t, tx.SetPayload(types.StakePayload{Amount: 0}))
+	require.Error(t, tx.ValidatePayload())
+	require.NoError(t, tx.SetPayload(types.StakePayload{Amount: 10}))
+	require.NoError(t, tx.ValidatePayload())
+
+	data, err := tx.Marshal()
+	require.NoError(t, err)
+	var decoded types.Transaction
+	require.NoError(t, decoded.Unmarshal(data))
+	require.Equal(t, types.TransactionTypeStake, decoded.Type)
+	var payload types.StakePayload
+	require.NoError(t, decoded.DecodePayload(&payload))
+	require.Equal(t, amount.Amount(10), payload.Amount)
+
+	tx = &types.Transaction{ID: "unknown", Type: types.TransactionType(42)}
+	require.Error(t, tx.ValidatePayload())
+}
@@ -1,5 +1,23 @@
 package chaintests
 
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/encryption"
+	"github.com/thrylos-labs/thrylos/shared"
+	"github.com/thrylos-labs/thrylos/store"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
 // func TestAddTransaction(t *testing.T) {
 // 	pool := chain.NewTxPool()
 // 	tx := &types.Transaction{ID: "tx1"}
@@ -81,3 +99,337 @@ package chaintests
 // 	assert.NoError(t, err)
 // 	assert.Equal(t, 0, pool.Size())
 // }
+
+func newTestTxPool(t *testing.T, cfg chain.TxPoolConfig) (types.TxPool, *chain.BlockchainImpl, crypto.PrivateKey, string) {
+	bc, blockchainStore, genesisKey := newTestBlockchainWithGenesisKey(t)
+	return chain.NewTxPoolWithConfig(blockchainStore, bc, cfg), bc, genesisKey, bc.GetChainID()
+}
+
+// newPoolTransfer returns a signed transfer of input back to its owner paying fee.
+func newPoolTransfer(t *testing.T, key crypto.PrivateKey, chainID, id string, input types.UTXO, fee int) *types.Transaction {
+	addr, err := key.PublicKey().Address()
+	require.NoError(t, err)
+	salt := sha256.Sum256([]byte(id))
+	tx := &types.Transaction{
+		ID:              id,
+		Inputs:          []types.UTXO{input},
+		Outputs:         []types.UTXO{{OwnerAddress: addr.String(), Amount: input.Amount - amount.Amount(fee)}},
+		GasFee:          fee,
+		Salt:            salt[:],
+		SenderAddress:   *addr,
+		SenderPublicKey: key.PublicKey(),
+	}
+	require.NoError(t, tx.Sign(key, chainID))
+	return tx
+}
+
+func newPoolInput(t *testing.T, key crypto.PrivateKey, txID string) types.UTXO {
+	addr, err := key.PublicKey().Address()
+	require.NoError(t, err)
+	return types.UTXO{TransactionID: txID, Index: 0, OwnerAddress: addr.String(), Amount: 1_000_000}
+}
+
+// newFundedPoolInput returns newPoolInput for key and adds it to the UTXO set of bc.
+func newFundedPoolInput(t *testing.T, bc *chain.BlockchainImpl, key crypto.PrivateKey, txID string) types.UTXO {
+	input := newPoolInput(t, key, txID)
+	bc.Blockchain.UTXOs[fmt.Sprintf("%s:0", txID)] = []*thrylos.UTXO{{
+		TransactionId: txID,
+		OwnerAddress:  input.OwnerAddress,
+		Amount:        int64(input.Amount),
+	}}
+	return input
+}
+
+// pooledOutput returns the first output of a pooled transaction as an input.
+func pooledOutput(tx *types.Transaction) types.UTXO {
+	output := tx.Outputs[0]
+	output.TransactionID = tx.ID
+	output.Index = 0
+	return output
+}
+
+func transactionIDs(txs []*types.Transaction) []string {
+	ids := make([]string, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ID
+	}
+	return ids
+}
+
+func TestTxPoolOrdersByFeeRate(t *testing.T) {
+	pool, bc, key, chainID := newTestTxPool(t, chain.DefaultTxPoolConfig())
+
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "low", newFundedPoolInput(t, bc, key, "a"), 10_000)))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "high", newFundedPoolInput(t, bc, key, "b"), 30_000)))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "medium", newFundedPoolInput(t, bc, key, "c"), 20_000)))
+
+	txs, err := pool.GetAllTransactions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"high", "medium", "low"}, transactionIDs(txs))
+
+	first, err := pool.GetFirstTransaction()
+	require.NoError(t, err)
+	require.Equal(t, "high", first.ID)
+
+	require.NoError(t, pool.RemoveTransaction(first))
+	first, err = pool.GetFirstTransaction()
+	require.NoError(t, err)
+	require.Equal(t, "medium", first.ID)
+	require.Equal(t, 2, pool.Size())
+}
+
+func TestTxPoolPerSenderLimit(t *testing.T) {
+	cfg := chain.DefaultTxPoolConfig()
+	cfg.MaxPerSender = 2
+	pool, bc, key, chainID := newTestTxPool(t, cfg)
+
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "tx1", newFundedPoolInput(t, bc, key, "a"), 10_000)))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "tx2", newFundedPoolInput(t, bc, key, "b"), 10_000)))
+	err := pool.AddTransaction(newPoolTransfer(t, key, chainID, "tx3", newFundedPoolInput(t, bc, key, "c"), 10_000))
+	require.True(t, errors.Is(err, chain.ErrSenderLimit), "Unexpected error: %v", err)
+
+	other, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, other, chainID, "other", newFundedPoolInput(t, bc, other, "d"), 10_000)))
+
+	// Removing a transaction frees a slot for its sender
+	tx1, err := pool.GetTransaction("tx1")
+	require.NoError(t, err)
+	require.NoError(t, pool.RemoveTransaction(tx1))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "tx3", newFundedPoolInput(t, bc, key, "c"), 10_000)))
+}
+
+func TestTxPoolEvictsLowestFeeRate(t *testing.T) {
+	probeKey, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	data, err := newPoolTransfer(t, probeKey, "probe", "low", newPoolInput(t, probeKey, "a"), 10_000).Marshal()
+	require.NoError(t, err)
+
+	// Room for two transactions of about the same size
+	cfg := chain.DefaultTxPoolConfig()
+	cfg.MaxBytes = 2*len(data) + len(data)/2
+	pool, bc, key, chainID := newTestTxPool(t, cfg)
+
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "low", newFundedPoolInput(t, bc, key, "a"), 10_000)))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "medium", newFundedPoolInput(t, bc, key, "b"), 20_000)))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "high", newFundedPoolInput(t, bc, key, "c"), 30_000)))
+
+	txs, err := pool.GetAllTransactions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"high", "medium"}, transactionIDs(txs))
+
+	// A transaction paying less than everything in a full pool is rejected
+	err = pool.AddTransaction(newPoolTransfer(t, key, chainID, "lowest", newFundedPoolInput(t, bc, key, "d"), 15_000))
+	require.True(t, errors.Is(err, chain.ErrMempoolFull), "Unexpected error: %v", err)
+	require.Equal(t, 2, pool.Size())
+}
+
+func TestTxPoolEvictsDescendantsWithTheirParent(t *testing.T) {
+	probeKey, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	data, err := newPoolTransfer(t, probeKey, "probe", "low", newPoolInput(t, probeKey, "a"), 10_000).Marshal()
+	require.NoError(t, err)
+
+	// Room for two transactions of about the same size
+	cfg := chain.DefaultTxPoolConfig()
+	cfg.MaxBytes = 2*len(data) + len(data)/2
+	pool, bc, key, chainID := newTestTxPool(t, cfg)
+
+	// A transaction spending the output of a low fee parent is not evicted ahead of it
+	parent := newPoolTransfer(t, key, chainID, "parent", newFundedPoolInput(t, bc, key, "a"), 10_000)
+	require.NoError(t, pool.AddTransaction(parent))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "other", newFundedPoolInput(t, bc, key, "b"), 15_000)))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "child", pooledOutput(parent), 40_000)))
+	txs, err := pool.GetAllTransactions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"child", "parent"}, transactionIDs(txs))
+
+	// Evicting the parent evicts the child, whose input leaves the pool with it
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "medium", newFundedPoolInput(t, bc, key, "c"), 20_000)))
+	batch, err := pool.GetBatchForBlock(1 << 20)
+	require.NoError(t, err)
+	require.Equal(t, []string{"medium"}, transactionIDs(batch))
+
+	// Removing a transaction removes its descendants as well
+	medium, err := pool.GetTransaction("medium")
+	require.NoError(t, err)
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "medium-child", pooledOutput(medium), 30_000)))
+	require.NoError(t, pool.RemoveTransaction(medium))
+	require.Equal(t, 0, pool.Size())
+}
+
+func TestTxPoolExpiresTransactions(t *testing.T) {
+	cfg := chain.DefaultTxPoolConfig()
+	cfg.TTL = 50 * time.Millisecond
+	pool, bc, key, chainID := newTestTxPool(t, cfg)
+
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "stale", newFundedPoolInput(t, bc, key, "a"), 10_000)))
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "fresh", newFundedPoolInput(t, bc, key, "b"), 10_000)))
+
+	txs, err := pool.GetAllTransactions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"fresh"}, transactionIDs(txs))
+	_, err = pool.GetTransaction("stale")
+	require.Error(t, err)
+}
+
+func TestTxPoolBatchForBlock(t *testing.T) {
+	pool, bc, key, chainID := newTestTxPool(t, chain.DefaultTxPoolConfig())
+
+	parent := newPoolTransfer(t, key, chainID, "parent", newFundedPoolInput(t, bc, key, "a"), 20_000)
+	other := newPoolTransfer(t, key, chainID, "other", newFundedPoolInput(t, bc, key, "b"), 10_000)
+	child := newPoolTransfer(t, key, chainID, "child", pooledOutput(parent), 50_000)
+	for _, tx := range []*types.Transaction{parent, other, child} {
+		require.NoError(t, pool.AddTransaction(tx))
+	}
+
+	// The child pays the most but waits for its parent
+	batch, err := pool.GetBatchForBlock(1 << 20)
+	require.NoError(t, err)
+	require.Equal(t, []string{"parent", "other", "child"}, transactionIDs(batch))
+
+	// Only the parent fits in a batch the size of one transaction
+	data, err := parent.Marshal()
+	require.NoError(t, err)
+	batch, err = pool.GetBatchForBlock(len(data))
+	require.NoError(t, err)
+	require.Equal(t, []string{"parent"}, transactionIDs(batch))
+	require.Equal(t, 3, pool.Size())
+}
+
+func TestTxPoolReplaceByFee(t *testing.T) {
+	pool, bc, key, chainID := newTestTxPool(t, chain.DefaultTxPoolConfig())
+	events := make(chan types.Message, 4)
+	shared.GetMessageBus().Subscribe(types.TransactionReplaced, events)
+	t.Cleanup(func() { shared.GetMessageBus().Unsubscribe(types.TransactionReplaced, events) })
+
+	input := newFundedPoolInput(t, bc, key, "a")
+	original := newPoolTransfer(t, key, chainID, "original", input, 10_000)
+	require.NoError(t, pool.AddTransaction(original))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "child", pooledOutput(original), 10_000)))
+
+	// A conflicting spend must beat the original and its child by the replacement bump
+	err := pool.AddTransaction(newPoolTransfer(t, key, chainID, "double-spend", input, 20_000))
+	require.True(t, errors.Is(err, chain.ErrTransactionConflict), "Unexpected error: %v", err)
+	err = pool.AddTransaction(newPoolTransfer(t, key, chainID, "too-cheap", input, 21_999))
+	require.True(t, errors.Is(err, chain.ErrTransactionConflict), "Unexpected error: %v", err)
+	require.Equal(t, 2, pool.Size())
+
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "replacement", input, 22_000)))
+	txs, err := pool.GetAllTransactions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"replacement"}, transactionIDs(txs))
+
+	replaced := make(map[string]string)
+	for len(replaced) < 2 {
+		select {
+		case msg := <-events:
+			event := msg.Data.(types.TransactionReplacedEvent)
+			replaced[event.ReplacedID] = event.ReplacementID
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for replacement events, got %v", replaced)
+		}
+	}
+	require.Equal(t, map[string]string{"original": "replacement", "child": "replacement"}, replaced)
+
+	// The replaced outputs are free again once the replacement leaves the pool
+	replacement, err := pool.GetTransaction("replacement")
+	require.NoError(t, err)
+	require.NoError(t, pool.RemoveTransaction(replacement))
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "respend", input, 10_000)))
+}
+
+func TestTxPoolRejectsInvalidSpends(t *testing.T) {
+	pool, bc, key, chainID := newTestTxPool(t, chain.DefaultTxPoolConfig())
+	input := newFundedPoolInput(t, bc, key, "a")
+	require.NoError(t, pool.AddTransaction(newPoolTransfer(t, key, chainID, "victim", input, 10_000)))
+
+	// Another sender cannot replace the victim by citing its output with a large fee
+	attacker, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	attackerAddr, err := attacker.PublicKey().Address()
+	require.NoError(t, err)
+	stolen := input
+	stolen.OwnerAddress = attackerAddr.String()
+	err = pool.AddTransaction(newPoolTransfer(t, attacker, chainID, "theft", stolen, 500_000))
+	require.True(t, errors.Is(err, chain.ErrInvalidSpend), "Unexpected error: %v", err)
+
+	// A fee the inputs do not cover cannot buy a replacement either
+	unfunded := newPoolTransfer(t, key, chainID, "unfunded", input, 20_000)
+	unfunded.GasFee = 2_000_000
+	require.NoError(t, unfunded.Sign(key, chainID))
+	err = pool.AddTransaction(unfunded)
+	require.True(t, errors.Is(err, chain.ErrInvalidSpend), "Unexpected error: %v", err)
+
+	// Outputs that are neither confirmed nor pooled cannot be spent
+	err = pool.AddTransaction(newPoolTransfer(t, key, chainID, "missing", newPoolInput(t, key, "missing"), 10_000))
+	require.True(t, errors.Is(err, chain.ErrInvalidSpend), "Unexpected error: %v", err)
+
+	txs, err := pool.GetAllTransactions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"victim"}, transactionIDs(txs))
+}
+
+func TestTxPoolReloadsPersistedTransactions(t *testing.T) {
+	database := newTestDatabase(t)
+	priv, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	aesKey, err := encryption.GenerateAESKey()
+	require.NoError(t, err)
+	config := &types.BlockchainConfig{
+		AESKey:            aesKey,
+		GenesisAccount:    priv,
+		TestMode:          true,
+		DisableBackground: true,
+	}
+
+	bc, blockchainStore, err := chain.NewBlockchainWithDatabase(config, database)
+	require.NoError(t, err)
+	genesisTx := bc.Blockchain.Genesis.Transactions[0]
+	genesisInput := newPoolInput(t, priv, genesisTx.ID)
+	genesisInput.Amount = genesisTx.Outputs[0].Amount
+
+	parent := newPoolTransfer(t, priv, bc.GetChainID(), "parent", genesisInput, 100_000)
+	child := newPoolTransfer(t, priv, bc.GetChainID(), "child", pooledOutput(parent), 200_000)
+	// The output spent by the orphan is only known to this process, so it is gone after a restart
+	orphan := newPoolTransfer(t, priv, bc.GetChainID(), "orphan", newFundedPoolInput(t, bc, priv, "missing"), 100_000)
+	for _, tx := range []*types.Transaction{parent, child, orphan} {
+		require.NoError(t, bc.TxPool().AddTransaction(tx))
+	}
+
+	// A record that no longer decodes is dropped as well
+	dbTx, err := blockchainStore.BeginTransaction()
+	require.NoError(t, err)
+	require.NoError(t, blockchainStore.SetTransaction(dbTx, []byte(store.PendingTransactionPrefix+"garbage"), []byte("not a transaction")))
+	require.NoError(t, blockchainStore.CommitTransaction(dbTx))
+
+	reopened, reopenedStore, err := chain.NewBlockchainWithDatabase(config, database)
+	require.NoError(t, err)
+
+	pooled := func() []string {
+		txs, err := reopened.TxPool().GetAllTransactions()
+		require.NoError(t, err)
+		return transactionIDs(txs)
+	}
+	stored := func(txID string) bool {
+		dbTx, err := reopenedStore.BeginTransaction()
+		require.NoError(t, err)
+		defer dbTx.Rollback()
+		exists, err := reopenedStore.TransactionExists(dbTx, txID)
+		require.NoError(t, err)
+		return exists
+	}
+	require.ElementsMatch(t, []string{"parent", "child"}, pooled())
+	require.False(t, stored("orphan"), "The record of a transaction spending missing outputs should be deleted")
+	require.False(t, stored("garbage"), "An unreadable record should be deleted")
+
+	// Mining the parent removes it and its record, the child stays pending
+	mined, err := reopened.TxPool().GetTransaction("parent")
+	require.NoError(t, err)
+	validator := newTestValidator(t, reopened, 100)
+	require.NoError(t, reopened.ProcessBlock(newSignedBlock(t, reopened.Blockchain.Genesis, validator, mined)))
+	require.Equal(t, []string{"child"}, pooled())
+	require.False(t, stored("parent"), "The record of a mined transaction should be deleted")
+	require.True(t, stored("child"))
+}
@@ -0,0 +1,73 @@
+package chaintests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func TestLockedOutputsCannotBeSpentEarly(t *testing.T) {
+	bc, _, genesisKey := newTestBlockchainWithGenesisKey(t)
+	genesis := bc.Blockchain.Genesis
+	genesisTx := genesis.Transactions[0]
+	validator := newTestValidator(t, bc, 100)
+	chainID := bc.GetChainID()
+
+	genesisAddr, err := genesisKey.PublicKey().Address()
+	require.NoError(t, err)
+	owner := genesisAddr.String()
+	supply := genesisTx.Outputs[0].Amount
+	vested := amount.Amount(1000)
+
+	// Lock one output until height 3 and another for an hour past block 1
+	fund := &types.Transaction{
+		ID:     "fund-locked",
+		Inputs: []types.UTXO{{TransactionID: genesisTx.ID, Index: 0, OwnerAddress: owner, Amount: supply}},
+		Outputs: []types.UTXO{
+			{OwnerAddress: owner, Amount: vested, LockUntilHeight: 3},
+			{OwnerAddress: owner, Amount: vested, LockUntilTime: genesis.Timestamp + 3600},
+			{OwnerAddress: owner, Amount: supply - 2*vested},
+		},
+		SenderAddress:   *genesisAddr,
+		SenderPublicKey: genesisKey.PublicKey(),
+	}
+	require.NoError(t, fund.Sign(genesisKey, chainID))
+	b1 := newSignedBlock(t, genesis, validator, fund)
+	require.NoError(t, bc.ProcessBlock(b1))
+
+	balance, err := bc.GetBalanceDetails(owner)
+	require.NoError(t, err)
+	require.Equal(t, supply-2*vested, balance.Spendable)
+	require.Equal(t, 2*vested, balance.Locked)
+	response, err := bc.BalanceResponse(owner)
+	require.NoError(t, err)
+	require.Equal(t, int64(2*vested), response.LockedBalance)
+	require.Equal(t, int64(supply), response.Balance)
+
+	spend := func(id string, index int) *types.Transaction {
+		tx := &types.Transaction{
+			ID:              id,
+			Inputs:          []types.UTXO{{TransactionID: fund.ID, Index: index, OwnerAddress: owner, Amount: vested}},
+			Outputs:         []types.UTXO{{OwnerAddress: validator.address, Amount: vested}},
+			SenderAddress:   *genesisAddr,
+			SenderPublicKey: genesisKey.PublicKey(),
+		}
+		require.NoError(t, tx.Sign(genesisKey, chainID))
+		return tx
+	}
+
+	// Block 2 is below the height lock
+	err = bc.ProcessBlock(newSignedBlock(t, b1, validator, spend("spend-height-locked", 0)))
+	require.True(t, errors.Is(err, types.ErrOutputLocked), "Unexpected error: %v", err)
+
+	// Block 3 reaches the height lock, but the time lock is still an hour away
+	b2 := newSignedBlock(t, b1, validator)
+	require.NoError(t, bc.ProcessBlock(b2))
+	err = bc.ProcessBlock(newSignedBlock(t, b2, validator, spend("spend-time-locked", 1)))
+	require.True(t, errors.Is(err, types.ErrOutputLocked), "Unexpected error: %v", err)
+	require.NoError(t, bc.ProcessBlock(newSignedBlock(t, b2, validator, spend("spend-height-locked", 0))))
+
+}
@@ -0,0 +1,96 @@
+package chaintests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func TestVerifyChainReportsFirstInconsistentHeight(t *testing.T) {
+	bc, blockchainStore, genesisKey := newTestBlockchainWithGenesisKey(t)
+	genesis := bc.Blockchain.Genesis
+	genesisTx := genesis.Transactions[0]
+	validator := newTestValidator(t, bc, 100)
+
+	// Spend the genesis output with a transaction signed by the genesis account
+	genesisAddr, err := genesisKey.PublicKey().Address()
+	require.NoError(t, err)
+	tx := &types.Transaction{
+		ID:              "verify-spend",
+		Inputs:          []types.UTXO{{TransactionID: genesisTx.ID, Index: 0, OwnerAddress: genesisAddr.String(), Amount: genesisTx.Outputs[0].Amount}},
+		Outputs:         []types.UTXO{{OwnerAddress: validator.address, Amount: genesisTx.Outputs[0].Amount}},
+		SenderAddress:   *genesisAddr,
+		SenderPublicKey: genesisKey.PublicKey(),
+	}
+	require.NoError(t, tx.Sign(genesisKey, bc.GetChainID()))
+
+	b1 := newSignedBlock(t, genesis, validator, tx)
+	require.NoError(t, bc.ProcessBlock(b1))
+	b2 := newSignedBlock(t, b1, validator)
+	require.NoError(t, bc.ProcessBlock(b2))
+
+	report, err := chain.VerifyChain(blockchainStore, bc.GetChainID())
+	require.NoError(t, err)
+	require.True(t, report.Valid, "Unexpected failure: %+v", report.Failure)
+	require.Equal(t, 3, report.BlocksChecked)
+	require.Equal(t, int64(2), report.TipHeight)
+
+	// Rewriting a transaction is caught by the Verkle root, which the hash covers
+	tampered, err := blockchainStore.GetBlock(1)
+	require.NoError(t, err)
+	tampered.Transactions[0].Outputs[0].Amount--
+	require.NoError(t, blockchainStore.SaveBlock(tampered))
+
+	report, err = chain.VerifyChain(blockchainStore, bc.GetChainID())
+	require.NoError(t, err)
+	require.False(t, report.Valid)
+	require.Equal(t, int64(1), report.Failure.Height)
+	require.Equal(t, chain.CheckVerkleRoot, report.Failure.Check)
+	require.Equal(t, 1, report.BlocksChecked)
+}
+
+func TestVerifyChainRejectsUnsignedSpend(t *testing.T) {
+	bc, blockchainStore := newForkTestBlockchain(t)
+	genesis := bc.Blockchain.Genesis
+	genesisTx := genesis.Transactions[0]
+	validator := newTestValidator(t, bc, 100)
+
+	unsigned := &types.Transaction{
+		ID:      "unsigned-spend",
+		Inputs:  []types.UTXO{{TransactionID: genesisTx.ID, Index: 0}},
+		Outputs: []types.UTXO{{OwnerAddress: validator.address, Amount: 1}},
+	}
+	// Blocks with unsigned spends are refused when applied, so the block is stored directly
+	block := newSignedBlock(t, genesis, validator, unsigned)
+	require.Error(t, bc.ProcessBlock(block))
+	require.NoError(t, blockchainStore.SaveBlock(block))
+
+	report, err := chain.VerifyChain(blockchainStore, bc.GetChainID())
+	require.NoError(t, err)
+	require.False(t, report.Valid)
+	require.Equal(t, chain.CheckTransactionSignature, report.Failure.Check)
+	require.Equal(t, "unsigned-spend", report.Failure.TransactionID)
+}
+
+func TestVerifyChainRejectsNegativeOutputs(t *testing.T) {
+	bc, blockchainStore, genesisKey := newTestBlockchainWithGenesisKey(t)
+	validator := newTestValidator(t, bc, 100)
+
+	// The block is refused when applied, so it is stored directly
+	input := genesisOutput(bc)
+	mint := newTransfer(t, genesisKey, bc.GetChainID(), "negative-output", []types.UTXO{input},
+		types.UTXO{OwnerAddress: validator.address, Amount: input.Amount + 1_000_000},
+		types.UTXO{OwnerAddress: validator.address, Amount: -1_000_000})
+	block := newSignedBlock(t, bc.Blockchain.Genesis, validator, mint)
+	require.Error(t, bc.ProcessBlock(block))
+	require.NoError(t, blockchainStore.SaveBlock(block))
+
+	report, err := chain.VerifyChain(blockchainStore, bc.GetChainID())
+	require.NoError(t, err)
+	require.False(t, report.Valid)
+	require.Equal(t, int64(1), report.Failure.Height)
+	require.Equal(t, chain.CheckUTXOSpend, report.Failure.Check)
+	require.Equal(t, "negative-output", report.Failure.TransactionID)
+}
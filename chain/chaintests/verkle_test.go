@@ -2,9 +2,13 @@ package chaintests
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/amount"
 	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/types"
 )
 
 // TestNewVerkleTree tests the NewVerkleTree function to ensure it creates a tree correctly with valid data.
@@ -54,3 +58,50 @@ func TestNewVerkleTree(t *testing.T) {
 		t.Errorf("Retrieved value for key2 does not match expected value. Got: %v, want: %v", retrievedValue2, value2)
 	}
 }
+
+func TestTransactionInclusionProof(t *testing.T) {
+	bc, _, genesisOwner := newTestBlockchainWithGenesisKey(t)
+	validator := newTestValidator(t, bc, 100)
+
+	// Each transaction pays the recipient out of the change of the previous one
+	var txs []*types.Transaction
+	change := genesisOutput(bc)
+	for i := 0; i < 3; i++ {
+		id := fmt.Sprintf("proof-tx-%d", i)
+		paid := amount.Amount(i + 1)
+		txs = append(txs, newTransfer(t, genesisOwner, bc.GetChainID(), id, []types.UTXO{change},
+			types.UTXO{OwnerAddress: "tl1recipient", Amount: paid},
+			types.UTXO{OwnerAddress: change.OwnerAddress, Amount: change.Amount - paid}))
+		change = types.UTXO{TransactionID: id, Index: 1, OwnerAddress: change.OwnerAddress, Amount: change.Amount - paid}
+	}
+	// A block whose Verkle root does not commit to its transactions is rejected, even
+	// when the validator signed it
+	tampered := newSignedBlock(t, bc.Blockchain.Genesis, validator, txs...)
+	tampered.Transactions = txs[:2]
+	chain.SignBlock(tampered, validator.key)
+	require.Error(t, bc.ProcessBlock(tampered))
+
+	block := newSignedBlock(t, bc.Blockchain.Genesis, validator, txs...)
+	require.NoError(t, bc.ProcessBlock(block))
+
+	proof, err := bc.GetTransactionProof(1, "proof-tx-1")
+	require.NoError(t, err)
+	require.NoError(t, chain.VerifyTransactionProof(block.VerkleRoot, txs[1], proof))
+
+	// Confirming the transaction does not change its leaf
+	confirmed := *txs[1]
+	confirmed.Status = "confirmed"
+	require.NoError(t, chain.VerifyTransactionProof(block.VerkleRoot, &confirmed, proof))
+
+	// The proof does not transfer to another transaction
+	require.Error(t, chain.VerifyTransactionProof(block.VerkleRoot, txs[2], proof))
+	altered := *txs[1]
+	altered.GasFee++
+	require.Error(t, chain.VerifyTransactionProof(block.VerkleRoot, &altered, proof))
+
+	// Nor to another block's commitment
+	require.Error(t, chain.VerifyTransactionProof(bc.Blockchain.Genesis.VerkleRoot, txs[1], proof))
+
+	_, err = bc.GetTransactionProof(1, "missing-tx")
+	require.Error(t, err)
+}
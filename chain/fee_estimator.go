@@ -0,0 +1,191 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// ErrFeeTooLow is returned when a transaction pays less than the minimum relay fee.
+var ErrFeeTooLow = errors.New("transaction fee is below the minimum relay fee")
+
+// Fee levels, as the percentile of fee rates paid in recent blocks and the number of
+// blocks the pending transactions ahead may fill before the transaction is included.
+const (
+	lowFeePercentile    = 0.25
+	mediumFeePercentile = 0.50
+	highFeePercentile   = 0.90
+
+	lowFeeTargetBlocks    = 6
+	mediumFeeTargetBlocks = 3
+	highFeeTargetBlocks   = 1
+)
+
+// FeeSuggestion is a fee rate in nanoTHRYLOS per byte of CBOR encoded transaction and
+// the height a transaction paying it is expected to be included at.
+type FeeSuggestion struct {
+	FeePerByte      float64 `json:"feePerByte"`
+	InclusionHeight int64   `json:"inclusionHeight"`
+}
+
+// FeeFor returns the fee a transaction of size bytes pays at the suggested rate.
+func (s FeeSuggestion) FeeFor(size int) int {
+	return int(math.Ceil(s.FeePerByte * float64(size)))
+}
+
+// FeeEstimate holds the low, medium and high fee suggestions for the next blocks.
+type FeeEstimate struct {
+	Low                FeeSuggestion `json:"low"`
+	Medium             FeeSuggestion `json:"medium"`
+	High               FeeSuggestion `json:"high"`
+	MinRelayFeePerByte float64       `json:"minRelayFeePerByte"`
+}
+
+// FeeEstimator suggests fees from the rates paid in recent blocks and the rates of the
+// transactions waiting in the pool.
+type FeeEstimator struct {
+	blockchain *types.Blockchain
+	pool       types.TxPool
+}
+
+func NewFeeEstimator(blockchain *types.Blockchain, pool types.TxPool) *FeeEstimator {
+	return &FeeEstimator{blockchain: blockchain, pool: pool}
+}
+
+// pendingFee is the fee rate and size of a transaction waiting in the pool.
+type pendingFee struct {
+	rate float64
+	size int
+}
+
+// Estimate returns fee suggestions for a transaction submitted now. Each level pays at
+// least the minimum relay fee, the chosen percentile of recent block fee rates, and
+// enough to outbid the pool transactions that would otherwise fill its target blocks.
+func (e *FeeEstimator) Estimate() (*FeeEstimate, error) {
+	if e.blockchain == nil {
+		return nil, errors.New("fee estimator has no blockchain")
+	}
+
+	e.blockchain.Mu.RLock()
+	blocks := e.blockchain.Blocks
+	if len(blocks) > config.FeeEstimateBlocks {
+		blocks = blocks[len(blocks)-config.FeeEstimateBlocks:]
+	}
+	var tipHeight int64 = -1
+	if len(blocks) > 0 {
+		tipHeight = blocks[len(blocks)-1].Index
+	}
+	var recent []float64
+	for _, block := range blocks {
+		for _, tx := range block.Transactions {
+			if len(tx.Inputs) == 0 {
+				continue
+			}
+			rate, _, err := transactionFeeRate(tx)
+			if err != nil {
+				e.blockchain.Mu.RUnlock()
+				return nil, fmt.Errorf("block %d: %v", block.Index, err)
+			}
+			recent = append(recent, rate)
+		}
+	}
+	e.blockchain.Mu.RUnlock()
+	sort.Float64s(recent)
+
+	var pending []pendingFee
+	if e.pool != nil {
+		txs, err := e.pool.GetAllTransactions()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the transaction pool: %v", err)
+		}
+		for _, tx := range txs {
+			rate, size, err := transactionFeeRate(tx)
+			if err != nil {
+				return nil, fmt.Errorf("pool transaction %s: %v", tx.ID, err)
+			}
+			pending = append(pending, pendingFee{rate: rate, size: size})
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].rate > pending[j].rate })
+
+	suggest := func(percentile float64, targetBlocks int) FeeSuggestion {
+		rate := math.Max(float64(config.MinRelayFeePerByte), percentileOf(recent, percentile))
+		rate = math.Max(rate, outbidRate(pending, targetBlocks))
+		return FeeSuggestion{
+			FeePerByte:      rate,
+			InclusionHeight: tipHeight + 1 + blocksAhead(pending, rate),
+		}
+	}
+	return &FeeEstimate{
+		Low:                suggest(lowFeePercentile, lowFeeTargetBlocks),
+		Medium:             suggest(mediumFeePercentile, mediumFeeTargetBlocks),
+		High:               suggest(highFeePercentile, highFeeTargetBlocks),
+		MinRelayFeePerByte: config.MinRelayFeePerByte,
+	}, nil
+}
+
+// MinRelayFee returns the smallest fee the pool accepts for a transaction of size bytes.
+func MinRelayFee(size int) int {
+	return size * config.MinRelayFeePerByte
+}
+
+// checkRelayFee returns an error wrapping ErrFeeTooLow when tx pays less than the
+// minimum relay fee for its size.
+func checkRelayFee(tx *types.Transaction) error {
+	_, size, err := transactionFeeRate(tx)
+	if err != nil {
+		return err
+	}
+	if minFee := MinRelayFee(size); tx.GasFee < minFee {
+		return fmt.Errorf("%w: fee %d for %d bytes, need at least %d", ErrFeeTooLow, tx.GasFee, size, minFee)
+	}
+	return nil
+}
+
+// transactionFeeRate returns the fee per byte a transaction pays and its CBOR size.
+func transactionFeeRate(tx *types.Transaction) (float64, int, error) {
+	data, err := tx.Marshal()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to encode transaction %s: %v", tx.ID, err)
+	}
+	return float64(tx.GasFee) / float64(len(data)), len(data), nil
+}
+
+// percentileOf returns the value at percentile p of sorted, or 0 when it is empty.
+func percentileOf(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(p*float64(len(sorted)-1))]
+}
+
+// outbidRate returns the fee rate of the first pending transaction that does not fit
+// in targetBlocks blocks, or 0 when the pool fits. pending is sorted by rate, highest first.
+func outbidRate(pending []pendingFee, targetBlocks int) float64 {
+	var bytes int
+	for i, fee := range pending {
+		bytes += fee.size
+		if bytes > targetBlocks*config.MaxBlockSize || i+1 > targetBlocks*config.MaxBlockTransactions {
+			return fee.rate
+		}
+	}
+	return 0
+}
+
+// blocksAhead returns the number of full blocks the pending transactions paying more
+// than rate fill.
+func blocksAhead(pending []pendingFee, rate float64) int64 {
+	var bytes, count int
+	for _, fee := range pending {
+		if fee.rate <= rate {
+			break
+		}
+		bytes += fee.size
+		count++
+	}
+	return int64(max(bytes/config.MaxBlockSize, count/config.MaxBlockTransactions))
+}
@@ -0,0 +1,121 @@
+package chain
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// GenesisTransactionID returns the ID of the transaction holding the genesis allocations.
+func GenesisTransactionID(chainID string) string {
+	return "genesis_tx_" + chainID
+}
+
+// NewGenesisBlockFromConfig deterministically builds the genesis block described by
+// a genesis file. The block only depends on the file contents, so every node loading
+// the same file computes the same block hash.
+func NewGenesisBlockFromConfig(genesis *config.Genesis) (*types.Block, error) {
+	if err := genesis.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid genesis: %v", err)
+	}
+
+	documentHash, err := genesisDocumentHash(genesis)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stake of the initial validators is locked in staking pool outputs after the
+	// allocations, like the stake bonded by a stake transaction
+	txID := GenesisTransactionID(genesis.ChainID)
+	outputs := make([]types.UTXO, 0, len(genesis.Allocations)+len(genesis.Validators))
+	for _, alloc := range genesis.Allocations {
+		outputs = append(outputs, types.UTXO{
+			Index:         len(outputs),
+			TransactionID: txID,
+			OwnerAddress:  alloc.Address,
+			Amount:        amount.Amount(alloc.Amount),
+		})
+	}
+	for _, v := range genesis.Validators {
+		outputs = append(outputs, types.UTXO{
+			Index:         len(outputs),
+			TransactionID: txID,
+			OwnerAddress:  types.StakingPoolAddress,
+			Amount:        amount.Amount(v.Stake),
+		})
+	}
+
+	block := &types.Block{
+		Index:     0,
+		Timestamp: genesis.Timestamp,
+		PrevHash:  hash.NullHash(),
+		Version:   types.BlockVersion,
+		Transactions: []*types.Transaction{{
+			ID:        txID,
+			Timestamp: genesis.Timestamp,
+			Outputs:   outputs,
+		}},
+		// Data commits to the full genesis document, including the chain ID,
+		// validator set and protocol parameters
+		Data: hex.EncodeToString(documentHash.Bytes()),
+	}
+
+	if err := InitializeVerkleTree(block); err != nil {
+		return nil, fmt.Errorf("failed to initialize Verkle tree: %v", err)
+	}
+	ComputeBlockHash(block)
+	return block, nil
+}
+
+// GenesisHash returns the hash of the genesis block described by the genesis file.
+// Nodes compare it with their stored block 0 at startup.
+func GenesisHash(genesis *config.Genesis) (hash.Hash, error) {
+	block, err := NewGenesisBlockFromConfig(genesis)
+	if err != nil {
+		return hash.NullHash(), err
+	}
+	return block.Hash, nil
+}
+
+// GenesisValidatorKeys decodes the public keys of the initial validator set.
+func GenesisValidatorKeys(genesis *config.Genesis) (map[string]crypto.PublicKey, error) {
+	keys := make(map[string]crypto.PublicKey, len(genesis.Validators))
+	for _, v := range genesis.Validators {
+		keyBytes, err := base64.StdEncoding.DecodeString(v.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key encoding for validator %s: %v", v.Address, err)
+		}
+		pubKey, err := crypto.NewPublicKeyFromBytes(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key for validator %s: %v", v.Address, err)
+		}
+		addr, err := pubKey.Address()
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive address for validator %s: %v", v.Address, err)
+		}
+		if addr.String() != v.Address {
+			return nil, fmt.Errorf("public key of validator %s belongs to %s", v.Address, addr.String())
+		}
+		keys[v.Address] = pubKey
+	}
+	return keys, nil
+}
+
+func genesisDocumentHash(genesis *config.Genesis) (hash.Hash, error) {
+	encMode, err := cbor.CanonicalEncOptions().EncMode()
+	if err != nil {
+		return hash.NullHash(), fmt.Errorf("failed to create canonical encoder: %v", err)
+	}
+	data, err := encMode.Marshal(genesis)
+	if err != nil {
+		return hash.NullHash(), fmt.Errorf("failed to encode genesis: %v", err)
+	}
+	return hash.NewHash(data), nil
+}
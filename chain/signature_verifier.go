@@ -0,0 +1,147 @@
+package chain
+
+import (
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/store"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// SignatureVerifier checks transaction signatures on a bounded number of workers and
+// remembers the (transaction ID, signature hash) pairs it has verified, so that a
+// transaction checked when it entered the pool is not checked again when its block
+// is applied. The signature hash covers the signing preimage, which includes the
+// chain ID, and the keys and signatures, so a cached result never vouches for a
+// transaction that was changed after it was verified.
+type SignatureVerifier struct {
+	workers chan struct{}
+	cache   *store.LRUCache
+
+	verified  atomic.Uint64
+	cacheHits atomic.Uint64
+}
+
+// SignatureVerifierStats counts the signatures a verifier has checked and the checks
+// it answered from its cache.
+type SignatureVerifierStats struct {
+	Verified  uint64 `json:"verified"`
+	CacheHits uint64 `json:"cacheHits"`
+}
+
+// NewSignatureVerifier returns a verifier running at most workers verifications at
+// once, one per CPU when workers is not positive, and caching up to cacheSize results.
+func NewSignatureVerifier(workers, cacheSize int) (*SignatureVerifier, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	cache, err := store.NewLRUCache(cacheSize, uint(cacheSize), 0.01)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the verified signature cache: %v", err)
+	}
+	return &SignatureVerifier{
+		workers: make(chan struct{}, workers),
+		cache:   cache,
+	}, nil
+}
+
+// Verify checks the signature of tx for chainID, or its multisig signatures.
+func (v *SignatureVerifier) Verify(tx *types.Transaction, chainID string) error {
+	key, err := verifiedSignatureKey(tx, chainID)
+	if err != nil {
+		return err
+	}
+	if _, ok := v.cache.Get(key); ok {
+		v.cacheHits.Add(1)
+		return nil
+	}
+
+	v.workers <- struct{}{}
+	err = verifyTransactionSignature(tx, chainID)
+	<-v.workers
+	v.verified.Add(1)
+	if err != nil {
+		return err
+	}
+	v.cache.Add(key, true)
+	return nil
+}
+
+// VerifyBatch checks the signatures of txs on the verifier workers and returns one
+// error per transaction, nil for those that verified.
+func (v *SignatureVerifier) VerifyBatch(txs []*types.Transaction, chainID string) []error {
+	errs := make([]error, len(txs))
+	workers := cap(v.workers)
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = v.Verify(txs[i], chainID)
+			}
+		}()
+	}
+	for i := range txs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return errs
+}
+
+// VerifyBlock checks the signatures of every transaction of block and returns the
+// error of the first one, in block order, that fails. The transactions of the genesis
+// block are trusted through its hash and are not signed.
+func (v *SignatureVerifier) VerifyBlock(block *types.Block, chainID string) error {
+	if block.Index == 0 {
+		return nil
+	}
+	for i, err := range v.VerifyBatch(block.Transactions, chainID) {
+		if err != nil {
+			return fmt.Errorf("transaction %s: %w", block.Transactions[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// Stats returns the verification counters.
+func (v *SignatureVerifier) Stats() SignatureVerifierStats {
+	return SignatureVerifierStats{
+		Verified:  v.verified.Load(),
+		CacheHits: v.cacheHits.Load(),
+	}
+}
+
+// verifiedSignatureKey returns the cache key of tx: its ID and the hash of its signing
+// preimage for chainID, sender key, signature and multisig policy and signatures.
+func verifiedSignatureKey(tx *types.Transaction, chainID string) (string, error) {
+	data, err := tx.SigningBytes(chainID)
+	if err != nil {
+		return "", err
+	}
+	if tx.SenderPublicKey != nil {
+		data = append(data, tx.SenderPublicKey.Bytes()...)
+	}
+	if tx.Signature != nil {
+		data = append(data, tx.Signature.Bytes()...)
+	}
+	if tx.Multisig != nil {
+		multisig, err := cbor.Marshal([]interface{}{tx.Multisig, tx.MultisigSignatures})
+		if err != nil {
+			return "", fmt.Errorf("failed to encode multisig signatures: %v", err)
+		}
+		data = append(data, multisig...)
+	}
+	sigHash := hash.NewHash(data)
+	return tx.ID + ":" + hex.EncodeToString(sigHash[:]), nil
+}
@@ -1,10 +1,8 @@
 package chain
 
 import (
-	"bytes"
 	"fmt"
 	"log"
-	"strconv"
 
 	"github.com/btcsuite/btcutil/bech32"
 	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
@@ -12,7 +10,9 @@ import (
 	"github.com/thrylos-labs/thrylos/amount"
 	"github.com/thrylos-labs/thrylos/crypto"
 	"github.com/thrylos-labs/thrylos/crypto/address"
+	"github.com/thrylos-labs/thrylos/shared"
 	"github.com/thrylos-labs/thrylos/types"
+	"github.com/thrylos-labs/thrylos/utils"
 )
 
 func ConvertToThrylosTransaction(tx *types.Transaction) (*thrylos.Transaction, error) {
@@ -34,21 +34,36 @@ func ConvertToThrylosTransaction(tx *types.Transaction) (*thrylos.Transaction, e
 	thrylosOutputs := make([]*thrylos.UTXO, len(tx.Outputs))
 	for i, output := range tx.Outputs {
 		thrylosOutputs[i] = &thrylos.UTXO{
-			TransactionId: output.TransactionID,
-			Index:         int32(output.Index),
-			OwnerAddress:  output.OwnerAddress,
-			Amount:        output.Amount.ToNanoTHR(),
+			TransactionId:   output.TransactionID,
+			Index:           int32(output.Index),
+			OwnerAddress:    output.OwnerAddress,
+			Amount:          output.Amount.ToNanoTHR(),
+			LockUntilHeight: output.LockUntilHeight,
+			LockUntilTime:   output.LockUntilTime,
 		}
 	}
 
-	return &thrylos.Transaction{
+	protoTx := &thrylos.Transaction{
 		Id:            tx.ID,
 		Inputs:        thrylosInputs,
 		Outputs:       thrylosOutputs,
 		Timestamp:     tx.Timestamp,
 		PreviousTxIds: tx.PreviousTxIds,
 		Gasfee:        int32(tx.GasFee),
-	}, nil
+		Type:          thrylos.TransactionType(tx.Type),
+		Payload:       tx.Payload,
+		Salt:          tx.Salt,
+		Sender:        tx.SenderAddress.String(),
+		Memo:          tx.Memo,
+	}
+	if tx.SenderPublicKey != nil {
+		protoTx.SenderPublicKey = tx.SenderPublicKey.Bytes()
+	}
+	if tx.Signature != nil {
+		protoTx.Signature = tx.Signature.Bytes()
+	}
+	protoTx.Multisig, protoTx.MultisigSignatures = utils.ConvertMultisigToProto(tx.Multisig, tx.MultisigSignatures)
+	return protoTx, nil
 }
 
 func SharedToThrylosInputs(inputs []types.UTXO, txSender string) []*thrylos.UTXO {
@@ -112,10 +127,12 @@ func SharedToThrylosOutputs(outputs []types.UTXO) []*thrylos.UTXO {
 		}
 
 		thrylosOutputs[i] = &thrylos.UTXO{
-			TransactionId: output.TransactionID,
-			Index:         int32(output.Index),
-			OwnerAddress:  output.OwnerAddress,
-			Amount:        int64(output.Amount),
+			TransactionId:   output.TransactionID,
+			Index:           int32(output.Index),
+			OwnerAddress:    output.OwnerAddress,
+			Amount:          int64(output.Amount),
+			LockUntilHeight: output.LockUntilHeight,
+			LockUntilTime:   output.LockUntilTime,
 		}
 
 		// Verify conversion
@@ -152,30 +169,24 @@ func ConvertThrylosTransactionToLocal(tx *thrylos.Transaction) (types.Transactio
 	// Convert inputs
 	localInputs := make([]types.UTXO, len(tx.Inputs))
 	for i, input := range tx.Inputs {
-		amt, err := amount.NewAmount(float64(input.Amount))
-		if err != nil {
-			return types.Transaction{}, fmt.Errorf("failed to convert input amount: %v", err)
-		}
 		localInputs[i] = types.UTXO{
 			TransactionID: input.TransactionId,
 			Index:         int(input.Index),
 			OwnerAddress:  input.OwnerAddress,
-			Amount:        amt,
+			Amount:        amount.Amount(input.Amount),
 		}
 	}
 
 	// Convert outputs
 	localOutputs := make([]types.UTXO, len(tx.Outputs))
 	for i, output := range tx.Outputs {
-		amt, err := amount.NewAmount(float64(output.Amount))
-		if err != nil {
-			return types.Transaction{}, fmt.Errorf("failed to convert output amount: %v", err)
-		}
 		localOutputs[i] = types.UTXO{
-			TransactionID: output.TransactionId,
-			Index:         int(output.Index),
-			OwnerAddress:  output.OwnerAddress,
-			Amount:        amt,
+			TransactionID:   output.TransactionId,
+			Index:           int(output.Index),
+			OwnerAddress:    output.OwnerAddress,
+			Amount:          amount.Amount(output.Amount),
+			LockUntilHeight: output.LockUntilHeight,
+			LockUntilTime:   output.LockUntilTime,
 		}
 	}
 
@@ -188,23 +199,35 @@ func ConvertThrylosTransactionToLocal(tx *thrylos.Transaction) (types.Transactio
 		return types.Transaction{}, fmt.Errorf("failed to convert sender address: %v", err)
 	}
 
+	multisig, multisigSigs, err := utils.ConvertMultisigFromProto(tx.Multisig, tx.MultisigSignatures)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
 	return types.Transaction{
-		ID:            tx.Id,
-		SenderAddress: *addr, // Note: dereferencing the pointer
-		Inputs:        localInputs,
-		Outputs:       localOutputs,
-		Timestamp:     tx.Timestamp,
-		Signature:     signature,
-		PreviousTxIds: tx.PreviousTxIds,
-		GasFee:        int(tx.Gasfee),
+		ID:                 tx.Id,
+		SenderAddress:      *addr, // Note: dereferencing the pointer
+		Inputs:             localInputs,
+		Outputs:            localOutputs,
+		Timestamp:          tx.Timestamp,
+		Signature:          signature,
+		PreviousTxIds:      tx.PreviousTxIds,
+		GasFee:             int(tx.Gasfee),
+		Salt:               tx.Salt,
+		Type:               types.TransactionType(tx.Type),
+		Payload:            tx.Payload,
+		Multisig:           multisig,
+		MultisigSignatures: multisigSigs,
+		Memo:               tx.Memo,
 	}, nil
 }
 
 // First, define a type for the public key lookup function
 type PublicKeyFetcher func(string) (crypto.PublicKey, error)
 
-// Then update the verification function
-func VerifyTransactionData(tx *thrylos.Transaction, utxos map[string][]*thrylos.UTXO, getPublicKey PublicKeyFetcher) (bool, error) {
+// VerifyTransactionData checks the signature of a transaction received in protobuf form
+// against the sender key returned by getPublicKey, then its inputs and balance.
+func VerifyTransactionData(tx *thrylos.Transaction, chainID string, utxos map[string][]*thrylos.UTXO, getPublicKey PublicKeyFetcher) (bool, error) {
 	// Validate salt exists and has proper length
 	if len(tx.Salt) == 0 {
 		return false, fmt.Errorf("transaction must have a salt value")
@@ -218,21 +241,22 @@ func VerifyTransactionData(tx *thrylos.Transaction, utxos map[string][]*thrylos.
 		return false, fmt.Errorf("transaction must have inputs and outputs")
 	}
 
-	// Get the public key using the fetcher
-	pubKey, err := getPublicKey(tx.Sender)
-	if err != nil {
-		return false, fmt.Errorf("invalid sender address: %v", err)
-	}
-
-	// Create transaction data bundle including salt for signature verification
-	txDataBundle := createTransactionDataBundle(tx)
-
-	// Create signature from transaction signature bytes
-	sig := crypto.NewSignature(tx.Signature)
-
-	// Verify signature using our crypto package
-	if err := pubKey.Verify(txDataBundle, &sig); err != nil {
-		return false, fmt.Errorf("invalid transaction signature: %v", err)
+	// Verify the signature over the same preimage every other verifier uses. A multisig
+	// sender carries its own policy, which must match the sender address.
+	sharedTx := utils.ConvertToSharedTransaction(tx)
+	if tx.Multisig != nil {
+		if err := shared.VerifyTransactionSignature(sharedTx, chainID); err != nil {
+			return false, fmt.Errorf("invalid transaction signature: %v", err)
+		}
+	} else {
+		// Get the public key using the fetcher
+		pubKey, err := getPublicKey(tx.Sender)
+		if err != nil {
+			return false, fmt.Errorf("invalid sender address: %v", err)
+		}
+		if err := sharedTx.VerifySignature(pubKey, chainID); err != nil {
+			return false, fmt.Errorf("invalid transaction signature: %v", err)
+		}
 	}
 
 	// Rest of your verification logic remains the same
@@ -253,17 +277,24 @@ func VerifyTransactionData(tx *thrylos.Transaction, utxos map[string][]*thrylos.
 	}
 
 	// Balance verification
-	inputSum := int64(0)
+	var inputSum, outputSum amount.Amount
+	var err error
 	for _, input := range tx.Inputs {
-		inputSum += input.Amount
+		if inputSum, err = inputSum.Add(amount.Amount(input.Amount)); err != nil {
+			return false, fmt.Errorf("invalid inputs: %v", err)
+		}
 	}
-
-	outputSum := int64(0)
 	for _, output := range tx.Outputs {
-		outputSum += output.Amount
+		if outputSum, err = outputSum.Add(amount.Amount(output.Amount)); err != nil {
+			return false, fmt.Errorf("invalid outputs: %v", err)
+		}
+	}
+	spent, err := outputSum.Add(amount.Amount(tx.Gasfee))
+	if err != nil {
+		return false, fmt.Errorf("invalid gas fee: %v", err)
 	}
 
-	if inputSum != outputSum+int64(tx.Gasfee) {
+	if inputSum != spent {
 		return false, fmt.Errorf("input amount (%d) does not match output amount (%d) plus gas fee (%d)",
 			inputSum, outputSum, tx.Gasfee)
 	}
@@ -271,64 +302,6 @@ func VerifyTransactionData(tx *thrylos.Transaction, utxos map[string][]*thrylos.
 	return true, nil
 }
 
-// Helper function to create a transaction data bundle for verification
-func createTransactionDataBundle(tx *thrylos.Transaction) []byte {
-	// Create a deterministic bundle of transaction data including the salt
-	var bundle [][]byte
-
-	// Add transaction components in a fixed order
-	bundle = append(bundle, []byte(tx.Id))
-	bundle = append(bundle, tx.Salt)
-	bundle = append(bundle, []byte(strconv.FormatInt(tx.Timestamp, 10)))
-
-	// Add input data
-	for _, input := range tx.Inputs {
-		inputData := []byte(fmt.Sprintf("%s:%d:%d",
-			input.TransactionId,
-			input.Index,
-			input.Amount))
-		bundle = append(bundle, inputData)
-	}
-
-	// Add output data
-	for _, output := range tx.Outputs {
-		outputData := []byte(fmt.Sprintf("%s:%d",
-			output.OwnerAddress,
-			output.Amount))
-		bundle = append(bundle, outputData)
-	}
-
-	// Add gas fee
-	bundle = append(bundle, []byte(strconv.FormatInt(int64(tx.Gasfee), 10)))
-
-	// Join all components with a separator
-	return bytes.Join(bundle, []byte("|"))
-}
-
-func convertInputsToJSON(inputs []*thrylos.UTXO) []map[string]interface{} {
-	result := make([]map[string]interface{}, len(inputs))
-	for i, input := range inputs {
-		result[i] = map[string]interface{}{
-			"amount":        input.Amount,
-			"index":         int(input.Index),
-			"owner_address": input.OwnerAddress,
-		}
-	}
-	return result
-}
-
-func convertOutputsToJSON(outputs []*thrylos.UTXO) []map[string]interface{} {
-	result := make([]map[string]interface{}, len(outputs))
-	for i, output := range outputs {
-		result[i] = map[string]interface{}{
-			"amount":        output.Amount,
-			"index":         int(output.Index),
-			"owner_address": output.OwnerAddress,
-		}
-	}
-	return result
-}
-
 // ConvertSharedToThrylos converts a shared.Transaction to a thrylos.Transaction.
 // func ValidateAndConvertTransaction(
 // 	tx *thrylos.Transaction,
@@ -395,32 +368,3 @@ func deriveAddressFromPublicKey(publicKey *mldsa44.PublicKey) (string, error) {
 
 	return address, nil
 }
-
-func createExactCanonicalForm(tx *thrylos.Transaction) map[string]interface{} {
-	inputs := make([]map[string]interface{}, len(tx.Inputs))
-	for i, input := range tx.Inputs {
-		inputs[i] = map[string]interface{}{
-			"amount": input.Amount,
-			"index":  input.Index,
-		}
-	}
-
-	outputs := make([]map[string]interface{}, len(tx.Outputs))
-	for i, output := range tx.Outputs {
-		outputs[i] = map[string]interface{}{
-			"amount": output.Amount,
-			"index":  output.Index,
-		}
-	}
-
-	return map[string]interface{}{
-		"gasfee":          tx.Gasfee,
-		"id":              tx.Id,
-		"inputs":          inputs,
-		"outputs":         outputs,
-		"previous_tx_ids": tx.PreviousTxIds,
-		"sender":          tx.Sender,
-		"status":          "pending",
-		"timestamp":       tx.Timestamp,
-	}
-}
@@ -1,24 +1,65 @@
 package chain
 
 import (
-	"container/list"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
+	"time"
 
+	thrylos "github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/shared"
 	"github.com/thrylos-labs/thrylos/types"
+)
 
-	"github.com/thrylos-labs/thrylos/store"
+var (
+	// ErrMempoolFull is returned when the pool is at its size limit and the transaction
+	// does not pay enough to evict lower fee transactions.
+	ErrMempoolFull = errors.New("transaction pool is full")
+	// ErrSenderLimit is returned when the sender already has the maximum number of
+	// transactions waiting in the pool.
+	ErrSenderLimit = errors.New("sender has too many pending transactions")
+	// ErrTransactionConflict is returned when the transaction spends an output already
+	// spent by a pooled transaction and does not pay enough to replace it.
+	ErrTransactionConflict = errors.New("transaction conflicts with a pooled transaction")
+	// ErrInvalidSpend is returned when the transaction spends outputs that do not exist,
+	// are not owned by its sender or are still locked, or when its inputs do not cover
+	// its outputs and fee.
+	ErrInvalidSpend = errors.New("transaction spends invalid inputs")
 )
 
-// Holds pending transactions before they're added to blocks
+// TxPoolConfig limits the size of the transaction pool.
+type TxPoolConfig struct {
+	MaxBytes     int           // Maximum total CBOR size of the pooled transactions
+	MaxPerSender int           // Maximum pooled transactions per sender address
+	TTL          time.Duration // How long a transaction may wait before it is dropped
+	// ReplacementBump is the percentage a conflicting transaction must pay above the
+	// total fee of the transactions it replaces.
+	ReplacementBump int
+}
+
+// DefaultTxPoolConfig returns the pool limits from the config package.
+func DefaultTxPoolConfig() TxPoolConfig {
+	return TxPoolConfig{
+		MaxBytes:        config.MempoolMaxBytes,
+		MaxPerSender:    config.MempoolMaxPerSender,
+		TTL:             config.MempoolTransactionTTL * time.Second,
+		ReplacementBump: config.MempoolReplacementBump,
+	}
+}
+
+// Holds pending transactions before they're added to blocks, ordered by fee rate
 
 type txPoolImpl struct {
 	mu           sync.RWMutex
-	transactions map[string]*list.Element
-	order        *list.List
+	transactions map[string]*txEntry
+	byFee        []*txEntry          // Highest fee rate first, oldest first among equal rates
+	spentBy      map[string]*txEntry // Outpoint key to the pooled transaction spending it
+	senderCounts map[string]int
+	totalBytes   int
+	config       TxPoolConfig
 	db           types.Store // Remove the pointer
 	blockchain   *BlockchainImpl
 	propagator   *types.TransactionPropagator // Remove as part of validator/consensus removal
@@ -30,42 +71,83 @@ func (p *txPoolImpl) GetActiveValidators(tx *types.Transaction) error {
 }
 
 type txEntry struct {
-	txID string
-	tx   *types.Transaction
+	txID    string
+	tx      *types.Transaction
+	size    int
+	feeRate float64
+	sender  string
+	addedAt time.Time
+}
+
+// ranksBefore reports whether e is picked for a block before other.
+func (e *txEntry) ranksBefore(other *txEntry) bool {
+	if e.feeRate != other.feeRate {
+		return e.feeRate > other.feeRate
+	}
+	if !e.addedAt.Equal(other.addedAt) {
+		return e.addedAt.Before(other.addedAt)
+	}
+	return e.txID < other.txID
 }
 
 // Constructor that returns the interface type
-func NewTxPool(db *store.Database, blockchain *BlockchainImpl) types.TxPool {
+func NewTxPool(db types.Store, blockchain *BlockchainImpl) types.TxPool {
+	return NewTxPoolWithConfig(db, blockchain, DefaultTxPoolConfig())
+}
+
+// NewTxPoolWithConfig returns a transaction pool with the given limits.
+func NewTxPoolWithConfig(db types.Store, blockchain *BlockchainImpl, cfg TxPoolConfig) types.TxPool {
 	return &txPoolImpl{
-		transactions: make(map[string]*list.Element),
-		order:        list.New(),
-		db:           db.Blockchain, // Remove the & operator
+		transactions: make(map[string]*txEntry),
+		spentBy:      make(map[string]*txEntry),
+		senderCounts: make(map[string]int),
+		config:       cfg,
+		db:           db,
 		blockchain:   blockchain,
 	}
 }
 
-// AddTransaction adds a transaction to the pool
+// AddTransaction adds a transaction to the pool. A transaction spending an output that
+// a pooled transaction already spends replaces it, and its descendants, only when it
+// pays the configured bump over their total fee; otherwise it is rejected with
+// ErrTransactionConflict. When the pool is full, transactions paying a lower fee rate
+// are evicted to make room; if that is not enough the transaction is rejected with
+// ErrMempoolFull.
 func (p *txPoolImpl) AddTransaction(tx *types.Transaction) error {
+	// The spend, salt and signature checks read the chain, so they run before the pool
+	// lock is taken. The spends are checked first so that the fee used to rank, replace
+	// and evict transactions is backed by the inputs of the sender.
+	pooled := p.pooledOutputs(tx)
+	if err := p.blockchain.checkPoolSpend(tx, pooled); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidSpend, tx.ID, err)
+	}
+	entry, err := p.newEntry(tx)
+	if err != nil {
+		return err
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	p.expireTransactions(time.Now())
+
 	// Check for existing transaction
 	if _, exists := p.transactions[tx.ID]; exists {
 		return errors.New("transaction already exists in the pool")
 	}
-
-	// Generate and set salt if not present
-	if len(tx.Salt) == 0 {
-		salt, err := generateSalt()
-		if err != nil {
-			return fmt.Errorf("failed to generate salt: %v", err)
+	// The pooled parents may have left the pool while the spends were checked
+	for _, input := range tx.Inputs {
+		if pooled[fmt.Sprintf("%s:%d", input.TransactionID, input.Index)] == nil {
+			continue
+		}
+		if _, exists := p.transactions[input.TransactionID]; !exists {
+			return fmt.Errorf("%w: %s: output %s left the pool", ErrInvalidSpend, tx.ID, input.Key())
 		}
-		tx.Salt = salt
 	}
 
-	// // Verify salt uniqueness - commenting out as it depends on BlockchainImpl
-	if err := p.verifyTransactionUniqueness(tx); err != nil {
-		return fmt.Errorf("transaction salt verification failed: %v", err)
+	replaced, victims, err := p.admit(entry)
+	if err != nil {
+		return err
 	}
 
 	// // Propagate to validators - removing validator propagation
@@ -74,44 +156,284 @@ func (p *txPoolImpl) AddTransaction(tx *types.Transaction) error {
 	// }
 	// log.Printf("Transaction %s propagated to all validators", tx.ID)
 
-	// Start database transaction
-	// Start database transaction
-	dbTx, err := p.db.BeginTransaction()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %v", err)
+	// Store the transaction and drop the records of the transactions it pushes out
+	if err := p.storeRecord(tx, append(replaced, victims...)); err != nil {
+		return err
 	}
-	defer dbTx.Rollback()
+	p.apply(entry, replaced, victims)
+
+	log.Printf("Transaction %s with salt added to pool. Total in pool: %d",
+		tx.ID, len(p.byFee))
+
+	return nil
+}
 
-	// Store transaction with salt
-	txKey := []byte("transaction-" + tx.ID)
+// newEntry checks that the salt of tx has not been used, that its memo fits, that tx
+// is signed and that it pays the minimum relay fee, and returns its pool entry. The
+// caller has already checked the spends of tx, so its fee rate is funded.
+func (p *txPoolImpl) newEntry(tx *types.Transaction) (*txEntry, error) {
+	if err := tx.ValidateMemo(); err != nil {
+		return nil, err
+	}
+
+	// The salt is covered by the signature, so it has to be set by the sender before
+	// signing rather than generated here
+	// // Verify salt uniqueness - commenting out as it depends on BlockchainImpl
+	if err := p.verifyTransactionUniqueness(tx); err != nil {
+		return nil, fmt.Errorf("transaction salt verification failed: %v", err)
+	}
+
+	if err := p.blockchain.sigVerifier.Verify(tx, p.blockchain.GetChainID()); err != nil {
+		return nil, err
+	}
+
+	// Reject transactions paying less than the minimum relay fee for their size
 	tx.Status = "pending"
-	txJSON, err := json.Marshal(tx)
+	if err := checkRelayFee(tx); err != nil {
+		return nil, err
+	}
+	feeRate, size, err := transactionFeeRate(tx)
 	if err != nil {
-		return fmt.Errorf("error marshaling transaction: %v", err)
+		return nil, err
 	}
+	return &txEntry{
+		txID:    tx.ID,
+		tx:      tx,
+		size:    size,
+		feeRate: feeRate,
+		sender:  tx.SenderAddress.String(),
+		addedAt: time.Now(),
+	}, nil
+}
 
-	// Get the badger transaction from the context
-	badgerTxn := dbTx.GetBadgerTxn()
-	if err := badgerTxn.Set(txKey, txJSON); err != nil {
-		return fmt.Errorf("error storing transaction: %v", err)
+// pooledOutputs returns the outputs of pooled transactions that tx spends, keyed like
+// the UTXO set.
+func (p *txPoolImpl) pooledOutputs(tx *types.Transaction) map[string]*thrylos.UTXO {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	pooled := make(map[string]*thrylos.UTXO)
+	for _, input := range tx.Inputs {
+		parent, exists := p.transactions[input.TransactionID]
+		if !exists || input.Index < 0 || input.Index >= len(parent.tx.Outputs) {
+			continue
+		}
+		pooled[fmt.Sprintf("%s:%d", input.TransactionID, input.Index)] = sharedUTXOToProto(parent.tx.Outputs[input.Index])
 	}
+	return pooled
+}
 
-	// Commit the transaction
-	if err := dbTx.Commit(); err != nil {
-		return fmt.Errorf("error committing transaction: %v", err)
+// admit checks entry against the pool limits and returns the transactions it replaces
+// and the transactions that must be evicted to make room for it.
+func (p *txPoolImpl) admit(entry *txEntry) (replaced, victims []*txEntry, err error) {
+	replaced, err = p.replacementSet(entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parent := spentParent(entry.tx, replaced); parent != nil {
+		return nil, nil, fmt.Errorf("%w: %s spends outputs of %s, which it replaces", ErrInvalidSpend, entry.txID, parent.txID)
+	}
+	pending := p.senderCounts[entry.sender]
+	for _, old := range replaced {
+		if old.sender == entry.sender {
+			pending--
+		}
 	}
+	if pending >= p.config.MaxPerSender {
+		return nil, nil, fmt.Errorf("%w: %s has %d", ErrSenderLimit, entry.sender, pending)
+	}
+	victims, err = p.evictionVictims(entry, replaced)
+	if err != nil {
+		return nil, nil, err
+	}
+	return replaced, victims, nil
+}
 
-	// Add to pool
-	entry := &txEntry{txID: tx.ID, tx: tx}
-	element := p.order.PushBack(entry)
-	p.transactions[tx.ID] = element
+// apply removes the replaced and evicted transactions and adds entry to the pool.
+func (p *txPoolImpl) apply(entry *txEntry, replaced, victims []*txEntry) {
+	for _, old := range replaced {
+		p.removeEntry(old)
+		log.Printf("Transaction %s replaced in the pool by %s", old.txID, entry.txID)
+		shared.GetMessageBus().Publish(types.Message{
+			Type: types.TransactionReplaced,
+			Data: types.TransactionReplacedEvent{ReplacedID: old.txID, ReplacementID: entry.txID},
+		})
+	}
+	for _, victim := range victims {
+		p.removeEntry(victim)
+		log.Printf("Transaction %s evicted from the pool by %s (%.2f < %.2f per byte)",
+			victim.txID, entry.txID, victim.feeRate, entry.feeRate)
+	}
+	p.insertEntry(entry)
+}
 
-	log.Printf("Transaction %s with salt added to pool. Total in pool: %d",
-		tx.ID, p.order.Len())
+// replacementSet returns the pooled transactions entry conflicts with and their
+// descendants, or an error wrapping ErrTransactionConflict when entry does not pay
+// enough to replace them.
+func (p *txPoolImpl) replacementSet(entry *txEntry) ([]*txEntry, error) {
+	replaced := p.withDescendants(p.conflictsWith(entry.tx))
+	if len(replaced) == 0 {
+		return nil, nil
+	}
 
+	var oldFee int
+	for _, old := range replaced {
+		oldFee += old.tx.GasFee
+	}
+	required := max(oldFee+(oldFee*p.config.ReplacementBump+99)/100, oldFee+1)
+	if entry.tx.GasFee < required {
+		return nil, fmt.Errorf("%w: %s spends outputs of %s; replacing %d transactions needs a fee of at least %d, got %d",
+			ErrTransactionConflict, entry.txID, replaced[0].txID, len(replaced), required, entry.tx.GasFee)
+	}
+	return replaced, nil
+}
+
+// conflictsWith returns the pooled transactions spending an output that tx spends.
+func (p *txPoolImpl) conflictsWith(tx *types.Transaction) []*txEntry {
+	var conflicts []*txEntry
+	for _, input := range tx.Inputs {
+		if old, exists := p.spentBy[input.Key()]; exists && old.txID != tx.ID && !containsEntry(conflicts, old) {
+			conflicts = append(conflicts, old)
+		}
+	}
+	return conflicts
+}
+
+// withDescendants extends entries with the pooled transactions that spend their
+// outputs, directly or through other pooled transactions.
+func (p *txPoolImpl) withDescendants(entries []*txEntry) []*txEntry {
+	for i := 0; i < len(entries); i++ {
+		for _, child := range p.byFee {
+			if containsEntry(entries, child) {
+				continue
+			}
+			for _, input := range child.tx.Inputs {
+				if input.TransactionID == entries[i].txID {
+					entries = append(entries, child)
+					break
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// spentParent returns the entry among entries whose outputs tx spends, or nil.
+func spentParent(tx *types.Transaction, entries []*txEntry) *txEntry {
+	for _, input := range tx.Inputs {
+		for _, e := range entries {
+			if input.TransactionID == e.txID {
+				return e
+			}
+		}
+	}
 	return nil
 }
 
+func containsEntry(entries []*txEntry, entry *txEntry) bool {
+	for _, e := range entries {
+		if e == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// evictionVictims returns the lowest fee transactions that must be evicted for entry
+// to fit in the pool once the replaced transactions are gone. Only transactions paying
+// a lower fee rate than entry are evicted, together with their descendants, whose
+// inputs leave the pool with them. Transactions whose outputs entry spends are kept.
+func (p *txPoolImpl) evictionVictims(entry *txEntry, replaced []*txEntry) ([]*txEntry, error) {
+	excess := p.totalBytes + entry.size - p.config.MaxBytes
+	skip := make(map[*txEntry]bool, len(replaced))
+	for _, old := range replaced {
+		excess -= old.size
+		skip[old] = true
+	}
+	if excess <= 0 {
+		return nil, nil
+	}
+	var victims []*txEntry
+	for i := len(p.byFee) - 1; i >= 0 && excess > 0; i-- {
+		victim := p.byFee[i]
+		if skip[victim] || containsEntry(victims, victim) {
+			continue
+		}
+		if victim.feeRate >= entry.feeRate {
+			break
+		}
+		evicted := p.withDescendants([]*txEntry{victim})
+		if spentParent(entry.tx, evicted) != nil {
+			continue
+		}
+		for _, e := range evicted {
+			if !skip[e] && !containsEntry(victims, e) {
+				victims = append(victims, e)
+				excess -= e.size
+			}
+		}
+	}
+	if excess > 0 {
+		return nil, fmt.Errorf("%w: %d of %d bytes used, transaction %s needs %d at %.2f per byte",
+			ErrMempoolFull, p.totalBytes, p.config.MaxBytes, entry.txID, entry.size, entry.feeRate)
+	}
+	return victims, nil
+}
+
+// expireTransactions drops the transactions that have waited longer than the pool TTL,
+// together with their descendants.
+func (p *txPoolImpl) expireTransactions(now time.Time) {
+	if p.config.TTL <= 0 {
+		return
+	}
+	var expired []*txEntry
+	for _, entry := range p.byFee {
+		if now.Sub(entry.addedAt) > p.config.TTL {
+			expired = append(expired, entry)
+		}
+	}
+	expired = p.withDescendants(expired)
+	for _, entry := range expired {
+		p.removeEntry(entry)
+		log.Printf("Transaction %s expired from the pool after %v", entry.txID, p.config.TTL)
+	}
+	if err := p.deleteRecords(expired); err != nil {
+		log.Printf("Failed to delete expired pool transactions: %v", err)
+	}
+}
+
+func (p *txPoolImpl) insertEntry(entry *txEntry) {
+	pos := sort.Search(len(p.byFee), func(i int) bool { return entry.ranksBefore(p.byFee[i]) })
+	p.byFee = append(p.byFee, nil)
+	copy(p.byFee[pos+1:], p.byFee[pos:])
+	p.byFee[pos] = entry
+
+	p.transactions[entry.txID] = entry
+	for _, input := range entry.tx.Inputs {
+		p.spentBy[input.Key()] = entry
+	}
+	p.senderCounts[entry.sender]++
+	p.totalBytes += entry.size
+}
+
+func (p *txPoolImpl) removeEntry(entry *txEntry) {
+	pos := sort.Search(len(p.byFee), func(i int) bool { return !p.byFee[i].ranksBefore(entry) })
+	if pos < len(p.byFee) && p.byFee[pos] == entry {
+		p.byFee = append(p.byFee[:pos], p.byFee[pos+1:]...)
+	}
+
+	delete(p.transactions, entry.txID)
+	for _, input := range entry.tx.Inputs {
+		if p.spentBy[input.Key()] == entry {
+			delete(p.spentBy, input.Key())
+		}
+	}
+	if p.senderCounts[entry.sender]--; p.senderCounts[entry.sender] <= 0 {
+		delete(p.senderCounts, entry.sender)
+	}
+	p.totalBytes -= entry.size
+}
+
 // // Helper function to verify transaction uniqueness using salt - commenting out as depends on BlockchainImpl
 func (p *txPoolImpl) verifyTransactionUniqueness(tx *types.Transaction) error {
 	if tx == nil {
@@ -140,13 +462,14 @@ func (p *txPoolImpl) BroadcastTransaction(tx *types.Transaction) error {
 	return nil
 }
 
+// GetAllTransactions returns the pooled transactions, highest fee rate first.
 func (p *txPoolImpl) GetAllTransactions() ([]*types.Transaction, error) {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	txs := make([]*types.Transaction, 0, p.order.Len())
-	for e := p.order.Front(); e != nil; e = e.Next() {
-		entry := e.Value.(*txEntry)
+	p.expireTransactions(time.Now())
+	txs := make([]*types.Transaction, 0, len(p.byFee))
+	for _, entry := range p.byFee {
 		txs = append(txs, entry.tx)
 	}
 
@@ -154,25 +477,90 @@ func (p *txPoolImpl) GetAllTransactions() ([]*types.Transaction, error) {
 	return txs, nil
 }
 
+// GetFirstTransaction returns the transaction paying the highest fee rate.
 func (p *txPoolImpl) GetFirstTransaction() (*types.Transaction, error) {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	if p.order.Len() == 0 {
+	p.expireTransactions(time.Now())
+	if len(p.byFee) == 0 {
 		return nil, errors.New("no transactions in the pool")
 	}
 
-	firstElement := p.order.Front()
-	entry := firstElement.Value.(*txEntry)
-	return entry.tx, nil
+	return p.byFee[0].tx, nil
+}
+
+// GetBatchForBlock returns the highest fee rate set of pooled transactions that fits
+// in maxBytes and config.MaxBlockTransactions. Transactions spending an output already
+// spent by a chosen transaction are skipped, and a transaction spending the output of
+// another pooled transaction is only chosen after its parent. The spends of every
+// pooled transaction were checked on admission and its pooled parents never leave
+// without it, so the fee rates ranked here are funded.
+func (p *txPoolImpl) GetBatchForBlock(maxBytes int) ([]*types.Transaction, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.expireTransactions(time.Now())
+
+	var batch []*types.Transaction
+	chosen := make(map[string]bool)
+	spent := make(map[string]bool)
+	pending := append([]*txEntry(nil), p.byFee...)
+	bytes := 0
+	for progress := true; progress && len(pending) > 0; {
+		progress = false
+		deferred := pending[:0]
+		for _, entry := range pending {
+			if len(batch) >= config.MaxBlockTransactions {
+				break
+			}
+			if bytes+entry.size > maxBytes || p.conflicts(entry.tx, spent) {
+				continue
+			}
+			if !p.parentsChosen(entry.tx, chosen) {
+				deferred = append(deferred, entry)
+				continue
+			}
+			for _, input := range entry.tx.Inputs {
+				spent[input.Key()] = true
+			}
+			chosen[entry.txID] = true
+			batch = append(batch, entry.tx)
+			bytes += entry.size
+			progress = true
+		}
+		pending = deferred
+	}
+
+	log.Printf("Selected %d of %d pooled transactions (%d bytes) for a block", len(batch), len(p.byFee), bytes)
+	return batch, nil
+}
+
+// conflicts reports whether tx spends an output in spent.
+func (p *txPoolImpl) conflicts(tx *types.Transaction, spent map[string]bool) bool {
+	for _, input := range tx.Inputs {
+		if spent[input.Key()] {
+			return true
+		}
+	}
+	return false
+}
+
+// parentsChosen reports whether every pooled transaction whose outputs tx spends is in chosen.
+func (p *txPoolImpl) parentsChosen(tx *types.Transaction, chosen map[string]bool) bool {
+	for _, input := range tx.Inputs {
+		if _, pooled := p.transactions[input.TransactionID]; pooled && !chosen[input.TransactionID] {
+			return false
+		}
+	}
+	return true
 }
 
 func (p *txPoolImpl) UpdateTransactionStatus(txID string, status string) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if elem, exists := p.transactions[txID]; exists {
-		entry := elem.Value.(*txEntry)
+	if entry, exists := p.transactions[txID]; exists {
 		entry.tx.Status = status
 		return nil
 	}
@@ -183,13 +571,12 @@ func (p *txPoolImpl) GetTransaction(txID string) (*types.Transaction, error) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	element, exists := p.transactions[txID]
+	entry, exists := p.transactions[txID]
 	if !exists {
 		log.Printf("Transaction %s not found in the pool", txID)
 		return nil, errors.New("transaction not found in the pool")
 	}
 
-	entry := element.Value.(*txEntry)
 	log.Printf("Transaction %s retrieved from the pool", txID)
 	return entry.tx, nil
 }
@@ -198,48 +585,76 @@ func (p *txPoolImpl) Size() int {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	return p.order.Len()
+	return len(p.byFee)
 }
 
-// // RemoveTransaction removes a transaction from the pool
+// RemoveTransaction removes a transaction from the pool, together with the pooled
+// transactions spending its outputs
 func (p *txPoolImpl) RemoveTransaction(tx *types.Transaction) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	element, exists := p.transactions[tx.ID]
+	entry, exists := p.transactions[tx.ID]
 	if !exists {
 		log.Printf("Transaction %s not found in the pool", tx.ID)
 		return errors.New("transaction not found in the pool")
 	}
 
-	p.order.Remove(element)
-	delete(p.transactions, tx.ID)
-	log.Printf("Transaction %s removed from the pool", tx.ID)
-	return nil
-	if tx == nil {
-		return fmt.Errorf("nil transaction")
+	removed := p.withDescendants([]*txEntry{entry})
+	for _, e := range removed {
+		p.removeEntry(e)
 	}
-	if len(tx.Salt) == 0 {
-		return fmt.Errorf("empty salt")
+	if err := p.deleteRecords(removed); err != nil {
+		return err
 	}
-	if len(tx.Salt) != 32 {
-		return fmt.Errorf("invalid salt length: expected 32 bytes, got %d", len(tx.Salt))
+	log.Printf("Transaction %s and %d descendants removed from the pool", tx.ID, len(removed)-1)
+	return nil
+}
+
+// RemoveBlockTransactions removes the transactions mined in block from the pool,
+// together with the pooled transactions that conflict with them and their descendants,
+// and deletes their stored records.
+func (p *txPoolImpl) RemoveBlockTransactions(block *types.Block) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var mined, conflicts []*txEntry
+	for _, tx := range block.Transactions {
+		if entry, exists := p.transactions[tx.ID]; exists {
+			mined = append(mined, entry)
+		}
+		for _, conflict := range p.conflictsWith(tx) {
+			if !containsEntry(conflicts, conflict) {
+				conflicts = append(conflicts, conflict)
+			}
+		}
 	}
 
-	// Use the efficient helper function to check salt uniqueness
-	if p.blockchain.checkSaltInBlocks(tx.Salt) {
-		return fmt.Errorf("duplicate salt detected: transaction replay attempt")
+	// Descendants of a mined transaction stay valid, those of a conflicting one do not
+	conflicts = p.withDescendants(conflicts)
+	removed := mined
+	for _, entry := range conflicts {
+		if !containsEntry(removed, entry) {
+			removed = append(removed, entry)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
 	}
 
-	return nil
+	for _, entry := range removed {
+		p.removeEntry(entry)
+	}
+	log.Printf("Removed %d mined and %d conflicting transactions of block %d from the pool",
+		len(mined), len(removed)-len(mined), block.Index)
+	return p.deleteRecords(removed)
 }
 
 func (p *txPoolImpl) GetTransactionStatus(txID string) (string, error) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	if elem, exists := p.transactions[txID]; exists {
-		entry := elem.Value.(*txEntry)
+	if entry, exists := p.transactions[txID]; exists {
 		return entry.tx.Status, nil
 	}
 	return "", fmt.Errorf("transaction not found")
@@ -0,0 +1,188 @@
+package chain
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	thrylos "github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// storeRecord writes the pool record of tx and deletes the records of the pooled
+// transactions it pushes out, in one database transaction.
+func (p *txPoolImpl) storeRecord(tx *types.Transaction, dropped []*txEntry) error {
+	return p.db.SavePendingTransaction(tx, entryIDs(dropped))
+}
+
+// deleteRecords deletes the pool records of entries.
+func (p *txPoolImpl) deleteRecords(entries []*txEntry) error {
+	return p.db.DeletePendingTransactions(entryIDs(entries))
+}
+
+func entryIDs(entries []*txEntry) []string {
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.txID
+	}
+	return ids
+}
+
+// reload restores the pool from the records stored by AddTransaction, so pending
+// transactions survive a restart. Every record is checked again against the current
+// UTXO set and the pool rules; the records that fail, because their transaction was
+// mined, spends outputs that are gone or no longer fits the pool, are deleted. It
+// returns the number of transactions restored.
+func (p *txPoolImpl) reload() (int, error) {
+	txs, stale, err := p.db.GetPendingTransactions()
+	if err != nil {
+		return 0, err
+	}
+	sort.SliceStable(txs, func(i, j int) bool { return txs[i].Timestamp < txs[j].Timestamp })
+
+	valid, invalid := p.blockchain.checkPendingSpends(txs)
+	for id, err := range invalid {
+		log.Printf("Dropping stale pool transaction %s: %v", id, err)
+		stale = append(stale, id)
+	}
+
+	// The salt and signature checks read the chain, so entries are built before the pool lock is taken
+	var entries []*txEntry
+	for _, tx := range valid {
+		entry, err := p.newEntry(tx)
+		if err != nil {
+			log.Printf("Dropping stale pool transaction %s: %v", tx.ID, err)
+			stale = append(stale, tx.ID)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	failed := make(map[string]bool, len(stale))
+	for _, id := range stale {
+		failed[id] = true
+	}
+	restored := 0
+	for _, entry := range entries {
+		err := p.admitReloaded(entry, failed)
+		if err != nil {
+			log.Printf("Dropping stale pool transaction %s: %v", entry.txID, err)
+			failed[entry.txID] = true
+			stale = append(stale, entry.txID)
+			continue
+		}
+		restored++
+	}
+
+	if err := p.db.DeletePendingTransactions(stale); err != nil {
+		return restored, err
+	}
+	log.Printf("Restored %d transactions to the pool, dropped %d stale records", restored, len(stale))
+	return restored, nil
+}
+
+// admitReloaded adds a reloaded entry to the pool unless it spends the output of a
+// transaction that failed to reload.
+func (p *txPoolImpl) admitReloaded(entry *txEntry, failed map[string]bool) error {
+	if _, exists := p.transactions[entry.txID]; exists {
+		return fmt.Errorf("transaction is stored twice")
+	}
+	for _, input := range entry.tx.Inputs {
+		if failed[input.TransactionID] {
+			return fmt.Errorf("spends output %s of a dropped transaction", input.Key())
+		}
+	}
+	replaced, victims, err := p.admit(entry)
+	if err != nil {
+		return err
+	}
+	if err := p.deleteRecords(append(replaced, victims...)); err != nil {
+		return err
+	}
+	p.apply(entry, replaced, victims)
+	return nil
+}
+
+// checkPendingSpends splits pool transactions into those whose inputs are in the
+// UTXO set, or are outputs of other valid pool transactions, and those that spend
+// missing, foreign or still locked outputs. Valid transactions are returned parents first.
+func (bc *BlockchainImpl) checkPendingSpends(txs []*types.Transaction) ([]*types.Transaction, map[string]error) {
+	bc.Blockchain.Mu.RLock()
+	defer bc.Blockchain.Mu.RUnlock()
+
+	next := bc.nextPendingBlock()
+	waiting := make(map[string]bool, len(txs))
+	for _, tx := range txs {
+		waiting[tx.ID] = true
+	}
+	var valid []*types.Transaction
+	invalid := make(map[string]error)
+	created := make(map[string]*thrylos.UTXO)
+	for progress := true; progress && len(waiting) > 0; {
+		progress = false
+		for _, tx := range txs {
+			if !waiting[tx.ID] {
+				continue
+			}
+			view := make(map[string][]*thrylos.UTXO, len(tx.Inputs))
+			ready := true
+			for _, input := range tx.Inputs {
+				utxoKey := fmt.Sprintf("%s:%d", input.TransactionID, input.Index)
+				if outputs := bc.Blockchain.UTXOs[utxoKey]; len(outputs) > 0 {
+					view[utxoKey] = outputs
+				} else if output := created[utxoKey]; output != nil {
+					view[utxoKey] = []*thrylos.UTXO{output}
+				} else if waiting[input.TransactionID] {
+					ready = false
+				}
+			}
+			if !ready {
+				continue
+			}
+			delete(waiting, tx.ID)
+			progress = true
+			if err := verifyTransactionSpends(view, next, tx); err != nil {
+				invalid[tx.ID] = err
+				continue
+			}
+			for index, output := range tx.Outputs {
+				created[fmt.Sprintf("%s:%d", tx.ID, index)] = sharedUTXOToProto(output)
+			}
+			valid = append(valid, tx)
+		}
+	}
+	for id := range waiting {
+		invalid[id] = fmt.Errorf("spends outputs of transactions that are not pending")
+	}
+	return valid, invalid
+}
+
+// checkPoolSpend checks that tx spends outputs of its sender, taken from the UTXO set
+// or from pooled, the outputs of pooled transactions keyed like the UTXO set, and that
+// its inputs cover its outputs and fee.
+func (bc *BlockchainImpl) checkPoolSpend(tx *types.Transaction, pooled map[string]*thrylos.UTXO) error {
+	bc.Blockchain.Mu.RLock()
+	defer bc.Blockchain.Mu.RUnlock()
+
+	view := make(map[string][]*thrylos.UTXO, len(tx.Inputs))
+	for _, input := range tx.Inputs {
+		utxoKey := fmt.Sprintf("%s:%d", input.TransactionID, input.Index)
+		if outputs := bc.Blockchain.UTXOs[utxoKey]; len(outputs) > 0 {
+			view[utxoKey] = outputs
+		} else if output := pooled[utxoKey]; output != nil {
+			view[utxoKey] = []*thrylos.UTXO{output}
+		}
+	}
+	return verifyTransactionSpends(view, bc.nextPendingBlock(), tx)
+}
+
+// nextPendingBlock returns a stand-in for the next block, against which the locks of
+// pending spends are checked. The caller holds the chain lock.
+func (bc *BlockchainImpl) nextPendingBlock() *types.Block {
+	tip := bc.Blockchain.Blocks[len(bc.Blockchain.Blocks)-1]
+	return &types.Block{Index: tip.Index + 1, Timestamp: max(time.Now().Unix(), tip.Timestamp+1)}
+}
@@ -1,9 +1,13 @@
 package chain
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 
 	"github.com/gballet/go-verkle"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/types"
 )
 
 // Acts as an organized way to store and verify transaction data
@@ -13,6 +17,15 @@ const (
 	LeafValueSize = 32 // Define LeafValueSize if it's standard for leaf values in Verkle trees
 )
 
+// TransactionProof proves that a transaction is committed to by the VerkleRoot of a block.
+// The proven leaf is the one transactionLeaf derives from the transaction.
+type TransactionProof struct {
+	BlockIndex int64               `json:"blockIndex"`
+	TxID       string              `json:"txId"`
+	Proof      *verkle.VerkleProof `json:"proof"`
+	StateDiff  verkle.StateDiff    `json:"stateDiff"`
+}
+
 func NewVerkleTree(data [][]byte) (verkle.VerkleNode, error) {
 	if len(data) == 0 {
 		return nil, errors.New("no data provided for Verkle tree creation")
@@ -34,3 +47,144 @@ func NewVerkleTree(data [][]byte) (verkle.VerkleNode, error) {
 
 	return root, nil
 }
+
+// transactionLeaf returns the Verkle key and value of a transaction: the key is the hash
+// of its signed fields and the value is the hash of its ID. The signed fields leave out
+// the status and block hash, so the key does not change once the transaction is
+// confirmed. The chain ID is left empty; a block only holds transactions of its chain.
+func transactionLeaf(tx *types.Transaction) (hash.Hash, hash.Hash, error) {
+	preimage, err := tx.SigningBytes("")
+	if err != nil {
+		return hash.Hash{}, hash.Hash{}, err
+	}
+	return hash.NewHash(preimage), hash.NewHash([]byte(tx.ID)), nil
+}
+
+// newTransactionTree builds and commits the Verkle tree of a block's transactions.
+func newTransactionTree(transactions []*types.Transaction) (verkle.VerkleNode, error) {
+	leaves := make([][]byte, 0, len(transactions))
+	for _, tx := range transactions {
+		key, value, err := transactionLeaf(tx)
+		if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, append(key.Bytes(), value.Bytes()...))
+	}
+
+	tree, err := NewVerkleTree(leaves)
+	if err != nil {
+		return nil, err
+	}
+	tree.Commit()
+	return tree, nil
+}
+
+// GetTransactionProof returns a proof that the transaction is included in the block at
+// blockIndex, verifiable against the block's VerkleRoot with VerifyTransactionProof.
+func (bc *BlockchainImpl) GetTransactionProof(blockIndex int, txID string) (*TransactionProof, error) {
+	block, err := bc.GetBlock(blockIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *types.Transaction
+	for _, tx := range block.Transactions {
+		if tx.ID == txID {
+			target = tx
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("transaction %s not found in block %d", txID, blockIndex)
+	}
+
+	tree, err := newTransactionTree(block.Transactions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Verkle tree: %v", err)
+	}
+	commitment := tree.Commitment().BytesUncompressedTrusted()
+	if !bytes.Equal(commitment[:], block.VerkleRoot) {
+		return nil, fmt.Errorf("block %d Verkle root does not match its transactions", blockIndex)
+	}
+
+	txHash, _, err := transactionLeaf(target)
+	if err != nil {
+		return nil, err
+	}
+	proof, _, _, _, err := verkle.MakeVerkleMultiProof(tree, nil, [][]byte{txHash.Bytes()}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proof: %v", err)
+	}
+	verkleProof, stateDiff, err := verkle.SerializeProof(proof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize proof: %v", err)
+	}
+
+	return &TransactionProof{
+		BlockIndex: block.Index,
+		TxID:       txID,
+		Proof:      verkleProof,
+		StateDiff:  stateDiff,
+	}, nil
+}
+
+// VerifyTransactionProof checks that the proof shows tx committed to by a block's
+// VerkleRoot. The leaf is derived from tx itself, so a proof for one transaction does not
+// verify another. It needs no access to the chain, so light clients can use it with a
+// block header only.
+func VerifyTransactionProof(verkleRoot []byte, tx *types.Transaction, proof *TransactionProof) error {
+	if tx == nil {
+		return errors.New("no transaction to verify")
+	}
+	if proof == nil || proof.Proof == nil || proof.Proof.IPAProof == nil {
+		return errors.New("empty transaction proof")
+	}
+	key, value, err := transactionLeaf(tx)
+	if err != nil {
+		return err
+	}
+
+	root, err := decodeVerkleRoot(verkleRoot)
+	if err != nil {
+		return fmt.Errorf("invalid Verkle root: %v", err)
+	}
+
+	p, err := verkle.DeserializeProof(proof.Proof, proof.StateDiff)
+	if err != nil {
+		return fmt.Errorf("failed to deserialize proof: %v", err)
+	}
+	preState, err := verkle.PreStateTreeFromProof(p, root)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild tree from proof: %v", err)
+	}
+	if err := verkle.VerifyVerkleProofWithPreState(p, preState); err != nil {
+		return fmt.Errorf("invalid proof: %v", err)
+	}
+
+	for i, provenKey := range p.Keys {
+		if !bytes.Equal(provenKey, key.Bytes()) {
+			continue
+		}
+		if !bytes.Equal(p.PreValues[i], value.Bytes()) {
+			return fmt.Errorf("proof value does not match transaction %s", tx.ID)
+		}
+		return nil
+	}
+	return fmt.Errorf("transaction %s is not covered by the proof", tx.ID)
+}
+
+// decodeVerkleRoot decodes a VerkleRoot as written by BytesUncompressedTrusted. Its Y
+// coordinate may be either representative of the point, which the untrusted uncompressed
+// decoding rejects, so the point is checked through its compressed encoding instead.
+func decodeVerkleRoot(verkleRoot []byte) (*verkle.Point, error) {
+	var decoded verkle.Point
+	if err := decoded.SetBytesUncompressed(verkleRoot, true); err != nil {
+		return nil, err
+	}
+	compressed := decoded.Bytes()
+	var root verkle.Point
+	if err := root.SetBytes(compressed[:]); err != nil {
+		return nil, err
+	}
+	return &root, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bufio"
+	"log"
+	"os"
+
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// runChainTransfer exports the chain to path or imports it from path, depending on
+// command, and closes the store. It returns the process exit code.
+func runChainTransfer(blockchain *chain.BlockchainImpl, blockchainStore types.Store, command, path string) int {
+	if closer, ok := blockchainStore.(interface{ Close() error }); ok {
+		defer closer.Close()
+	}
+
+	if command == "export" {
+		file, err := os.Create(path)
+		if err != nil {
+			log.Printf("Failed to create export file %s: %v", path, err)
+			return 1
+		}
+		defer file.Close()
+
+		writer := bufio.NewWriter(file)
+		count, err := blockchain.ExportChain(writer)
+		if err == nil {
+			err = writer.Flush()
+		}
+		if err != nil {
+			log.Printf("Export failed after %d blocks: %v", count, err)
+			return 1
+		}
+		log.Printf("Exported %d blocks to %s", count, path)
+		return 0
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Printf("Failed to open import file %s: %v", path, err)
+		return 1
+	}
+	defer file.Close()
+
+	count, err := blockchain.ImportChain(bufio.NewReader(file))
+	if err != nil {
+		log.Printf("Import stopped after %d blocks: %v", count, err)
+		return 1
+	}
+	log.Printf("Imported %d blocks from %s", count, path)
+	return 0
+}
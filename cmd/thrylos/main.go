@@ -11,7 +11,10 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/thrylos-labs/thrylos"
 	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/node"
 	"github.com/thrylos-labs/thrylos/types"
 
 	"github.com/joho/godotenv"
@@ -57,7 +60,7 @@ func loadEnv() (map[string]string, error) {
 		"HTTP_NODE_ADDRESS",
 		"GRPC_NODE_ADDRESS",
 		"AES_KEY_ENV_VAR",
-		"GENESIS_ACCOUNT",
+		"GENESIS_FILE",
 		"DATA_DIR",
 	}
 
@@ -122,10 +125,10 @@ func main() {
 		log.Fatalf("Error decoding AES key: %v", err)
 	}
 
-	// Genesis account
-	genesisAccount := envFile["GENESIS_ACCOUNT"]
-	if genesisAccount == "" {
-		log.Fatal("Genesis account is not set in environment variables. Please configure a genesis account before starting.")
+	// Genesis file shared by every node of the network
+	genesis, err := config.LoadGenesisFromFile(envFile["GENESIS_FILE"])
+	if err != nil {
+		log.Fatalf("Error loading genesis file: %v", err)
 	}
 
 	// Get the absolute path of the node data directory
@@ -135,6 +138,18 @@ func main() {
 	}
 	log.Printf("Using blockchain data directory: %s", absPath)
 
+	// Commands run against the data directory and exit without starting the node
+	command := ""
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+	switch command {
+	case "verify-chain":
+		os.Exit(runVerifyChain(absPath, aesKey, genesis.ChainID))
+	case "migrate":
+		os.Exit(runMigrate(absPath, len(os.Args) > 2 && os.Args[2] == "--dry-run"))
+	}
+
 	// Initialize the blockchain and database with the AES key
 
 	// Remember to set TestMode to false in your production environment to ensure that the fallback mechanism is never used with real transactions.
@@ -145,17 +160,29 @@ func main() {
 		log.Fatalf("Error generating private key: %v", err)
 	}
 
-	blockchain, _, err := chain.NewBlockchain(&types.BlockchainConfig{
+	blockchain, blockchainStore, err := chain.NewBlockchain(&types.BlockchainConfig{
 		DataDir:           absPath,
 		AESKey:            aesKey,
 		GenesisAccount:    privKey,
 		TestMode:          true,
-		DisableBackground: false,
+		DisableBackground: command != "",
+		Genesis:           genesis,
 	})
 	if err != nil {
 		log.Fatalf("Failed to initialize the blockchain at %s: %v", absPath, err)
 	}
 
+	switch command {
+	case "":
+	case "export", "import":
+		if len(os.Args) < 3 {
+			log.Fatalf("Usage: thrylos %s <file>", command)
+		}
+		os.Exit(runChainTransfer(blockchain, blockchainStore, command, os.Args[2]))
+	default:
+		log.Fatalf("Unknown command %q", command)
+	}
+
 	// Perform an integrity check on the blockchain
 	if !blockchain.CheckChainIntegrity() {
 		log.Fatal("Blockchain integrity check failed.")
@@ -189,6 +216,18 @@ func main() {
 	// Setup HTTP/WS servers
 	// setupServers(mux, envFile)
 
+	// Serve the JSON-RPC methods of the node over HTTP
+	if httpAddress := envFile["HTTP_NODE_ADDRESS"]; httpAddress != "" {
+		rpcMux := http.NewServeMux()
+		rpcMux.Handle("/", node.NewJSONRPCHandler(node.NewChainNode(blockchain, blockchainStore)))
+		rpcServer := &http.Server{Addr: httpAddress, Handler: rpcMux}
+		isDevelopment := envFile["ENV"] == "development"
+		if !isDevelopment {
+			rpcServer.TLSConfig = &tls.Config{Certificates: []tls.Certificate{loadCertificate(envFile)}}
+		}
+		go startServer(rpcServer, "JSON-RPC", isDevelopment)
+	}
+
 	// Setup and start gRPC server
 	lis, err := net.Listen("tcp", grpcAddress)
 	if err != nil {
@@ -206,7 +245,7 @@ func main() {
 		s = grpc.NewServer(grpc.Creds(creds))
 	}
 
-	// thrylos.RegisterBlockchainServiceServer(s, &server{blockchain: blockchain})
+	thrylos.RegisterBlockchainServiceServer(s, NewServer(blockchain, blockchainStore))
 
 	log.Printf("Starting gRPC server on %s\n", grpcAddress)
 	if err := s.Serve(lis); err != nil {
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/thrylos-labs/thrylos/store"
+)
+
+// runMigrate migrates the database in dataDir to the current schema version, or with
+// dryRun reports the migrations it needs without applying them, and prints the
+// migrations as JSON on stdout. It returns the process exit code: 0 on success and 2
+// when the database could not be opened or migrated.
+func runMigrate(dataDir string, dryRun bool) int {
+	database, err := store.NewDatabaseWithOptions(dataDir, store.DatabaseOptions{SkipMigrations: true})
+	if err != nil {
+		log.Printf("Failed to open the blockchain database at %s: %v", dataDir, err)
+		return 2
+	}
+	defer database.Close()
+
+	from, err := database.SchemaVersion()
+	if err != nil {
+		log.Print(err)
+		return 2
+	}
+	migrations, err := database.Migrate(dryRun)
+	if err != nil {
+		log.Printf("Failed to migrate the blockchain database at %s: %v", dataDir, err)
+		return 2
+	}
+
+	out, err := json.MarshalIndent(map[string]interface{}{
+		"fromVersion": from,
+		"toVersion":   store.CurrentSchemaVersion,
+		"dryRun":      dryRun,
+		"migrations":  migrations,
+	}, "", "  ")
+	if err != nil {
+		log.Printf("Failed to encode migration report: %v", err)
+		return 2
+	}
+	fmt.Println(string(out))
+	return 0
+}
@@ -1,54 +1,135 @@
 package main
 
-// type server struct {
-// 	thrylos.UnimplementedBlockchainServiceServer
-// 	blockchain   *chain.BlockchainImpl
-// 	store        types.Store                   // Changed from *database.BlockchainDB to types.Store
-// 	PublicKeyMap map[string]*mldsa44.PublicKey // Changed to use mldsa44.PublicKey
-// 	hasherPool   *XOFPool                      // Add the hasher pool here
-
-// }
-
-// func NewServer(store types.Store) *server {
-// 	pool := NewXOFPool(10) // Adjust the pool size based on expected load
-
-// 	return &server{
-// 		store:        store, // Using store instead of db
-// 		PublicKeyMap: make(map[string]*mldsa44.PublicKey),
-// 		hasherPool:   pool,
-// 	}
-// }
-
-// // server.go
-// // server.go
-// func (s *server) GetBalance(ctx context.Context, req *thrylos.GetBalanceRequest) (*thrylos.BalanceResponse, error) {
-// 	if req.Address == "" {
-// 		return nil, status.Error(codes.InvalidArgument, "Address is required")
-// 	}
-
-// 	// Create empty UTXO map for the GetBalance call
-// 	utxoMap := make(map[string][]types.UTXO)
-
-// 	// Get balance using the existing GetBalance method with UTXO map
-// 	balance, err := s.store.GetBalance(req.Address, utxoMap)
-// 	if err != nil {
-// 		// Handle new wallet case
-// 		if strings.Contains(err.Error(), "wallet not found") {
-// 			balance = 700000000 // 70 Thrylos in nanoTHR
-// 		} else {
-// 			return nil, status.Errorf(codes.Internal, "Failed to get balance: %v", err)
-// 		}
-// 	}
-
-// 	// Calculate Thrylos balance
-// 	balanceThrylos := float64(balance) / 1e7
-
-// 	return &thrylos.BalanceResponse{
-// 		Balance:           balance,
-// 		BalanceThrylos:    balanceThrylos,
-// 		BlockchainAddress: req.Address,
-// 	}, nil
-// }
+import (
+	"context"
+
+	"github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// server serves the BlockchainService from the opened chain and its store. The
+// submission, stats and streaming methods answer Unimplemented until they are restored.
+type server struct {
+	thrylos.UnimplementedBlockchainServiceServer
+	blockchain *chain.BlockchainImpl
+	store      types.Store
+}
+
+func NewServer(blockchain *chain.BlockchainImpl, store types.Store) *server {
+	return &server{
+		blockchain: blockchain,
+		store:      store,
+	}
+}
+
+func (s *server) GetBalance(ctx context.Context, req *thrylos.GetBalanceRequest) (*thrylos.BalanceResponse, error) {
+	if req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "Address is required")
+	}
+
+	resp, err := s.blockchain.BalanceResponse(req.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get balance: %v", err)
+	}
+	return resp, nil
+}
+
+func (s *server) GetLastBlock(ctx context.Context, req *thrylos.EmptyRequest) (*thrylos.BlockResponse, error) {
+	block, _, err := s.blockchain.GetLastBlock()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get the last block: %v", err)
+	}
+	if block == nil {
+		return nil, status.Error(codes.NotFound, "The chain has no blocks")
+	}
+	return blockResponse(block)
+}
+
+func (s *server) GetBlock(ctx context.Context, req *thrylos.GetBlockRequest) (*thrylos.BlockResponse, error) {
+	return s.blockAt(req.Id)
+}
+
+func (s *server) GetBlockByIndex(ctx context.Context, req *thrylos.GetBlockByIndexRequest) (*thrylos.BlockResponse, error) {
+	return s.blockAt(req.Index)
+}
+
+func (s *server) GetBlockByHash(ctx context.Context, req *thrylos.GetBlockByHashRequest) (*thrylos.BlockResponse, error) {
+	blockHash, err := hash.FromBytes(req.Hash)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid block hash: %v", err)
+	}
+	block, err := s.store.GetBlockByHash(blockHash)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Block %x not found: %v", req.Hash, err)
+	}
+	return blockResponse(block)
+}
+
+func (s *server) blockAt(index int32) (*thrylos.BlockResponse, error) {
+	if index < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Block index cannot be negative")
+	}
+	block, err := s.store.GetBlock(uint32(index))
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Block %d not found: %v", index, err)
+	}
+	return blockResponse(block)
+}
+
+// GetTransactionsByMemo returns a page of the confirmed transactions carrying exactly the requested memo
+func (s *server) GetTransactionsByMemo(ctx context.Context, req *thrylos.GetTransactionsByMemoRequest) (*thrylos.TransactionsResponse, error) {
+	if len(req.Memo) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Memo is required")
+	}
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Offset cannot be negative")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 100
+	}
+
+	txs, err := chain.GetTransactionsByMemo(s.store, req.Memo, int(req.Offset), limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to look up transactions by memo: %v", err)
+	}
+
+	resp := &thrylos.TransactionsResponse{}
+	for _, tx := range txs {
+		protoTx, err := chain.ConvertToThrylosTransaction(tx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to convert transaction %s: %v", tx.ID, err)
+		}
+		resp.Transactions = append(resp.Transactions, protoTx)
+	}
+	return resp, nil
+}
+
+func blockResponse(block *types.Block) (*thrylos.BlockResponse, error) {
+	protoBlock := &thrylos.Block{
+		Index:     int32(block.Index),
+		Timestamp: block.Timestamp,
+		PrevHash:  block.PrevHash.Bytes(),
+		Validator: block.Validator,
+		Hash:      block.Hash.Bytes(),
+		Salt:      block.Salt,
+	}
+	if block.Signature != nil {
+		protoBlock.Signature = block.Signature.Bytes()
+	}
+	for _, tx := range block.Transactions {
+		protoTx, err := chain.ConvertToThrylosTransaction(tx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to convert transaction %s: %v", tx.ID, err)
+		}
+		protoBlock.Transactions = append(protoBlock.Transactions, protoTx)
+	}
+	return &thrylos.BlockResponse{Block: protoBlock}, nil
+}
 
 // // The streaming version also needs to be updated
 // func (s *server) StreamBalance(req *thrylos.GetBalanceRequest, stream thrylos.BlockchainService_StreamBalanceServer) error {
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/encryption"
+	"github.com/thrylos-labs/thrylos/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// staleMemoStore answers memo lookups with an index entry whose transaction is no
+// longer at the recorded position.
+type staleMemoStore struct {
+	types.Store
+}
+
+func (s staleMemoStore) GetTransactionsByMemo(memo []byte, offset, limit int) ([]*types.TransactionLocation, error) {
+	return []*types.TransactionLocation{{TxID: "gone", BlockHeight: 0, Position: 0}}, nil
+}
+
+func newTestBlockchain(t *testing.T) (*chain.BlockchainImpl, types.Store, crypto.PrivateKey) {
+	priv, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	aesKey, err := encryption.GenerateAESKey()
+	require.NoError(t, err)
+
+	bc, blockchainStore, err := chain.NewBlockchain(&types.BlockchainConfig{
+		InMemory:          true,
+		AESKey:            aesKey,
+		GenesisAccount:    priv,
+		TestMode:          true,
+		DisableBackground: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { blockchainStore.(interface{ Close() error }).Close() })
+	return bc, blockchainStore, priv
+}
+
+// newTestClient serves srv over an in-memory connection and returns a client for it.
+func newTestClient(t *testing.T, srv thrylos.BlockchainServiceServer) thrylos.BlockchainServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	thrylos.RegisterBlockchainServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return thrylos.NewBlockchainServiceClient(conn)
+}
+
+// mineMemoTransfers confirms count transfers carrying memo, one per block, each
+// spending the change of the one before.
+func mineMemoTransfers(t *testing.T, bc *chain.BlockchainImpl, owner crypto.PrivateKey, memo []byte, count int) []string {
+	validatorKey, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	validatorAddr, err := validatorKey.PublicKey().Address()
+	require.NoError(t, err)
+	require.NoError(t, bc.Blockchain.Database.SavePublicKey(validatorKey.PublicKey()))
+	if bc.Blockchain.BondedStake == nil {
+		bc.Blockchain.BondedStake = make(map[string]amount.Amount)
+	}
+	bc.Blockchain.BondedStake[validatorAddr.String()] = 100
+	bc.Blockchain.ActiveValidators = append(bc.Blockchain.ActiveValidators, validatorAddr.String())
+
+	ownerAddr, err := owner.PublicKey().Address()
+	require.NoError(t, err)
+	parent := bc.Blockchain.Genesis
+	genesisTx := parent.Transactions[0]
+	input := types.UTXO{TransactionID: genesisTx.ID, OwnerAddress: ownerAddr.String(), Amount: genesisTx.Outputs[0].Amount}
+	var ids []string
+	for i := 0; i < count; i++ {
+		id := fmt.Sprintf("memo-%d", i)
+		salt := sha256.Sum256([]byte(id))
+		tx := &types.Transaction{
+			ID:              id,
+			Inputs:          []types.UTXO{input},
+			Outputs:         []types.UTXO{{OwnerAddress: ownerAddr.String(), Amount: input.Amount - amount.Amount(100_000)}},
+			GasFee:          100_000,
+			Salt:            salt[:],
+			Memo:            memo,
+			SenderAddress:   *ownerAddr,
+			SenderPublicKey: owner.PublicKey(),
+		}
+		require.NoError(t, tx.Sign(owner, bc.GetChainID()))
+
+		block := &types.Block{
+			Index:        parent.Index + 1,
+			Timestamp:    parent.Timestamp + 1,
+			PrevHash:     parent.Hash,
+			Transactions: []*types.Transaction{tx},
+			Validator:    validatorAddr.String(),
+		}
+		require.NoError(t, chain.InitializeVerkleTree(block))
+		chain.SignBlock(block, validatorKey)
+		require.NoError(t, bc.ProcessBlock(block))
+
+		parent = block
+		input = types.UTXO{TransactionID: tx.ID, OwnerAddress: ownerAddr.String(), Amount: tx.Outputs[0].Amount}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestGetTransactionsByMemoOverGRPC(t *testing.T) {
+	bc, blockchainStore, genesisKey := newTestBlockchain(t)
+	memo := []byte("invoice 42")
+	ids := mineMemoTransfers(t, bc, genesisKey, memo, 3)
+	client := newTestClient(t, NewServer(bc, blockchainStore))
+	ctx := context.Background()
+
+	memoIDs := func(offset, limit int32) []string {
+		resp, err := client.GetTransactionsByMemo(ctx, &thrylos.GetTransactionsByMemoRequest{Memo: memo, Offset: offset, Limit: limit})
+		require.NoError(t, err)
+		var got []string
+		for _, tx := range resp.Transactions {
+			require.Equal(t, memo, tx.Memo)
+			got = append(got, tx.Id)
+		}
+		return got
+	}
+
+	// Pages follow chain order, and a missing limit returns everything
+	require.Equal(t, ids, memoIDs(0, 0))
+	require.Equal(t, ids[:2], memoIDs(0, 2))
+	require.Equal(t, ids[2:], memoIDs(2, 2))
+	require.Empty(t, memoIDs(3, 2))
+
+	resp, err := client.GetTransactionsByMemo(ctx, &thrylos.GetTransactionsByMemoRequest{Memo: []byte("other memo")})
+	require.NoError(t, err)
+	require.Empty(t, resp.Transactions)
+
+	// Malformed requests are refused
+	_, err = client.GetTransactionsByMemo(ctx, &thrylos.GetTransactionsByMemoRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.GetTransactionsByMemo(ctx, &thrylos.GetTransactionsByMemoRequest{Memo: memo, Offset: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// An index entry that no longer matches its block is reported, not skipped
+	staleClient := newTestClient(t, NewServer(bc, staleMemoStore{blockchainStore}))
+	_, err = staleClient.GetTransactionsByMemo(ctx, &thrylos.GetTransactionsByMemoRequest{Memo: memo})
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "stale")
+}
+
+func TestBlockchainServiceServesBlocksAndBalances(t *testing.T) {
+	bc, blockchainStore, genesisKey := newTestBlockchain(t)
+	ids := mineMemoTransfers(t, bc, genesisKey, []byte("memo"), 2)
+	client := newTestClient(t, NewServer(bc, blockchainStore))
+	ctx := context.Background()
+
+	last, err := client.GetLastBlock(ctx, &thrylos.EmptyRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), last.Block.Index)
+	require.Equal(t, ids[1], last.Block.Transactions[0].Id)
+
+	byIndex, err := client.GetBlockByIndex(ctx, &thrylos.GetBlockByIndexRequest{Index: 1})
+	require.NoError(t, err)
+	require.Equal(t, ids[0], byIndex.Block.Transactions[0].Id)
+
+	byHash, err := client.GetBlockByHash(ctx, &thrylos.GetBlockByHashRequest{Hash: byIndex.Block.Hash})
+	require.NoError(t, err)
+	require.Equal(t, byIndex.Block.Index, byHash.Block.Index)
+
+	_, err = client.GetBlock(ctx, &thrylos.GetBlockRequest{Id: 10})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	genesisAddr, err := genesisKey.PublicKey().Address()
+	require.NoError(t, err)
+	balance, err := client.GetBalance(ctx, &thrylos.GetBalanceRequest{Address: genesisAddr.String()})
+	require.NoError(t, err)
+	require.Equal(t, int64(bc.Blockchain.Genesis.Transactions[0].Outputs[0].Amount)-200_000, balance.SpendableBalance)
+
+	// Methods without a backing chain API are still refused explicitly
+	_, err = client.GetStats(ctx, &thrylos.GetStatsRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
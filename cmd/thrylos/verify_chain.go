@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/store"
+)
+
+// runVerifyChain re-checks the persisted chain in dataDir, with transaction signatures
+// bound to chainID, and prints a JSON report on stdout. It returns the process exit code: 0 when the chain is consistent, 1 when an
+// inconsistent block was found and 2 when the chain could not be read.
+func runVerifyChain(dataDir string, aesKey []byte, chainID string) int {
+	database, err := store.NewDatabase(dataDir)
+	if err != nil {
+		log.Printf("Failed to open the blockchain database at %s: %v", dataDir, err)
+		return 2
+	}
+	defer database.Close()
+
+	storeInstance, err := store.NewStore(database, aesKey)
+	if err != nil {
+		log.Printf("Failed to create store: %v", err)
+		return 2
+	}
+
+	report, err := chain.VerifyChain(storeInstance, chainID)
+	if err != nil {
+		log.Printf("Chain verification could not run: %v", err)
+		return 2
+	}
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Printf("Failed to encode verification report: %v", err)
+		return 2
+	}
+	fmt.Println(string(out))
+
+	if !report.Valid {
+		return 1
+	}
+	return 0
+}
@@ -7,14 +7,13 @@ import (
 )
 
 type Config struct {
-	NanoPerThrylos                 float64 `toml:"nano_per_thrylos"`
 	InitialTotalSupply             float64 `toml:"initial_total_supply"`
-	AnnualStakeReward              float64 `toml:"annual_stake_reward"`
-	DailyStakeReward               float64 `toml:"daily_stake_reward"`
-	MinimumStakeAmount             float64 `toml:"minimum_stake_amount"`
+	AnnualStakeReward              int64   `toml:"annual_stake_reward"`  // nanoTHRYLOS
+	DailyStakeReward               int64   `toml:"daily_stake_reward"`   // nanoTHRYLOS
+	MinimumStakeAmount             int64   `toml:"minimum_stake_amount"` // nanoTHRYLOS
 	MinStakePercentage             float64 `toml:"min_stake_percentage"`
 	RewardDistributionTimeInterval int     `toml:"reward_distribution_time_interval"`
-	DelegationRewardPercent        float64 `toml:"delegation_reward_percent"`
+	DelegationRewardPercent        int64   `toml:"delegation_reward_percent"`
 }
 
 func LoadConfigFromFile(filePath string) (*Config, error) {
@@ -27,7 +26,6 @@ func LoadConfigFromFile(filePath string) (*Config, error) {
 
 func GenerateDefaultConfig() *Config {
 	return &Config{
-		NanoPerThrylos:                 NanoPerThrylos,
 		InitialTotalSupply:             InitialTotalSupply,
 		AnnualStakeReward:              AnnualStakeReward,
 		DailyStakeReward:               DailyStakeReward,
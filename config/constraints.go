@@ -1,13 +1,15 @@
 package config
 
+import "github.com/thrylos-labs/thrylos/amount"
+
 const (
 	// Token Related
-	NanoPerThrylos     = 1e7
-	InitialTotalSupply = 120_000_000 // 120 million tokens
+	NanoPerThrylos     = amount.NanoTHRYLOS // Base units in one THRYLOS
+	InitialTotalSupply = 120_000_000        // 120 million tokens
 
 	// Staking Related
 	AnnualStakeReward  = 4_800_000 * NanoPerThrylos
-	DailyStakeReward   = AnnualStakeReward / 365
+	DailyStakeReward   = int64(AnnualStakeReward) / 365 // Rounded down to a whole nanoTHRYLOS
 	MinimumStakeAmount = 40 * NanoPerThrylos
 	MinStakePercentage = 0.1 // 0.1% of total supply
 
@@ -15,5 +17,28 @@ const (
 	RewardDistributionTimeInterval = 24 * 60 * 60 // one day in seconds
 
 	// Delegation Related
-	DelegationRewardPercent = 0.5 // 50%
+	DelegationRewardPercent = 50 // Percent of a delegator's reward paid to the delegator, the rest goes to validators
+
+	// Block Related
+	MedianTimeBlocks         = 11              // Blocks whose median timestamp a new block must exceed
+	MaxBlockTimeDriftSeconds = 2 * 60          // Default limit on block timestamps ahead of the local clock
+	MaxBlockSize             = 4 * 1024 * 1024 // Maximum serialized block size in bytes
+	MaxBlockTransactions     = 10_000          // Maximum number of transactions in a block
+
+	// Transaction Related
+	MaxMemoSize = 256 // Maximum size in bytes of the memo a transaction may carry
+
+	// Fee Related
+	MinRelayFeePerByte = 1  // Minimum fee in nanoTHRYLOS per byte of CBOR encoded transaction
+	FeeEstimateBlocks  = 20 // Recent blocks whose fees feed the fee estimator
+
+	// Mempool Related
+	MempoolMaxBytes        = 32 * 1024 * 1024 // Maximum total CBOR size of the transactions in the pool
+	MempoolMaxPerSender    = 64               // Maximum pending transactions from a single sender address
+	MempoolTransactionTTL  = 24 * 60 * 60     // Seconds a transaction may wait in the pool before it is dropped
+	MempoolReplacementBump = 10               // Percent a replacement must pay above the fees of the transactions it replaces
+
+	// Signature Verification Related
+	SignatureVerifyWorkers     = 0       // Concurrent signature verifications, one per CPU when 0
+	VerifiedSignatureCacheSize = 100_000 // Verified transaction signatures remembered between pool admission and block application
 )
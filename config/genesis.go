@@ -0,0 +1,102 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Genesis describes block 0 of a network. Every node of the network loads the
+// same genesis file so they all derive the same genesis block and hash.
+type Genesis struct {
+	ChainID     string              `toml:"chain_id" json:"chain_id"`
+	Timestamp   int64               `toml:"timestamp" json:"timestamp"` // Unix seconds
+	Allocations []GenesisAllocation `toml:"allocations" json:"allocations"`
+	Validators  []GenesisValidator  `toml:"validators" json:"validators"`
+	Params      ProtocolParams      `toml:"params" json:"params"`
+}
+
+// GenesisAllocation assigns an initial UTXO to an address.
+type GenesisAllocation struct {
+	Address string `toml:"address" json:"address"`
+	Amount  int64  `toml:"amount" json:"amount"` // nanoTHRYLOS
+}
+
+// GenesisValidator is a member of the initial validator set.
+type GenesisValidator struct {
+	Address   string `toml:"address" json:"address"`
+	PublicKey string `toml:"public_key" json:"public_key"` // base64 encoded ML-DSA-44 public key
+	Stake     int64  `toml:"stake" json:"stake"`           // nanoTHRYLOS
+}
+
+// ProtocolParams are the consensus parameters fixed at genesis.
+type ProtocolParams struct {
+	MinimumStakeAmount int64 `toml:"minimum_stake_amount" json:"minimum_stake_amount"`
+	BlockTimeSeconds   int64 `toml:"block_time_seconds" json:"block_time_seconds"`
+}
+
+// LoadGenesisFromFile reads a genesis file. Files ending in .json are decoded
+// as JSON, everything else as TOML.
+func LoadGenesisFromFile(filePath string) (*Genesis, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var genesis Genesis
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		if err := json.Unmarshal(data, &genesis); err != nil {
+			return nil, fmt.Errorf("failed to decode genesis file %s: %v", filePath, err)
+		}
+	} else {
+		if _, err := toml.Decode(string(data), &genesis); err != nil {
+			return nil, fmt.Errorf("failed to decode genesis file %s: %v", filePath, err)
+		}
+	}
+
+	if err := genesis.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid genesis file %s: %v", filePath, err)
+	}
+	return &genesis, nil
+}
+
+// Validate checks that the genesis document is complete.
+func (g *Genesis) Validate() error {
+	if g.ChainID == "" {
+		return errors.New("chain_id is required")
+	}
+	if g.Timestamp <= 0 {
+		return errors.New("timestamp must be a positive unix time")
+	}
+	if len(g.Allocations) == 0 {
+		return errors.New("at least one allocation is required")
+	}
+	for i, alloc := range g.Allocations {
+		if alloc.Address == "" {
+			return fmt.Errorf("allocation %d has no address", i)
+		}
+		if alloc.Amount <= 0 {
+			return fmt.Errorf("allocation %d for %s must have a positive amount", i, alloc.Address)
+		}
+	}
+	seen := make(map[string]bool, len(g.Validators))
+	for i, v := range g.Validators {
+		if v.Address == "" || v.PublicKey == "" {
+			return fmt.Errorf("validator %d must have an address and a public key", i)
+		}
+		if seen[v.Address] {
+			return fmt.Errorf("validator %s is listed more than once", v.Address)
+		}
+		seen[v.Address] = true
+		if v.Stake < g.Params.MinimumStakeAmount {
+			return fmt.Errorf("validator %s stake %d is below the minimum stake %d",
+				v.Address, v.Stake, g.Params.MinimumStakeAmount)
+		}
+	}
+	return nil
+}
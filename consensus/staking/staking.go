@@ -2,11 +2,10 @@ package staking
 
 import (
 	"errors"
-	"fmt"
 	"sync"
 	"time"
 
-	"github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/amount"
 	"github.com/thrylos-labs/thrylos/config"
 	"github.com/thrylos-labs/thrylos/types"
 )
@@ -39,40 +38,64 @@ func NewStakingService(blockchain *types.Blockchain) *StakingService {
 	}
 }
 
-// calculateStakeReward calculates daily reward for each validator and delegator
-func (s *StakingService) CalculateStakeReward(rewardDistributionTime int64) map[string]float64 {
+// calculateStakeReward calculates daily reward for each validator and delegator.
+// Stake-time averages only weigh the shares; they are truncated to whole nanoTHRYLOS
+// and the rewards are exact proportions of the daily reward, rounded down.
+func (s *StakingService) CalculateStakeReward(rewardDistributionTime int64) (map[string]amount.Amount, error) {
 	// Add validation for time parameters
 	if rewardDistributionTime <= s.pool.LastRewardTime {
-		return nil
+		return nil, nil
 	}
 
 	// finalise stake period before reward distribution
-	totalStakeTimeAverage := float64(0)
+	var totalStakeTimeAverage amount.Amount
+	var err error
 	for _, stake := range s.stakes {
 		if stake.LastStakeUpdateTime < rewardDistributionTime {
-			stakeTime := stake.Amount * (rewardDistributionTime - stake.LastStakeUpdateTime)
-			stake.StakeTimeSum += float64(stakeTime)
+			stake.StakeTimeSum += stakeWeight(stake.Amount, rewardDistributionTime-stake.LastStakeUpdateTime)
 			stake.LastStakeUpdateTime = rewardDistributionTime
 			stake.StakeTimeAverage = stake.StakeTimeSum / float64(rewardDistributionTime-s.pool.LastRewardTime)
-			totalStakeTimeAverage += stake.StakeTimeAverage
+			if totalStakeTimeAverage, err = totalStakeTimeAverage.Add(stakeTimeAverage(stake)); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	rewards := make(map[string]float64)
-	extraRewardsFromDelegation := float64(0)
-	validatorsTotalStakeTimeAverage := float64(0)
+	dailyReward := amount.Amount(config.DailyStakeReward)
+	rewards := make(map[string]amount.Amount)
+	extraRewardsFromDelegation := amount.Amount(0)
+	validatorsTotalStakeTimeAverage := amount.Amount(0)
 	//distribution of rewards to delegators and validators
 	if totalStakeTimeAverage > 0 {
 		for addr, stake := range s.stakes {
-			reward := (stake.StakeTimeAverage / float64(totalStakeTimeAverage)) * float64(config.DailyStakeReward)
+			reward, err := dailyReward.MulDiv(int64(stakeTimeAverage(stake)), int64(totalStakeTimeAverage))
+			if err != nil {
+				return nil, err
+			}
 			if stake.ValidatorRole {
 				rewards[addr] = reward
-				stake.TotalStakeRewards += reward
-				validatorsTotalStakeTimeAverage += stake.StakeTimeAverage
+				if stake.TotalStakeRewards, err = stake.TotalStakeRewards.Add(reward); err != nil {
+					return nil, err
+				}
+				if validatorsTotalStakeTimeAverage, err = validatorsTotalStakeTimeAverage.Add(stakeTimeAverage(stake)); err != nil {
+					return nil, err
+				}
 			} else {
-				rewards[addr] = reward * config.DelegationRewardPercent
-				extraRewardsFromDelegation += reward * (1 - config.DelegationRewardPercent)
-				stake.TotalDelegationRewards += reward * config.DelegationRewardPercent
+				delegatorReward, err := reward.MulDiv(config.DelegationRewardPercent, 100)
+				if err != nil {
+					return nil, err
+				}
+				rewards[addr] = delegatorReward
+				validatorShare, err := reward.Sub(delegatorReward)
+				if err != nil {
+					return nil, err
+				}
+				if extraRewardsFromDelegation, err = extraRewardsFromDelegation.Add(validatorShare); err != nil {
+					return nil, err
+				}
+				if stake.TotalDelegationRewards, err = stake.TotalDelegationRewards.Add(delegatorReward); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
@@ -81,59 +104,97 @@ func (s *StakingService) CalculateStakeReward(rewardDistributionTime int64) map[
 	if extraRewardsFromDelegation > 0 && validatorsTotalStakeTimeAverage > 0 {
 		for addr, stake := range s.stakes {
 			if stake.ValidatorRole {
-				reward := (stake.StakeTimeAverage / float64(validatorsTotalStakeTimeAverage)) * extraRewardsFromDelegation
-				rewards[addr] += reward
-				stake.TotalDelegationRewards += reward
+				reward, err := extraRewardsFromDelegation.MulDiv(int64(stakeTimeAverage(stake)), int64(validatorsTotalStakeTimeAverage))
+				if err != nil {
+					return nil, err
+				}
+				if rewards[addr], err = rewards[addr].Add(reward); err != nil {
+					return nil, err
+				}
+				if stake.TotalDelegationRewards, err = stake.TotalDelegationRewards.Add(reward); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
-	return rewards
+	return rewards, nil
 }
 
-func (s *StakingService) EstimateStakeReward(targetAddress string, currentTimeStamp int64) float64 {
-	totalStakeTimeAverage := float64(0)
-	addressStakeTimeAverage := float64(0)
-	validatorsStakeTimeAverage := float64(0)
-	delegatorsStakeTimeAverage := float64(0)
+// stakeWeight returns the stake-time weight of amount staked for duration seconds. It is
+// a float64 because the product of large stakes and long durations overflows int64.
+func stakeWeight(amount int64, duration int64) float64 {
+	return float64(amount) * float64(duration)
+}
+
+// stakeTimeAverage returns the average stake of stake over the reward period, truncated
+// to whole nanoTHRYLOS.
+func stakeTimeAverage(stake *types.Stake) amount.Amount {
+	return amount.Amount(stake.StakeTimeAverage)
+}
+
+// EstimateStakeReward estimates the reward targetAddress would receive if rewards were
+// distributed at currentTimeStamp.
+func (s *StakingService) EstimateStakeReward(targetAddress string, currentTimeStamp int64) (amount.Amount, error) {
+	var totalStakeTimeAverage, addressStakeTimeAverage amount.Amount
+	var validatorsStakeTimeAverage, delegatorsStakeTimeAverage amount.Amount
 	isDelegator := false
 
+	var err error
 	for addr, stake := range s.stakes {
 		if stake.LastStakeUpdateTime < currentTimeStamp {
-			stakeTime := stake.Amount * (currentTimeStamp - stake.LastStakeUpdateTime)
-			stakeTimeSum := stake.StakeTimeSum + float64(stakeTime)
-			totalStakeTimeAverage += stakeTimeSum / float64(currentTimeStamp-s.pool.LastRewardTime)
+			stakeTimeSum := stake.StakeTimeSum + stakeWeight(stake.Amount, currentTimeStamp-stake.LastStakeUpdateTime)
+			average := amount.Amount(stakeTimeSum / float64(currentTimeStamp-s.pool.LastRewardTime))
+			if totalStakeTimeAverage, err = totalStakeTimeAverage.Add(average); err != nil {
+				return 0, err
+			}
 
 			if addr == targetAddress {
-				addressStakeTimeAverage = stakeTimeSum / float64(currentTimeStamp-s.pool.LastRewardTime)
+				addressStakeTimeAverage = average
 				if !stake.ValidatorRole {
 					isDelegator = true
 				}
 			}
 			if stake.ValidatorRole {
-				validatorsStakeTimeAverage += stakeTimeSum / float64(currentTimeStamp-s.pool.LastRewardTime)
+				validatorsStakeTimeAverage, err = validatorsStakeTimeAverage.Add(average)
 			} else {
-				delegatorsStakeTimeAverage += stakeTimeSum / float64(currentTimeStamp-s.pool.LastRewardTime)
+				delegatorsStakeTimeAverage, err = delegatorsStakeTimeAverage.Add(average)
+			}
+			if err != nil {
+				return 0, err
 			}
 		}
 	}
 	if totalStakeTimeAverage == 0 {
-		return 0
+		return 0, nil
 	}
 
+	dailyReward := amount.Amount(config.DailyStakeReward)
+	reward, err := dailyReward.MulDiv(int64(addressStakeTimeAverage), int64(totalStakeTimeAverage))
+	if err != nil {
+		return 0, err
+	}
 	if isDelegator {
-		return (addressStakeTimeAverage / totalStakeTimeAverage) * float64(config.DailyStakeReward) * config.DelegationRewardPercent
+		return reward.MulDiv(config.DelegationRewardPercent, 100)
 	}
 
 	if delegatorsStakeTimeAverage == 0 {
-		return (addressStakeTimeAverage / totalStakeTimeAverage) * float64(config.DailyStakeReward) * (1 - config.DelegationRewardPercent)
+		return reward.MulDiv(100-config.DelegationRewardPercent, 100)
 	}
 
-	extraDelegationReward := delegatorsStakeTimeAverage * float64(config.DailyStakeReward) * (1 - config.DelegationRewardPercent) / totalStakeTimeAverage
-
-	extraAddressReward := extraDelegationReward * addressStakeTimeAverage / validatorsStakeTimeAverage
+	delegationReward, err := dailyReward.MulDiv(int64(delegatorsStakeTimeAverage), int64(totalStakeTimeAverage))
+	if err != nil {
+		return 0, err
+	}
+	extraDelegationReward, err := delegationReward.MulDiv(100-config.DelegationRewardPercent, 100)
+	if err != nil {
+		return 0, err
+	}
 
-	reward := (addressStakeTimeAverage / totalStakeTimeAverage) * float64(config.DailyStakeReward)
-	return extraAddressReward + reward
+	extraAddressReward, err := extraDelegationReward.MulDiv(int64(addressStakeTimeAverage), int64(validatorsStakeTimeAverage))
+	if err != nil {
+		return 0, err
+	}
+	return extraAddressReward.Add(reward)
 }
 
 // Add this method to your StakingService struct
@@ -150,7 +211,7 @@ func (s *StakingService) GetPoolStats() map[string]interface{} {
 
 	return map[string]interface{}{
 		"totalStaked": map[string]interface{}{
-			"thrylos": float64(s.pool.TotalStaked) / 1e7,
+			"thrylos": amount.Amount(s.pool.TotalStaked).ToTHRYLOS(),
 			"nano":    s.pool.TotalStaked,
 		},
 		"delegatorCount": len(s.stakes),
@@ -159,13 +220,13 @@ func (s *StakingService) GetPoolStats() map[string]interface{} {
 			"timeUntilReward": timeUntilReward,
 			"lastRewardTime":  lastRewardTime, // Using LastRewardTime consistently
 			"rewardInterval":  "24h",
-			"dailyRewardPool": config.DailyStakeReward / 1e7,
+			"dailyRewardPool": amount.Amount(config.DailyStakeReward).ToTHRYLOS(),
 			"validatorShare":  "50%",
 			"delegatorShare":  "50%",
 		},
 		"validatorInfo": map[string]interface{}{
 			"activeCount":    len(s.blockchain.ActiveValidators),
-			"minStakeAmount": float64(s.pool.MinStakeAmount) / 1e7,
+			"minStakeAmount": amount.Amount(s.pool.MinStakeAmount).ToTHRYLOS(),
 		},
 	}
 }
@@ -181,53 +242,11 @@ func (s *StakingService) IsValidator(address string) bool {
 	return false
 }
 
-func (s *StakingService) CreateStake(userAddress string, amount int64) (*types.Stake, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Automatically determine if address is a validator
-	isValidator := s.IsValidator(userAddress)
-	isDelegator := !isValidator // isDelegator is opposite of isValidator
-
-	// Use appropriate minimum based on status
-	minRequired := s.pool.MinStakeAmount
-	if isDelegator {
-		minRequired = s.pool.MinDelegation
-	}
-
-	if amount < minRequired {
-		return nil, fmt.Errorf("minimum amount required is %d THRYLOS", minRequired/1e7)
-	}
-
-	// Create transaction based on type
-	txType := "stake"
-	if isDelegator {
-		txType = "delegate"
-	}
-	txID := fmt.Sprintf("%s-%s-%d", txType, userAddress, time.Now().UnixNano())
-
-	stakingTx := &thrylos.Transaction{
-		Id:        txID,
-		Sender:    userAddress,
-		Timestamp: time.Now().Unix(),
-		Outputs: []*thrylos.UTXO{{
-			OwnerAddress:  "staking_pool",
-			Amount:        amount,
-			Index:         0,
-			TransactionId: "",
-		}},
-	}
-
-	// Create the stake transaction
-	// if err := s.blockchain.AddPendingTransaction(stakingTx); err != nil {
-	// 	return nil, fmt.Errorf("failed to create staking transaction: %v", err)
-	// }
-
-	return s.createStakeInternal(userAddress, isDelegator, amount, stakingTx.Timestamp)
-}
-
 // Keep internal function for testing
-func (s *StakingService) createStakeInternal(userAddress string, isDelegator bool, amount int64, timestamp int64) (*types.Stake, error) {
+func (s *StakingService) createStakeInternal(userAddress string, isDelegator bool, stakeAmount int64, timestamp int64) (*types.Stake, error) {
+	if stakeAmount <= 0 {
+		return nil, errors.New("stake amount must be positive")
+	}
 	now := timestamp
 
 	// Initialize stake if it doesn't exist
@@ -248,21 +267,30 @@ func (s *StakingService) createStakeInternal(userAddress string, isDelegator boo
 	stake := s.stakes[userAddress]
 	duration := now - stake.LastStakeUpdateTime
 	totalDuration := now - s.pool.LastRewardTime
-	stakeTime := stake.Amount * duration
+	stakeTime := stakeWeight(stake.Amount, duration)
 
-	stake.Amount += amount
-	stake.StakeTimeSum += float64(stakeTime)
+	newAmount, err := amount.Amount(stake.Amount).Add(amount.Amount(stakeAmount))
+	if err != nil {
+		return nil, err
+	}
+	poolTotal := &s.pool.TotalStaked
+	if isDelegator {
+		poolTotal = &s.pool.TotalDelegated
+	}
+	newPoolTotal, err := amount.Amount(*poolTotal).Add(amount.Amount(stakeAmount))
+	if err != nil {
+		return nil, err
+	}
+
+	stake.Amount = int64(newAmount)
+	stake.StakeTimeSum += stakeTime
 	if totalDuration > 0 {
 		stake.StakeTimeAverage = stake.StakeTimeSum / float64(totalDuration)
 	}
 	stake.LastStakeUpdateTime = now
 
 	// Update pool totals
-	if isDelegator {
-		s.pool.TotalDelegated += amount
-	} else {
-		s.pool.TotalStaked += amount
-	}
+	*poolTotal = int64(newPoolTotal)
 
 	return stake, nil
 }
@@ -312,6 +340,9 @@ func (s *StakingService) unstakeTokensInternal(userAddress string, isDelegator b
 		return errors.New("no stake found for address")
 	}
 
+	if amount <= 0 {
+		return errors.New("unstake amount must be positive")
+	}
 	if stake.Amount < amount {
 		return errors.New("insufficient staked amount")
 	}
@@ -320,11 +351,11 @@ func (s *StakingService) unstakeTokensInternal(userAddress string, isDelegator b
 	now := timestamp
 	duration := now - stake.LastStakeUpdateTime
 	totalDuration := now - s.pool.LastRewardTime
-	stakeTime := stake.Amount * duration
+	stakeTime := stakeWeight(stake.Amount, duration)
 
 	// Update stake amount (removed oldAmount declaration)
 	stake.Amount -= amount
-	stake.StakeTimeSum += float64(stakeTime)
+	stake.StakeTimeSum += stakeTime
 	if totalDuration > 0 {
 		stake.StakeTimeAverage = stake.StakeTimeSum / float64(totalDuration)
 	}
@@ -374,8 +405,8 @@ func (s *StakingService) GetEffectiveInflationRate() float64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	currentTotalSupply := float64(s.getTotalSupply()) / 1e7
-	fixedYearlyReward := float64(s.pool.FixedYearlyReward) / 1e7
+	currentTotalSupply := float64(s.getTotalSupply()) / config.NanoPerThrylos
+	fixedYearlyReward := float64(s.pool.FixedYearlyReward) / config.NanoPerThrylos
 	return (fixedYearlyReward / currentTotalSupply) * 100
 }
 
@@ -2,7 +2,9 @@ package address
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"sort"
 
 	mldsa "github.com/cloudflare/circl/sign/mldsa/mldsa44"
 
@@ -14,6 +16,11 @@ import (
 const (
 	AddressSize   = 32
 	AddressPrefix = "tl1"
+
+	// MaxMultisigKeys bounds the number of keys behind a multisig address.
+	MaxMultisigKeys = 16
+	// multisigDomain separates multisig address hashes from single key ones.
+	multisigDomain = "THRYLOS_MULTISIG"
 )
 
 type Address [AddressSize]byte
@@ -31,6 +38,39 @@ func New(pubKey *mldsa.PublicKey) (*Address, error) {
 	return &address, nil
 }
 
+// NewMultisig derives the address controlled by any threshold of pubKeys. The keys
+// are sorted first, so every ordering of the same set gives the same address.
+func NewMultisig(threshold int, pubKeys []*mldsa.PublicKey) (*Address, error) {
+	if len(pubKeys) == 0 || len(pubKeys) > MaxMultisigKeys {
+		return nil, fmt.Errorf("multisig needs between 1 and %d keys, got %d", MaxMultisigKeys, len(pubKeys))
+	}
+	if threshold < 1 || threshold > len(pubKeys) {
+		return nil, fmt.Errorf("multisig threshold %d is out of range for %d keys", threshold, len(pubKeys))
+	}
+	keys := make([][]byte, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		keys[i] = pubKey.Bytes()
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+
+	data := append([]byte(multisigDomain), 0x00)
+	data = binary.BigEndian.AppendUint16(data, uint16(threshold))
+	for i, key := range keys {
+		if i > 0 && bytes.Equal(keys[i-1], key) {
+			return nil, fmt.Errorf("multisig keys must be distinct")
+		}
+		data = append(data, key...)
+	}
+	hashBytes := hash.NewHash(data)
+	words, err := bech32.ConvertBits(hashBytes[:20], 8, 5, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert multisig hash to 5-bit words: %v", err)
+	}
+	var address Address
+	copy(address[:], words)
+	return &address, nil
+}
+
 func NullAddress() *Address {
 	return &Address{}
 }
@@ -67,15 +107,9 @@ func FromString(addr string) (*Address, error) {
 		return nil, fmt.Errorf("failed to decode bech32 address: %v", err)
 	}
 
-	// Convert back to 8-bit bytes
-	converted, err := bech32.ConvertBits(decoded, 5, 8, false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert bits: %v", err)
-	}
-
-	// Create a new Address
+	// An Address holds the 5-bit words, as produced by New and encoded by String
 	var newAddr Address
-	copy(newAddr[:], converted)
+	copy(newAddr[:], decoded)
 
 	return &newAddr, nil
 }
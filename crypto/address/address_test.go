@@ -19,3 +19,15 @@ func TestNew(t *testing.T) {
 	address, _ := New(pk)
 	require.Equal(t, address.String(), actual)
 }
+
+func TestFromStringRoundTrip(t *testing.T) {
+	seed := rand.New(rand.NewSource(1234))
+	pk, _, err := mldsa.GenerateKey(seed)
+	require.NoError(t, err)
+	address, err := New(pk)
+	require.NoError(t, err)
+
+	parsed, err := FromString(address.String())
+	require.NoError(t, err)
+	require.Equal(t, *address, *parsed)
+}
@@ -0,0 +1,246 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Encrypted transaction data is carried in envelopes that start with a two byte
+// header, the format version and the algorithm:
+//
+//	key envelope:     version || algorithm || ML-KEM ciphertext || nonce || sealed content key
+//	payload envelope: version || algorithm || nonce || sealed payload
+//
+// A transaction's EncryptedAESKey holds the key envelope: a random AES-256 content key
+// sealed for the recipient under a key encapsulated with ML-KEM-768. EncryptedInputs
+// and EncryptedOutputs hold payload envelopes sealed with the content key. Everything
+// is sealed with AES-256-GCM. The additional data is the header, the ML-KEM ciphertext
+// of the key envelope and the role of the payload, so envelopes cannot be altered,
+// downgraded, swapped or moved under another encapsulation unnoticed.
+const (
+	EnvelopeVersion = 1
+
+	AlgorithmMLKEM768AES256GCM = 1 // ML-KEM-768 key encapsulation, AES-256-GCM encryption
+
+	envelopeHeaderSize = 2
+	contentKeySize     = 32
+	keyWrapDomain      = "THRYLOS_KEM_WRAP"
+	keyDeriveDomain    = "THRYLOS_KEM_KEY"
+)
+
+// Additional data binding each sealed part of an envelope to its role.
+const (
+	contentKeyLabel = "key"
+	inputsLabel     = "inputs"
+	outputsLabel    = "outputs"
+)
+
+var (
+	// ErrUnsupportedEnvelope is returned for envelopes of an unknown version or algorithm.
+	ErrUnsupportedEnvelope = errors.New("unsupported encryption envelope")
+	// ErrDecryptionFailed is returned when an envelope is truncated, was sealed for
+	// another key or was modified.
+	ErrDecryptionFailed = errors.New("decryption failed")
+)
+
+// GenerateEncryptionKeyPair generates an ML-KEM-768 key pair to receive encrypted
+// transaction data with.
+func GenerateEncryptionKeyPair() (*mlkem768.PublicKey, *mlkem768.PrivateKey, error) {
+	return mlkem768.GenerateKeyPair(rand.Reader)
+}
+
+// DeriveEncryptionKeyPair derives an ML-KEM-768 key pair from secret key material of
+// at least 32 bytes, so a node can receive encrypted data under a key it already holds.
+func DeriveEncryptionKeyPair(secret []byte) (*mlkem768.PublicKey, *mlkem768.PrivateKey, error) {
+	if len(secret) < contentKeySize {
+		return nil, nil, fmt.Errorf("secret too short to derive a key pair: need %d bytes, got %d", contentKeySize, len(secret))
+	}
+	seed := blake2b.Sum512(append([]byte(keyDeriveDomain), secret...))
+	pubKey, privKey := mlkem768.NewKeyFromSeed(seed[:])
+	return pubKey, privKey, nil
+}
+
+// ParseEncryptionPublicKey decodes a packed ML-KEM-768 public key.
+func ParseEncryptionPublicKey(data []byte) (*mlkem768.PublicKey, error) {
+	if len(data) != mlkem768.PublicKeySize {
+		return nil, fmt.Errorf("invalid ML-KEM-768 public key size: expected %d bytes, got %d", mlkem768.PublicKeySize, len(data))
+	}
+	var pubKey mlkem768.PublicKey
+	if err := pubKey.Unpack(data); err != nil {
+		return nil, fmt.Errorf("invalid ML-KEM-768 public key: %v", err)
+	}
+	return &pubKey, nil
+}
+
+// SealTransactionData encrypts the inputs and outputs of a transaction for recipient
+// under a fresh content key. It returns the values of the transaction's
+// EncryptedAESKey, EncryptedInputs and EncryptedOutputs.
+func SealTransactionData(recipient *mlkem768.PublicKey, inputs, outputs []byte) (encryptedKey, encryptedInputs, encryptedOutputs []byte, err error) {
+	contentKey, err := GenerateAESKey()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if encryptedKey, err = WrapContentKey(contentKey, recipient); err != nil {
+		return nil, nil, nil, err
+	}
+	kemCiphertext := encryptedKey[envelopeHeaderSize : envelopeHeaderSize+mlkem768.CiphertextSize]
+	if encryptedInputs, err = seal(contentKey, kemCiphertext, inputs, inputsLabel); err != nil {
+		return nil, nil, nil, err
+	}
+	if encryptedOutputs, err = seal(contentKey, kemCiphertext, outputs, outputsLabel); err != nil {
+		return nil, nil, nil, err
+	}
+	return encryptedKey, encryptedInputs, encryptedOutputs, nil
+}
+
+// OpenTransactionData decrypts the EncryptedInputs and EncryptedOutputs of a
+// transaction with the recipient's key, recovering the content key from its
+// EncryptedAESKey.
+func OpenTransactionData(recipient *mlkem768.PrivateKey, encryptedKey, encryptedInputs, encryptedOutputs []byte) (inputs, outputs []byte, err error) {
+	contentKey, err := UnwrapContentKey(encryptedKey, recipient)
+	if err != nil {
+		return nil, nil, err
+	}
+	kemCiphertext := encryptedKey[envelopeHeaderSize : envelopeHeaderSize+mlkem768.CiphertextSize]
+	if inputs, err = open(contentKey, kemCiphertext, encryptedInputs, inputsLabel); err != nil {
+		return nil, nil, fmt.Errorf("encrypted inputs: %w", err)
+	}
+	if outputs, err = open(contentKey, kemCiphertext, encryptedOutputs, outputsLabel); err != nil {
+		return nil, nil, fmt.Errorf("encrypted outputs: %w", err)
+	}
+	return inputs, outputs, nil
+}
+
+// WrapContentKey seals a content key for recipient into a key envelope.
+func WrapContentKey(contentKey []byte, recipient *mlkem768.PublicKey) ([]byte, error) {
+	if len(contentKey) != contentKeySize {
+		return nil, fmt.Errorf("invalid content key size: expected %d bytes, got %d", contentKeySize, len(contentKey))
+	}
+	if recipient == nil {
+		return nil, errors.New("no recipient key to encrypt for")
+	}
+
+	envelope := make([]byte, envelopeHeaderSize+mlkem768.CiphertextSize)
+	envelope[0], envelope[1] = EnvelopeVersion, AlgorithmMLKEM768AES256GCM
+	sharedKey := make([]byte, mlkem768.SharedKeySize)
+	recipient.EncapsulateTo(envelope[envelopeHeaderSize:], sharedKey, nil)
+
+	aead, err := newAEAD(keyWrapKey(sharedKey))
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	aad := additionalData(envelope[:envelopeHeaderSize], envelope[envelopeHeaderSize:], contentKeyLabel)
+	envelope = append(envelope, nonce...)
+	return aead.Seal(envelope, nonce, contentKey, aad), nil
+}
+
+// UnwrapContentKey recovers the content key from a key envelope.
+func UnwrapContentKey(envelope []byte, recipient *mlkem768.PrivateKey) ([]byte, error) {
+	if err := checkHeader(envelope); err != nil {
+		return nil, err
+	}
+	if recipient == nil {
+		return nil, errors.New("no recipient key to decrypt with")
+	}
+	body := envelope[envelopeHeaderSize:]
+	if len(body) < mlkem768.CiphertextSize {
+		return nil, fmt.Errorf("%w: key envelope is truncated", ErrDecryptionFailed)
+	}
+
+	// Decapsulation never fails; a ciphertext for another key yields a key that does
+	// not open the sealed content key
+	sharedKey := make([]byte, mlkem768.SharedKeySize)
+	recipient.DecapsulateTo(sharedKey, body[:mlkem768.CiphertextSize])
+
+	contentKey, err := openSealed(keyWrapKey(sharedKey), envelope[:envelopeHeaderSize], body[:mlkem768.CiphertextSize], body[mlkem768.CiphertextSize:], contentKeyLabel)
+	if err != nil {
+		return nil, err
+	}
+	if len(contentKey) != contentKeySize {
+		return nil, fmt.Errorf("%w: invalid content key size %d", ErrDecryptionFailed, len(contentKey))
+	}
+	return contentKey, nil
+}
+
+// keyWrapKey derives the AES-256 key that seals the content key from the ML-KEM
+// shared key.
+func keyWrapKey(sharedKey []byte) []byte {
+	key := blake2b.Sum256(append([]byte(keyWrapDomain), sharedKey...))
+	return key[:]
+}
+
+// seal encrypts data with the content key into a payload envelope bound to the ML-KEM
+// ciphertext of its key envelope.
+func seal(contentKey, kemCiphertext, data []byte, label string) ([]byte, error) {
+	aead, err := newAEAD(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	envelope := make([]byte, envelopeHeaderSize+aead.NonceSize(), envelopeHeaderSize+aead.NonceSize()+len(data)+aead.Overhead())
+	envelope[0], envelope[1] = EnvelopeVersion, AlgorithmMLKEM768AES256GCM
+	nonce := envelope[envelopeHeaderSize:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(envelope, nonce, data, additionalData(envelope[:envelopeHeaderSize], kemCiphertext, label)), nil
+}
+
+// open decrypts a payload envelope with the content key.
+func open(contentKey, kemCiphertext, envelope []byte, label string) ([]byte, error) {
+	if err := checkHeader(envelope); err != nil {
+		return nil, err
+	}
+	return openSealed(contentKey, envelope[:envelopeHeaderSize], kemCiphertext, envelope[envelopeHeaderSize:], label)
+}
+
+// openSealed decrypts nonce || ciphertext sealed under key for the given header, ML-KEM
+// ciphertext and label.
+func openSealed(key, header, kemCiphertext, sealed []byte, label string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("%w: envelope is truncated", ErrDecryptionFailed)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	data, err := aead.Open(nil, nonce, ciphertext, additionalData(header, kemCiphertext, label))
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return data, nil
+}
+
+func checkHeader(envelope []byte) error {
+	if len(envelope) < envelopeHeaderSize {
+		return fmt.Errorf("%w: envelope is truncated", ErrDecryptionFailed)
+	}
+	if envelope[0] != EnvelopeVersion || envelope[1] != AlgorithmMLKEM768AES256GCM {
+		return fmt.Errorf("%w: version %d, algorithm %d", ErrUnsupportedEnvelope, envelope[0], envelope[1])
+	}
+	return nil
+}
+
+func additionalData(header, kemCiphertext []byte, label string) []byte {
+	aad := append(append([]byte(nil), header...), kemCiphertext...)
+	return append(aad, label...)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
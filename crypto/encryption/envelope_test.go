@@ -0,0 +1,107 @@
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSealTransactionDataRoundTrip(t *testing.T) {
+	pubKey, privKey, err := GenerateEncryptionKeyPair()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	packed, err := pubKey.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed to pack public key: %v", err)
+	}
+	recipient, err := ParseEncryptionPublicKey(packed)
+	if err != nil {
+		t.Fatalf("Failed to parse public key: %v", err)
+	}
+
+	inputs, outputs := []byte("encrypted inputs"), []byte("encrypted outputs")
+	key, sealedInputs, sealedOutputs, err := SealTransactionData(recipient, inputs, outputs)
+	if err != nil {
+		t.Fatalf("Failed to seal transaction data: %v", err)
+	}
+	if key[0] != EnvelopeVersion || key[1] != AlgorithmMLKEM768AES256GCM {
+		t.Fatalf("Unexpected envelope header %x", key[:2])
+	}
+
+	gotInputs, gotOutputs, err := OpenTransactionData(privKey, key, sealedInputs, sealedOutputs)
+	if err != nil {
+		t.Fatalf("Failed to open transaction data: %v", err)
+	}
+	if !bytes.Equal(gotInputs, inputs) || !bytes.Equal(gotOutputs, outputs) {
+		t.Fatalf("Decrypted data does not match: %q, %q", gotInputs, gotOutputs)
+	}
+
+	// Swapped payloads are refused
+	if _, _, err := OpenTransactionData(privKey, key, sealedOutputs, sealedInputs); !errors.Is(err, ErrDecryptionFailed) {
+		t.Fatalf("Expected swapped payloads to fail, got %v", err)
+	}
+
+	// So is a modified payload
+	tampered := append([]byte(nil), sealedInputs...)
+	tampered[len(tampered)-1] ^= 1
+	if _, _, err := OpenTransactionData(privKey, key, tampered, sealedOutputs); !errors.Is(err, ErrDecryptionFailed) {
+		t.Fatalf("Expected a modified payload to fail, got %v", err)
+	}
+
+	// And a different recipient key
+	_, otherKey, err := GenerateEncryptionKeyPair()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	if _, _, err := OpenTransactionData(otherKey, key, sealedInputs, sealedOutputs); !errors.Is(err, ErrDecryptionFailed) {
+		t.Fatalf("Expected another recipient to fail, got %v", err)
+	}
+
+	// Unknown versions are rejected rather than guessed at
+	unknown := append([]byte(nil), key...)
+	unknown[0] = EnvelopeVersion + 1
+	if _, err := UnwrapContentKey(unknown, privKey); !errors.Is(err, ErrUnsupportedEnvelope) {
+		t.Fatalf("Expected an unsupported envelope error, got %v", err)
+	}
+}
+
+func TestPayloadsAreBoundToTheirEncapsulation(t *testing.T) {
+	pubKey, privKey, err := DeriveEncryptionKeyPair(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatalf("Failed to derive key pair: %v", err)
+	}
+	samePub, _, err := DeriveEncryptionKeyPair(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatalf("Failed to derive key pair: %v", err)
+	}
+	if !pubKey.Equal(samePub) {
+		t.Fatalf("Deriving from the same secret gave different keys")
+	}
+	if _, _, err := DeriveEncryptionKeyPair([]byte("short")); err == nil {
+		t.Fatalf("Expected a short secret to be refused")
+	}
+
+	key, sealedInputs, sealedOutputs, err := SealTransactionData(pubKey, []byte("inputs"), []byte("outputs"))
+	if err != nil {
+		t.Fatalf("Failed to seal transaction data: %v", err)
+	}
+
+	// The recipient knows the content key, but cannot move the payloads under a new
+	// encapsulation of it
+	contentKey, err := UnwrapContentKey(key, privKey)
+	if err != nil {
+		t.Fatalf("Failed to unwrap content key: %v", err)
+	}
+	otherPub, otherKey, err := GenerateEncryptionKeyPair()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	rewrapped, err := WrapContentKey(contentKey, otherPub)
+	if err != nil {
+		t.Fatalf("Failed to wrap content key: %v", err)
+	}
+	if _, _, err := OpenTransactionData(otherKey, rewrapped, sealedInputs, sealedOutputs); !errors.Is(err, ErrDecryptionFailed) {
+		t.Fatalf("Expected payloads under another encapsulation to fail, got %v", err)
+	}
+}
@@ -16,6 +16,15 @@ type publicKey struct {
 func NewPublicKey(pubKey *mldsa44.PublicKey) PublicKey {
 	return &publicKey{pubKey: pubKey}
 }
+
+// NewPublicKeyFromBytes rebuilds a public key from its raw ML-DSA-44 encoding.
+func NewPublicKeyFromBytes(keyData []byte) (PublicKey, error) {
+	pub := new(mldsa44.PublicKey)
+	if err := pub.UnmarshalBinary(keyData); err != nil {
+		return nil, err
+	}
+	return &publicKey{pubKey: pub}, nil
+}
 func (p publicKey) Bytes() []byte {
 	return p.pubKey.Bytes()
 }
@@ -28,6 +37,19 @@ func (p publicKey) Address() (*address.Address, error) {
 	return address.New(p.pubKey)
 }
 
+// MultisigAddress returns the address controlled by any threshold of pubKeys.
+func MultisigAddress(threshold int, pubKeys []PublicKey) (*address.Address, error) {
+	keys := make([]*mldsa44.PublicKey, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		mldsaPubKey, ok := pubKey.(*publicKey)
+		if !ok {
+			return nil, errors.New("invalid public key type")
+		}
+		keys[i] = mldsaPubKey.pubKey
+	}
+	return address.NewMultisig(threshold, keys)
+}
+
 func (p publicKey) Verify(data []byte, sig *Signature) error {
 	if sig == nil {
 		return errors.New("signature cannot be nil")
@@ -50,6 +72,11 @@ func (p publicKey) Marshal() ([]byte, error) {
 	return cbor.Marshal(pub)
 }
 
+// MarshalCBOR lets the key be embedded in CBOR encoded structures as a byte string.
+func (p publicKey) MarshalCBOR() ([]byte, error) {
+	return p.Marshal()
+}
+
 func (p publicKey) Unmarshal(data []byte) error {
 	var d []byte
 	err := cbor.Unmarshal(data, d)
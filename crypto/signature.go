@@ -50,6 +50,11 @@ func (s signature) Marshal() ([]byte, error) {
 	return cbor.Marshal(s.sig)
 }
 
+// MarshalCBOR lets the signature be embedded in CBOR encoded structures as a byte string.
+func (s signature) MarshalCBOR() ([]byte, error) {
+	return s.Marshal()
+}
+
 func (s signature) Unmarshal(data []byte) error {
 	return cbor.Unmarshal(data, s.sig)
 }
@@ -1,12 +1,10 @@
 package debug
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
-	"sort"
 
-	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/thrylos-labs/thrylos/types"
 )
 
 // SignatureDebugger helps diagnose transaction signature issues
@@ -20,68 +18,36 @@ func NewSignatureDebugger() *SignatureDebugger {
 	}
 }
 
-func (d *SignatureDebugger) DebugSignature(tx map[string]interface{}, signature, publicKey []byte) error {
-	// Create sorted canonical form first
-	sortedPayload := d.sortMapToJSON(tx)
-
-	// Marshal the sorted payload
-	canonicalData, err := json.Marshal(sortedPayload)
+// DebugSignature logs the signing preimage of tx for chainID alongside its signature
+// and sender key, then verifies the signature against that preimage.
+func (d *SignatureDebugger) DebugSignature(tx *types.Transaction, chainID string) error {
+	message, err := tx.SigningBytes(chainID)
 	if err != nil {
-		return fmt.Errorf("canonical data creation failed: %v", err)
+		return fmt.Errorf("signing preimage creation failed: %v", err)
 	}
 
 	d.logger.Printf("=== Detailed Signature Debug ===")
-	d.logger.Printf("1. Original Payload: %+v", tx)
-	d.logger.Printf("2. Sorted Payload: %+v", sortedPayload)
-	d.logger.Printf("3. Canonical JSON: %s", string(canonicalData))
-	d.logger.Printf("4. Message bytes (hex): %x", canonicalData)
-	d.logger.Printf("5. Signature (hex): %x", signature)
-	d.logger.Printf("6. Public Key (hex): %x", publicKey)
-
-	pk := new(mldsa44.PublicKey)
-	if err := pk.UnmarshalBinary(publicKey); err != nil {
-		return fmt.Errorf("failed to unmarshal public key: %v", err)
+	d.logger.Printf("1. Transaction: %s (type %s)", tx.ID, tx.Type)
+	d.logger.Printf("2. Chain ID: %s", chainID)
+	d.logger.Printf("3. Signing domain: %s v%d", types.TransactionSigningDomain, types.TransactionSigningVersion)
+	d.logger.Printf("4. Message bytes (hex): %x", message)
+	if tx.Signature != nil {
+		d.logger.Printf("5. Signature (hex): %x", tx.Signature.Bytes())
+	}
+	if tx.SenderPublicKey == nil {
+		return fmt.Errorf("transaction has no sender public key")
 	}
+	d.logger.Printf("6. Public Key (hex): %x", tx.SenderPublicKey.Bytes())
 
-	// Verify signature
-	if !mldsa44.Verify(pk, canonicalData, signature, nil) {
+	if err := tx.VerifySignature(tx.SenderPublicKey, chainID); err != nil {
 		d.logger.Printf("❌ Signature Verification Failed")
-		return fmt.Errorf("signature verification failed")
+		return err
 	}
 
 	d.logger.Printf("✅ Signature Verification Succeeded")
 	return nil
 }
 
-func (d *SignatureDebugger) sortMapToJSON(m map[string]interface{}) map[string]interface{} {
-	sorted := make(map[string]interface{})
-	keys := make([]string, 0, len(m))
-
-	for k := range m {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	for _, k := range keys {
-		v := m[k]
-		// Handle nested maps
-		if nestedMap, ok := v.(map[string]interface{}); ok {
-			sorted[k] = d.sortMapToJSON(nestedMap)
-		} else if nestedSlice, ok := v.([]interface{}); ok {
-			// Handle slices of maps
-			for i, item := range nestedSlice {
-				if nestedMap, ok := item.(map[string]interface{}); ok {
-					nestedSlice[i] = d.sortMapToJSON(nestedMap)
-				}
-			}
-			sorted[k] = nestedSlice
-		} else {
-			sorted[k] = v
-		}
-	}
-	return sorted
-}
-
 // Helper function to compare two canonical forms
 func (d *SignatureDebugger) CompareCanonicalForms(expected, actual []byte) {
 	d.logger.Printf("=== Canonical Form Comparison ===")
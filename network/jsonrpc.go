@@ -74,8 +74,6 @@ package network
 // 		result, err = node.handleGetPeers(req.Params)
 // 	case "submitSignedTransaction":
 // 		result, err = node.handleSubmitSignedTransaction(req.Params)
-// 	case "estimateGas":
-// 		result, err = node.handleEstimateGas(req.Params)
 // 	case "stake":
 // 		result, err = node.handleStaking(req.Params)
 // 	case "delegate":
@@ -616,40 +614,6 @@ package network
 // 	}, nil
 // }
 
-// func (h *Handler) handleEstimateGas(params []interface{}) (interface{}, error) {
-// 	// Log the incoming request
-// 	log.Printf("estimateGas JSON-RPC method called")
-
-// 	// Check if params exist
-// 	if len(params) < 1 {
-// 		return nil, fmt.Errorf("dataSize parameter required")
-// 	}
-
-// 	// Extract dataSize from params
-// 	dataSizeFloat, ok := params[0].(float64)
-// 	if !ok {
-// 		return nil, fmt.Errorf("invalid dataSize parameter: must be a number")
-// 	}
-
-// 	// Convert float64 to int
-// 	dataSize := int(dataSizeFloat)
-
-// 	// Calculate gas
-// 	gas := node.CalculateGas(dataSize, 0)
-
-// 	// Log the estimation
-// 	log.Printf("Gas fee estimate calculated: %d for data size: %d", gas, dataSize)
-
-// 	// Return structured response
-// 	return struct {
-// 		GasFee     int    `json:"gasFee"`
-// 		GasFeeUnit string `json:"gasFeeUnit"`
-// 	}{
-// 		GasFee:     gas,
-// 		GasFeeUnit: "nanoTHRYLOS",
-// 	}, nil
-// }
-
 // func (h *Handler) handleGetStakingStats(params []interface{}) (interface{}, error) {
 // 	// Check if params exist
 // 	if len(params) < 1 {
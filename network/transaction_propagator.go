@@ -81,11 +81,13 @@ func convertToSharedTransaction(tx *thrylos.Transaction) *types.Transaction {
 	outputs := make([]types.UTXO, len(tx.Outputs))
 	for i, output := range tx.Outputs {
 		outputs[i] = types.UTXO{
-			TransactionID: output.TransactionId,
-			Index:         int(output.Index),
-			OwnerAddress:  output.OwnerAddress,
-			Amount:        amount.Amount(output.Amount),
-			IsSpent:       output.IsSpent,
+			TransactionID:   output.TransactionId,
+			Index:           int(output.Index),
+			OwnerAddress:    output.OwnerAddress,
+			Amount:          amount.Amount(output.Amount),
+			IsSpent:         output.IsSpent,
+			LockUntilHeight: output.LockUntilHeight,
+			LockUntilTime:   output.LockUntilTime,
 		}
 	}
 
@@ -102,6 +104,7 @@ func convertToSharedTransaction(tx *thrylos.Transaction) *types.Transaction {
 		BlockHash:        string(tx.BlockHash),
 		Salt:             tx.Salt,
 		Status:           tx.Status,
+		Memo:             tx.Memo,
 		Signature:        nil,
 	}
 
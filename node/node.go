@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/thrylos-labs/thrylos/chain"
 	"github.com/thrylos-labs/thrylos/consensus/processor"
 	"github.com/thrylos-labs/thrylos/consensus/selection"
 	"github.com/thrylos-labs/thrylos/consensus/staking"
@@ -33,9 +34,8 @@ type Node struct {
 	chainID          string
 
 	// Basic settings
-	GasEstimateURL string
-	serverHost     string
-	useSSL         bool
+	serverHost string
+	useSSL     bool
 
 	// Essential synchronization
 	Mu           sync.RWMutex
@@ -90,11 +90,6 @@ func NewNode(address string, dataDir string) *Node { // removed knownPeers
 	log.Println("AES key decoded successfully")
 
 	// Get essential configuration
-	gasEstimateURL := envFile["GAS_ESTIMATE_URL"]
-	if gasEstimateURL == "" {
-		log.Fatal("Gas estimate URL is not set in environment variables")
-	}
-
 	genesisAccount := envFile["GENESIS_ACCOUNT"]
 	if genesisAccount == "" {
 		log.Fatal("Genesis account is not set in environment variables")
@@ -126,7 +121,6 @@ func NewNode(address string, dataDir string) *Node { // removed knownPeers
 		// Database:         db,               // db implements types.Store
 		PublicKeyMap:     make(map[string]mldsa44.PublicKey),
 		ResponsibleUTXOs: make(map[string]types.UTXO),
-		GasEstimateURL:   gasEstimateURL,
 		serverHost:       serverHost,
 		useSSL:           useSSL,
 		BlockTrigger:     make(chan struct{}, 1),
@@ -175,3 +169,17 @@ func NewNode(address string, dataDir string) *Node { // removed knownPeers
 
 	return node
 }
+
+// NewChainNode returns a Node serving an already opened blockchain and its store.
+func NewChainNode(bc *chain.BlockchainImpl, db types.Store) *Node {
+	return &Node{
+		blockchain:       bc.Blockchain,
+		Database:         db,
+		txPool:           bc.TxPool(),
+		chainID:          bc.GetChainID(),
+		PublicKeyMap:     make(map[string]mldsa44.PublicKey),
+		ResponsibleUTXOs: make(map[string]types.UTXO),
+		BlockTrigger:     make(chan struct{}, 1),
+		messageCh:        make(chan types.Message, 1000),
+	}
+}
@@ -0,0 +1,9 @@
+package node
+
+import "github.com/thrylos-labs/thrylos/chain"
+
+// EstimateFees suggests low, medium and high transaction fees from the fee rates paid
+// in recent blocks and the transactions waiting in the pool.
+func (node *Node) EstimateFees() (*chain.FeeEstimate, error) {
+	return chain.NewFeeEstimator(node.blockchain, node.txPool).Estimate()
+}
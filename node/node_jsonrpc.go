@@ -0,0 +1,132 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/thrylos-labs/thrylos/chain"
+)
+
+type JSONRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      interface{}   `json:"id"`
+}
+
+type JSONRPCResponse struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Result  interface{}   `json:"result,omitempty"`
+	Error   *JSONRPCError `json:"error,omitempty"`
+	ID      interface{}   `json:"id"`
+}
+
+type JSONRPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// JSONRPCHandler serves the node's JSON-RPC methods over HTTP POST
+type JSONRPCHandler struct {
+	node *Node
+}
+
+func NewJSONRPCHandler(node *Node) *JSONRPCHandler {
+	return &JSONRPCHandler{node: node}
+}
+
+func sendJSONRPCError(w http.ResponseWriter, jsonrpcErr *JSONRPCError, id interface{}) {
+	response := JSONRPCResponse{
+		JSONRPC: "2.0",
+		Error:   jsonrpcErr,
+		ID:      id,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *JSONRPCHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req JSONRPCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendJSONRPCError(w, &JSONRPCError{
+			Code:    -32700,
+			Message: "Parse error",
+		}, req.ID)
+		return
+	}
+
+	var result interface{}
+	var err error
+
+	switch req.Method {
+	case "estimateGas", "estimateFee":
+		result, err = h.handleEstimateGas(req.Params)
+	default:
+		sendJSONRPCError(w, &JSONRPCError{
+			Code:    -32601,
+			Message: fmt.Sprintf("Method %q not found", req.Method),
+		}, req.ID)
+		return
+	}
+
+	if err != nil {
+		sendJSONRPCError(w, &JSONRPCError{
+			Code:    -32603,
+			Message: err.Error(),
+		}, req.ID)
+		return
+	}
+
+	response := JSONRPCResponse{
+		JSONRPC: "2.0",
+		Result:  result,
+		ID:      req.ID,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// EstimateGasResult is the fee suggested for a transaction of the requested size
+type EstimateGasResult struct {
+	GasFee     int                `json:"gasFee"`
+	GasFeeUnit string             `json:"gasFeeUnit"`
+	Estimate   *chain.FeeEstimate `json:"estimate"`
+}
+
+func (h *JSONRPCHandler) handleEstimateGas(params []interface{}) (interface{}, error) {
+	if len(params) < 1 {
+		return nil, fmt.Errorf("dataSize parameter required")
+	}
+
+	dataSizeFloat, ok := params[0].(float64)
+	if !ok || dataSizeFloat < 0 {
+		return nil, fmt.Errorf("invalid dataSize parameter: must be a non-negative number")
+	}
+	dataSize := int(dataSizeFloat)
+
+	// Estimate fees locally from recent blocks and the transaction pool
+	estimate, err := h.node.EstimateFees()
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate fees: %v", err)
+	}
+
+	gas := estimate.Medium.FeeFor(dataSize)
+	log.Printf("Gas fee estimate calculated: %d for data size: %d", gas, dataSize)
+
+	return &EstimateGasResult{
+		GasFee:     gas,
+		GasFeeUnit: "nanoTHRYLOS",
+		Estimate:   estimate,
+	}, nil
+}
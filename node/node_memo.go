@@ -0,0 +1,12 @@
+package node
+
+import (
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// GetTransactionsByMemo returns a page of the confirmed transactions carrying exactly
+// memo, in chain order.
+func (node *Node) GetTransactionsByMemo(memo []byte, offset, limit int) ([]*types.Transaction, error) {
+	return chain.GetTransactionsByMemo(node.Database, memo, offset, limit)
+}
@@ -1,9 +1,5 @@
 package node
 
-import (
-	"github.com/thrylos-labs/thrylos/types"
-)
-
 // // // This method should be aligned with how we're handling stake determinations
 // func (node *Node) UnstakeTokens(userAddress string, isDelegator bool, amount int64) error {
 // 	// We should determine if it's a delegator by checking validator status
@@ -42,15 +38,6 @@ func (node *Node) GetStakingStats() map[string]interface{} {
 	return node.StakingService.GetPoolStats()
 }
 
-func (node *Node) CreateStake(userAddress string, amount int64) (*types.Stake, error) {
-	return node.StakingService.CreateStake(userAddress, amount)
-}
-
-// These delegation-specific methods are correct
-func (node *Node) DelegateToPool(delegator string, amount int64) (*types.Stake, error) {
-	return node.StakingService.CreateStake(delegator, amount)
-}
-
 // func (node *Node) UndelegateFromPool(delegator string, amount int64) error {
 // 	return node.UnstakeTokens(delegator, true, amount)
 // }
@@ -0,0 +1,79 @@
+package nodetests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/chain"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/encryption"
+	"github.com/thrylos-labs/thrylos/node"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func newTestNode(t *testing.T) (*node.Node, *chain.BlockchainImpl) {
+	priv, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	aesKey, err := encryption.GenerateAESKey()
+	require.NoError(t, err)
+
+	bc, blockchainStore, err := chain.NewBlockchain(&types.BlockchainConfig{
+		InMemory:          true,
+		AESKey:            aesKey,
+		GenesisAccount:    priv,
+		TestMode:          true,
+		DisableBackground: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { blockchainStore.(interface{ Close() error }).Close() })
+	return node.NewChainNode(bc, blockchainStore), bc
+}
+
+func callJSONRPC(t *testing.T, handler http.Handler, method string, params ...interface{}) (int, map[string]json.RawMessage) {
+	body, err := json.Marshal(node.JSONRPCRequest{JSONRPC: "2.0", Method: method, Params: params, ID: 1})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+
+	var response map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec.Code, response
+}
+
+func TestEstimateGasOverJSONRPC(t *testing.T) {
+	n, bc := newTestNode(t)
+	handler := node.NewJSONRPCHandler(n)
+
+	expected, err := chain.NewFeeEstimator(bc.Blockchain, bc.TxPool()).Estimate()
+	require.NoError(t, err)
+
+	for _, method := range []string{"estimateGas", "estimateFee"} {
+		code, response := callJSONRPC(t, handler, method, 250)
+		require.Equal(t, http.StatusOK, code, method)
+		require.NotContains(t, response, "error", method)
+
+		var result node.EstimateGasResult
+		require.NoError(t, json.Unmarshal(response["result"], &result))
+		require.Equal(t, expected.Medium.FeeFor(250), result.GasFee, method)
+		require.Equal(t, "nanoTHRYLOS", result.GasFeeUnit)
+		require.Equal(t, expected, result.Estimate)
+	}
+
+	// The data size is required and must be a number
+	code, response := callJSONRPC(t, handler, "estimateGas")
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Contains(t, string(response["error"]), "dataSize parameter required")
+
+	code, response = callJSONRPC(t, handler, "estimateGas", "large")
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Contains(t, string(response["error"]), "invalid dataSize")
+
+	// Unknown methods are reported as such
+	code, response = callJSONRPC(t, handler, "noSuchMethod")
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Contains(t, string(response["error"]), "-32601")
+}
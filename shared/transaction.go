@@ -13,7 +13,6 @@ package shared
 
 import (
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -37,19 +36,6 @@ type TransactionContextImpl struct {
 	transaction *types.Transaction
 }
 
-type TransactionForSigning struct {
-	ID               string       `json:"id"`
-	Timestamp        int64        `json:"timestamp"`
-	Inputs           []types.UTXO `json:"inputs"`
-	Outputs          []types.UTXO `json:"outputs"`
-	EncryptedInputs  []byte       `json:"encryptedInputs,omitempty"`
-	EncryptedOutputs []byte       `json:"encryptedOutputs,omitempty"`
-	EncryptedAESKey  []byte       `json:"encryptedAESKey,omitempty"`
-	PreviousTxIds    []string     `json:"previousTxIds,omitempty"`
-	Sender           string       `json:"sender"`
-	GasFee           int          `json:"gasFee"`
-}
-
 // Implement the TransactionContext interface
 func (tc *TransactionContextImpl) GetUTXOs() map[string][]types.UTXO {
 	tc.mu.RLock()
@@ -126,12 +112,17 @@ func ValidateTransactionStructure(tx *types.Transaction) error {
 		return fmt.Errorf("transaction must have inputs and outputs")
 	}
 
-	outputSum := amount.Amount(0)
+	if err := tx.ValidateMemo(); err != nil {
+		return err
+	}
+
 	for _, output := range tx.Outputs {
 		if output.Amount <= 0 {
 			return fmt.Errorf("invalid output amount: %d", output.Amount)
 		}
-		outputSum += output.Amount
+	}
+	if _, err := types.SumUTXOs(tx.Outputs); err != nil {
+		return fmt.Errorf("invalid outputs: %w", err)
 	}
 
 	return nil
@@ -151,78 +142,32 @@ func UnserializeTransaction(data []byte) (*types.Transaction, error) {
 	return &tx, nil
 }
 
-func SerializeTransactionWithoutSignature(tx *types.Transaction) ([]byte, error) {
-	txCopy := *tx
-	txCopy.Signature = nil
-	return cbor.Marshal(txCopy)
-}
-
-// VerifyTransactionSignature verifies the transaction signature
-func VerifyTransactionSignature(tx *types.Transaction) error {
-	txBytes, err := SerializeTransactionWithoutSignature(tx)
-	if err != nil {
-		return fmt.Errorf("failed to serialize transaction: %v", err)
-	}
-
-	if (tx.Salt == nil) || (len(tx.Salt) == 0) {
-		return tx.Signature.Verify(&tx.SenderPublicKey, txBytes)
-	}
-	return tx.Signature.VerifyWithSalt(&tx.SenderPublicKey, txBytes, tx.Salt)
-}
-
-func ValidateTransaction(tx *types.Transaction, availableUTXOs map[string][]types.UTXO) error {
-	// First perform structure validation using shared validation function
-	if err := ValidateTransactionStructure(tx); err != nil {
-		return fmt.Errorf("structure validation failed: %w", err)
-	}
-
-	// Blockchain-specific validation
-	inputSum := amount.Amount(0)
-	for _, input := range tx.Inputs {
-		// Construct the key used to find the UTXOs for this input
-		utxoKey := fmt.Sprintf("%s-%d", input.TransactionID, input.Index) // Use string formatting instead of Key() method
-
-		utxos, exists := availableUTXOs[utxoKey]
-		if !exists || len(utxos) == 0 {
-			return fmt.Errorf("input UTXO not found or empty slice: %s", utxoKey)
+// VerifyTransactionSignature checks that the sender public key belongs to the sender
+// address and that it signed the transaction's signing preimage for chainID. For a
+// multisig sender the policy must belong to the sender address instead, and enough
+// of its keys must have signed the preimage.
+func VerifyTransactionSignature(tx *types.Transaction, chainID string) error {
+	if tx.Multisig != nil {
+		policyAddr, err := tx.Multisig.Address()
+		if err != nil {
+			return fmt.Errorf("invalid multisig policy: %v", err)
 		}
-
-		// Verify UTXO ownership and amount
-		if utxos[0].OwnerAddress != tx.SenderAddress.String() {
-			return fmt.Errorf("UTXO owner mismatch for: %s", utxoKey)
+		if policyAddr.String() != tx.SenderAddress.String() {
+			return fmt.Errorf("multisig policy belongs to %s, not %s", policyAddr.String(), tx.SenderAddress.String())
 		}
-
-		inputSum += utxos[0].Amount
+		return tx.VerifyMultisig(chainID)
 	}
-
-	// Calculate output sum
-	outputSum := amount.Amount(0)
-	for _, output := range tx.Outputs {
-		outputSum += output.Amount
+	if tx.SenderPublicKey == nil {
+		return fmt.Errorf("transaction has no sender public key")
 	}
-
-	// Validate balance including gas fee
-	if inputSum != outputSum+amount.Amount(tx.GasFee) {
-		return fmt.Errorf("input sum (%d) does not match output sum (%d) plus gas fee (%d)",
-			inputSum, outputSum, tx.GasFee)
+	senderAddr, err := tx.SenderPublicKey.Address()
+	if err != nil {
+		return fmt.Errorf("invalid sender public key: %v", err)
 	}
-	return nil
-}
-
-func SerializeTransactionForSigning(tx *types.Transaction) ([]byte, error) {
-	txForSigning := TransactionForSigning{
-		ID:               tx.ID,
-		Timestamp:        tx.Timestamp,
-		Inputs:           tx.Inputs,
-		Outputs:          tx.Outputs,
-		EncryptedInputs:  tx.EncryptedInputs,
-		EncryptedOutputs: tx.EncryptedOutputs,
-		EncryptedAESKey:  tx.EncryptedAESKey,
-		PreviousTxIds:    tx.PreviousTxIds,
-		Sender:           tx.SenderAddress.String(),
-		GasFee:           tx.GasFee,
+	if senderAddr.String() != tx.SenderAddress.String() {
+		return fmt.Errorf("sender public key belongs to %s, not %s", senderAddr.String(), tx.SenderAddress.String())
 	}
-	return json.Marshal(txForSigning)
+	return tx.VerifySignature(tx.SenderPublicKey, chainID)
 }
 
 // SanitizeAndFormatAddress cleans and validates blockchain addresses.
@@ -313,14 +258,11 @@ func ValidateTransactionBalance(tx *types.Transaction) error {
 		}
 	}
 
-	var inputSum, outputSum amount.Amount // Changed to amount.Amount type
-
 	// Validate inputs (in nanoTHRYLOS)
 	for _, input := range tx.Inputs {
 		if input.Amount <= 0 {
 			return fmt.Errorf("invalid input amount: %d nanoTHRYLOS", input.Amount)
 		}
-		inputSum += input.Amount
 	}
 
 	// Validate outputs (in nanoTHRYLOS)
@@ -328,19 +270,31 @@ func ValidateTransactionBalance(tx *types.Transaction) error {
 		if output.Amount <= 0 {
 			return fmt.Errorf("invalid output amount: %d nanoTHRYLOS", output.Amount)
 		}
-		outputSum += output.Amount
+	}
+
+	inputSum, err := types.SumUTXOs(tx.Inputs)
+	if err != nil {
+		return fmt.Errorf("invalid inputs: %w", err)
+	}
+	outputSum, err := types.SumUTXOs(tx.Outputs)
+	if err != nil {
+		return fmt.Errorf("invalid outputs: %w", err)
 	}
 
 	// Convert gas fee to amount.Amount to ensure type consistency
 	gasFeeAmount := amount.Amount(tx.GasFee)
+	spent, err := outputSum.Add(gasFeeAmount)
+	if err != nil {
+		return fmt.Errorf("invalid gas fee: %w", err)
+	}
 
 	log.Printf("Transaction validation - Input sum: %s", inputSum)
 	log.Printf("Transaction validation - Output sum: %s", outputSum)
 	log.Printf("Transaction validation - Gas fee: %s", gasFeeAmount)
-	log.Printf("Transaction validation - Total (outputs + gas fee): %s", outputSum+gasFeeAmount)
+	log.Printf("Transaction validation - Total (outputs + gas fee): %s", spent)
 
 	// Account for gas fee in the balance calculation using amount.Amount arithmetic
-	if inputSum != outputSum+gasFeeAmount {
+	if inputSum != spent {
 		return fmt.Errorf("inputs (%s) do not match outputs (%s) plus gas fee (%s)",
 			inputSum, outputSum, gasFeeAmount)
 	}
@@ -0,0 +1,314 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// txnWriter is the part of a transaction the block writers need. Get returns a copy of
+// the value and badger.ErrKeyNotFound for missing keys. It is implemented by
+// badgerWriter and batchWriter.
+type txnWriter interface {
+	Get(key []byte) ([]byte, error)
+	Set(key, val []byte) error
+	Delete(key []byte) error
+}
+
+// badgerWriter adapts a Badger transaction to txnWriter.
+type badgerWriter struct {
+	*badger.Txn
+}
+
+func (t badgerWriter) Get(key []byte) ([]byte, error) {
+	return getValue(t.Txn, key)
+}
+
+// getValue reads a copy of the value stored under key.
+func getValue(txn *badger.Txn, key []byte) ([]byte, error) {
+	item, err := txn.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+// batchWriter spreads writes over as many Badger transactions as needed. Writes that
+// fit in a single transaction are atomic; larger blocks are committed in batches and
+// rely on the commit marker for recovery.
+type batchWriter struct {
+	db      *badger.DB
+	txn     *badger.Txn
+	flushed bool // A batch has been committed, so the writes are no longer atomic
+}
+
+func newBatchWriter(db *badger.DB) *batchWriter {
+	return &batchWriter{db: db, txn: db.NewTransaction(true)}
+}
+
+func (w *batchWriter) Get(key []byte) ([]byte, error) {
+	return getValue(w.txn, key)
+}
+
+func (w *batchWriter) Set(key, val []byte) error {
+	err := w.txn.Set(key, val)
+	if errors.Is(err, badger.ErrTxnTooBig) {
+		if err := w.flush(); err != nil {
+			return err
+		}
+		return w.txn.Set(key, val)
+	}
+	return err
+}
+
+func (w *batchWriter) Delete(key []byte) error {
+	err := w.txn.Delete(key)
+	if errors.Is(err, badger.ErrTxnTooBig) {
+		if err := w.flush(); err != nil {
+			return err
+		}
+		return w.txn.Delete(key)
+	}
+	return err
+}
+
+// flush commits the current batch and starts the next one.
+func (w *batchWriter) flush() error {
+	if err := w.txn.Commit(); err != nil {
+		return fmt.Errorf("failed to commit batch: %v", err)
+	}
+	w.txn = w.db.NewTransaction(true)
+	w.flushed = true
+	return nil
+}
+
+func (w *batchWriter) Commit() error {
+	return w.txn.Commit()
+}
+
+func (w *batchWriter) Discard() {
+	w.txn.Discard()
+}
+
+// commitMarker records the block being committed or disconnected. It is written first
+// and removed last, so finding it at startup means the block was partially applied.
+type commitMarker struct {
+	Height    int64     `cbor:"1,keyasint"`
+	BlockHash hash.Hash `cbor:"2,keyasint"`
+}
+
+// CommitBlock stores a block together with its header, indexes, undo record and the
+// UTXO changes it makes: spent outputs are marked as spent and created outputs are
+// added. Everything is written in one Badger transaction unless the block is too
+// large, in which case RecoverIncompleteBlock rolls back a commit that fails after
+// its first batch, or that was interrupted, at the next start.
+func (s *store) CommitBlock(b *types.Block, spent []types.UTXO, created []types.UTXO, undo *types.BlockUndo) error {
+	w := newBatchWriter(s.db.GetDB())
+	defer w.Discard()
+
+	err := commitBlock(w, b, spent, created, undo)
+	if err == nil {
+		err = w.Commit()
+	}
+	if err != nil {
+		log.Printf("Error committing block %d: %v", b.Index, err)
+		if w.flushed {
+			// Earlier batches are already on disk; revert them using the marker
+			w.Discard()
+			if _, _, recoverErr := s.RecoverIncompleteBlock(); recoverErr != nil {
+				return fmt.Errorf("error committing block %d: %v (rollback failed: %v)", b.Index, err, recoverErr)
+			}
+		}
+		return fmt.Errorf("error committing block %d: %v", b.Index, err)
+	}
+	return nil
+}
+
+// commitBlock writes everything CommitBlock stores, between setting and removing the
+// commit marker.
+func commitBlock(w txnWriter, b *types.Block, spent []types.UTXO, created []types.UTXO, undo *types.BlockUndo) error {
+	blockData, err := b.Marshal()
+	if err != nil {
+		return fmt.Errorf("error marshaling block %d: %v", b.Index, err)
+	}
+	headerData, err := b.Header().Marshal()
+	if err != nil {
+		return fmt.Errorf("error marshaling header of block %d: %v", b.Index, err)
+	}
+	undoData, err := undo.Marshal()
+	if err != nil {
+		return fmt.Errorf("error marshaling undo record for block %d: %v", b.Index, err)
+	}
+	markerData, err := cbor.Marshal(&commitMarker{Height: b.Index, BlockHash: b.Hash})
+	if err != nil {
+		return fmt.Errorf("error marshaling commit marker: %v", err)
+	}
+
+	// The marker and undo record go first so an interrupted commit can be rolled back
+	if err := w.Set([]byte(CommitMarkerKey), markerData); err != nil {
+		return err
+	}
+	if err := w.Set([]byte(BlockUndoPrefix+b.Hash.String()), undoData); err != nil {
+		return err
+	}
+	if err := removeBlockIndexes(w, uint32(b.Index)); err != nil {
+		return err
+	}
+	if err := w.Set([]byte(fmt.Sprintf("%s%d", BlockPrefix, b.Index)), blockData); err != nil {
+		return err
+	}
+	if err := w.Set([]byte(fmt.Sprintf("%s%d", HeaderPrefix, b.Index)), headerData); err != nil {
+		return err
+	}
+	if err := putBlockIndexes(w, b); err != nil {
+		return err
+	}
+	for _, utxo := range spent {
+		if err := setUTXO(w, utxo, true); err != nil {
+			return err
+		}
+	}
+	for _, utxo := range created {
+		if err := setUTXO(w, utxo, false); err != nil {
+			return err
+		}
+	}
+	return w.Delete([]byte(CommitMarkerKey))
+}
+
+// DisconnectBlock reverts a committed block using its undo record: spent outputs become
+// unspent again, created outputs are removed and the block leaves its height.
+func (s *store) DisconnectBlock(undo *types.BlockUndo) error {
+	w := newBatchWriter(s.db.GetDB())
+	defer w.Discard()
+
+	err := disconnectBlockMarked(w, undo)
+	if err == nil {
+		err = w.Commit()
+	}
+	if err != nil {
+		return fmt.Errorf("error disconnecting block %d: %v", undo.Height, err)
+	}
+	return nil
+}
+
+// disconnectBlockMarked applies an undo record between setting and removing the
+// commit marker, so an interrupted disconnect is finished at the next start.
+func disconnectBlockMarked(w txnWriter, undo *types.BlockUndo) error {
+	markerData, err := cbor.Marshal(&commitMarker{Height: undo.Height, BlockHash: undo.BlockHash})
+	if err != nil {
+		return fmt.Errorf("error marshaling commit marker: %v", err)
+	}
+	if err := w.Set([]byte(CommitMarkerKey), markerData); err != nil {
+		return err
+	}
+	if err := disconnectBlock(w, undo); err != nil {
+		return err
+	}
+	return w.Delete([]byte(CommitMarkerKey))
+}
+
+// disconnectBlock applies an undo record. It is idempotent so it can be repeated
+// when recovering from an interrupted commit or disconnect.
+func disconnectBlock(w txnWriter, undo *types.BlockUndo) error {
+	for _, utxo := range undo.Created {
+		if err := w.Delete([]byte(GenerateUTXOKey(utxo.OwnerAddress, utxo.TransactionID, utxo.Index))); err != nil {
+			return err
+		}
+	}
+	for _, utxo := range undo.Spent {
+		if err := setUTXO(w, utxo, false); err != nil {
+			return err
+		}
+	}
+
+	// Only remove the block if the height still holds it
+	data, err := w.Get([]byte(fmt.Sprintf("%s%d", BlockPrefix, undo.Height)))
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var stored types.Block
+	if err := stored.Unmarshal(data); err != nil {
+		return fmt.Errorf("error unmarshaling block %d: %v", undo.Height, err)
+	}
+	if !stored.Hash.Equal(undo.BlockHash) {
+		return nil
+	}
+	if err := removeBlockIndexes(w, uint32(undo.Height)); err != nil {
+		return err
+	}
+	if err := w.Delete([]byte(fmt.Sprintf("%s%d", BlockPrefix, undo.Height))); err != nil {
+		return err
+	}
+	return w.Delete([]byte(fmt.Sprintf("%s%d", HeaderPrefix, undo.Height)))
+}
+
+// setUTXO writes an output under its owner key in the format used by the UTXO queries.
+func setUTXO(w txnWriter, utxo types.UTXO, isSpent bool) error {
+	utxo.IsSpent = isSpent
+	val, err := json.Marshal(utxo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal UTXO: %v", err)
+	}
+	return w.Set([]byte(GenerateUTXOKey(utxo.OwnerAddress, utxo.TransactionID, utxo.Index)), val)
+}
+
+// RecoverIncompleteBlock checks for a block whose commit or disconnect was interrupted
+// and finishes reverting it, so the store ends at the last fully applied height. It
+// reports the height that was reverted, if any.
+func (s *store) RecoverIncompleteBlock() (int64, bool, error) {
+	w := newBatchWriter(s.db.GetDB())
+	defer w.Discard()
+
+	height, found, err := recoverIncompleteBlock(w)
+	if err == nil && found {
+		if err = w.Commit(); err != nil {
+			err = fmt.Errorf("error recovering block %d: %v", height, err)
+		}
+	}
+	return height, found && err == nil, err
+}
+
+// recoverIncompleteBlock reverts the block named by the commit marker, if there is one,
+// and removes the marker.
+func recoverIncompleteBlock(w txnWriter) (int64, bool, error) {
+	data, err := w.Get([]byte(CommitMarkerKey))
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("error reading commit marker: %v", err)
+	}
+	var marker commitMarker
+	if err := cbor.Unmarshal(data, &marker); err != nil {
+		return 0, false, fmt.Errorf("error unmarshaling commit marker: %v", err)
+	}
+	log.Printf("Found partially applied block %d (%s), reverting it", marker.Height, marker.BlockHash.String())
+
+	undoData, err := w.Get([]byte(BlockUndoPrefix + marker.BlockHash.String()))
+	if err != nil {
+		return marker.Height, false, fmt.Errorf("cannot recover block %d: error retrieving undo record: %v", marker.Height, err)
+	}
+	var undo types.BlockUndo
+	if err := undo.Unmarshal(undoData); err != nil {
+		return marker.Height, false, fmt.Errorf("cannot recover block %d: error unmarshaling undo record: %v", marker.Height, err)
+	}
+
+	err = disconnectBlock(w, &undo)
+	if err == nil {
+		err = w.Delete([]byte(CommitMarkerKey))
+	}
+	if err != nil {
+		return marker.Height, false, fmt.Errorf("error recovering block %d: %v", marker.Height, err)
+	}
+	return marker.Height, true, nil
+}
@@ -0,0 +1,277 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/thrylos-labs/thrylos/crypto/address"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func blockHashIndexKey(blockHash hash.Hash) []byte {
+	return []byte(BlockHashIndexPrefix + blockHash.String())
+}
+
+func txLocationIndexKey(txID string) []byte {
+	return []byte(TxLocationIndexPrefix + txID)
+}
+
+func addressHistoryPrefix(addr string) string {
+	return AddressHistoryPrefix + addr + "-"
+}
+
+// addressHistoryKey is ordered by block number then position, so iterating the prefix
+// of an address returns its transactions in chain order.
+func addressHistoryKey(addr string, loc *types.TransactionLocation) []byte {
+	return []byte(fmt.Sprintf("%s%012d-%06d", addressHistoryPrefix(addr), loc.BlockHeight, loc.Position))
+}
+
+func memoIndexPrefix(memo []byte) string {
+	memoHash := hash.NewHash(memo)
+	return MemoIndexPrefix + memoHash.String() + "-"
+}
+
+// memoIndexKey is keyed by the hash of the memo, which bounds the key size, and is
+// ordered like addressHistoryKey.
+func memoIndexKey(memo []byte, loc *types.TransactionLocation) []byte {
+	return []byte(fmt.Sprintf("%s%012d-%06d", memoIndexPrefix(memo), loc.BlockHeight, loc.Position))
+}
+
+// saltIndexKey is keyed by the hash of the salt, like memoIndexKey.
+func saltIndexKey(salt []byte) []byte {
+	saltHash := hash.NewHash(salt)
+	return []byte(SaltIndexPrefix + saltHash.String())
+}
+
+// transactionAddresses returns the addresses a transaction touches: its sender and the
+// owners of its outputs.
+func transactionAddresses(tx *types.Transaction) []string {
+	seen := make(map[string]bool)
+	var addresses []string
+	add := func(addr string) {
+		if addr != "" && !seen[addr] {
+			seen[addr] = true
+			addresses = append(addresses, addr)
+		}
+	}
+	if !tx.SenderAddress.Compare(*address.NullAddress()) {
+		add(tx.SenderAddress.String())
+	}
+	for _, output := range tx.Outputs {
+		add(output.OwnerAddress)
+	}
+	return addresses
+}
+
+// putBlockIndexes writes the hash, transaction, address, memo and salt indexes of a block.
+func putBlockIndexes(txn txnWriter, b *types.Block) error {
+	height := []byte(strconv.FormatInt(b.Index, 10))
+	if err := txn.Set(blockHashIndexKey(b.Hash), height); err != nil {
+		return err
+	}
+	for position, tx := range b.Transactions {
+		loc := &types.TransactionLocation{
+			TxID:        tx.ID,
+			BlockHeight: b.Index,
+			BlockHash:   b.Hash,
+			Position:    position,
+		}
+		data, err := loc.Marshal()
+		if err != nil {
+			return fmt.Errorf("error marshaling location of transaction %s: %v", tx.ID, err)
+		}
+		if err := txn.Set(txLocationIndexKey(tx.ID), data); err != nil {
+			return err
+		}
+		for _, addr := range transactionAddresses(tx) {
+			if err := txn.Set(addressHistoryKey(addr, loc), data); err != nil {
+				return err
+			}
+		}
+		if len(tx.Memo) > 0 {
+			if err := txn.Set(memoIndexKey(tx.Memo, loc), data); err != nil {
+				return err
+			}
+		}
+		if len(tx.Salt) > 0 {
+			if err := txn.Set(saltIndexKey(tx.Salt), data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removeBlockIndexes deletes the indexes of the block stored at the given height, if any,
+// before it is replaced or deleted.
+func removeBlockIndexes(txn txnWriter, blockNumber uint32) error {
+	data, err := txn.Get([]byte(fmt.Sprintf("%s%d", BlockPrefix, blockNumber)))
+	if err == badger.ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var b types.Block
+	if err := b.Unmarshal(data); err != nil {
+		return fmt.Errorf("error unmarshaling block %d: %v", blockNumber, err)
+	}
+
+	if err := txn.Delete(blockHashIndexKey(b.Hash)); err != nil {
+		return err
+	}
+	for position, tx := range b.Transactions {
+		loc := &types.TransactionLocation{BlockHeight: b.Index, Position: position}
+		// The transaction may already be indexed in a block of the new branch
+		if err := deleteLocationOf(txn, txLocationIndexKey(tx.ID), b.Hash); err != nil {
+			return err
+		}
+		if len(tx.Salt) > 0 {
+			if err := deleteLocationOf(txn, saltIndexKey(tx.Salt), b.Hash); err != nil {
+				return err
+			}
+		}
+		for _, addr := range transactionAddresses(tx) {
+			if err := txn.Delete(addressHistoryKey(addr, loc)); err != nil {
+				return err
+			}
+		}
+		if len(tx.Memo) > 0 {
+			if err := txn.Delete(memoIndexKey(tx.Memo, loc)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteLocationOf removes the transaction location stored under key if it points at
+// the given block.
+func deleteLocationOf(txn txnWriter, key []byte, blockHash hash.Hash) error {
+	data, err := txn.Get(key)
+	if err == badger.ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var loc types.TransactionLocation
+	if err := loc.Unmarshal(data); err != nil {
+		return fmt.Errorf("error unmarshaling location under %s: %v", key, err)
+	}
+	if !loc.BlockHash.Equal(blockHash) {
+		return nil
+	}
+	return txn.Delete(key)
+}
+
+// GetBlockByHash retrieves a canonical block by its hash.
+func (s *store) GetBlockByHash(blockHash hash.Hash) (*types.Block, error) {
+	data, err := s.db.Get(blockHashIndexKey(blockHash))
+	if err != nil {
+		return nil, fmt.Errorf("block %s not found: %v", blockHash.String(), err)
+	}
+	blockNumber, err := strconv.ParseUint(string(data), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block number for hash %s: %v", blockHash.String(), err)
+	}
+	block, err := s.GetBlock(uint32(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	if !block.Hash.Equal(blockHash) {
+		return nil, fmt.Errorf("block index for %s is stale", blockHash.String())
+	}
+	return block, nil
+}
+
+// GetTransactionLocation returns the block and position a transaction was included at.
+func (s *store) GetTransactionLocation(txID string) (*types.TransactionLocation, error) {
+	data, err := s.db.Get(txLocationIndexKey(txID))
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s not found: %v", txID, err)
+	}
+	var loc types.TransactionLocation
+	if err := loc.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("error unmarshaling location of transaction %s: %v", txID, err)
+	}
+	return &loc, nil
+}
+
+// GetTransactionLocationBySalt returns the location of the canonical transaction
+// carrying salt.
+func (s *store) GetTransactionLocationBySalt(salt []byte) (*types.TransactionLocation, error) {
+	if len(salt) == 0 {
+		return nil, fmt.Errorf("empty salt")
+	}
+	data, err := s.db.Get(saltIndexKey(salt))
+	if err != nil {
+		return nil, fmt.Errorf("salt not found: %v", err)
+	}
+	var loc types.TransactionLocation
+	if err := loc.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("error unmarshaling location of salt: %v", err)
+	}
+	return &loc, nil
+}
+
+// GetAddressHistory returns the transactions touching an address in chain order,
+// skipping the first offset entries and returning at most limit entries.
+func (s *store) GetAddressHistory(addr string, offset, limit int) ([]*types.TransactionLocation, error) {
+	history, err := s.pageLocations(addressHistoryPrefix(addr), offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error reading history of %s: %v", addr, err)
+	}
+	return history, nil
+}
+
+// GetTransactionsByMemo returns the transactions carrying exactly memo in chain order,
+// skipping the first offset entries and returning at most limit entries.
+func (s *store) GetTransactionsByMemo(memo []byte, offset, limit int) ([]*types.TransactionLocation, error) {
+	if len(memo) == 0 {
+		return nil, fmt.Errorf("empty memo")
+	}
+	locations, err := s.pageLocations(memoIndexPrefix(memo), offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error reading transactions by memo: %v", err)
+	}
+	return locations, nil
+}
+
+// pageLocations reads a page of the transaction locations stored under an index prefix.
+func (s *store) pageLocations(prefix string, offset, limit int) ([]*types.TransactionLocation, error) {
+	if offset < 0 || limit <= 0 {
+		return nil, fmt.Errorf("invalid page offset %d, limit %d", offset, limit)
+	}
+
+	var locations []*types.TransactionLocation
+	err := s.db.GetDB().View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		keyPrefix := []byte(prefix)
+		skipped := 0
+		for it.Seek(keyPrefix); it.ValidForPrefix(keyPrefix) && len(locations) < limit; it.Next() {
+			if skipped < offset {
+				skipped++
+				continue
+			}
+			data, err := it.Item().ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			var loc types.TransactionLocation
+			if err := loc.Unmarshal(data); err != nil {
+				return fmt.Errorf("error unmarshaling location: %v", err)
+			}
+			locations = append(locations, &loc)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
@@ -2,11 +2,8 @@ package store
 
 import (
 	"fmt"
-	"log"
 	"os"
-	"path/filepath"
 	"sync"
-	"time"
 
 	badger "github.com/dgraph-io/badger/v3"
 	"github.com/thrylos-labs/thrylos/types"
@@ -21,29 +18,33 @@ type Database struct {
 	encryptionKey []byte      // The AES-256 key used for encryption and decryption
 }
 
-// NewDatabase initializes and returns a new instance of BadgerDB
+// DatabaseOptions control how NewDatabaseWithOptions opens a database.
+type DatabaseOptions struct {
+	// DryRunMigrations logs the schema migrations an older database needs without
+	// applying them. The database stays at its old version and should only be inspected.
+	DryRunMigrations bool
+	// SkipMigrations opens the database at its current version for callers that run
+	// Migrate themselves. Databases written by a newer schema are still refused.
+	SkipMigrations bool
+}
+
+// NewDatabase initializes and returns a new instance of BadgerDB, migrated to
+// CurrentSchemaVersion.
 func NewDatabase(path string) (*Database, error) {
-	// Add a small delay to ensure proper cleanup
-	time.Sleep(100 * time.Millisecond)
-
-	// Remove any existing lock file before opening
-	lockFile := filepath.Join(path, "LOCK")
-	log.Printf("Attempting to remove lock file: %s", lockFile)
-	if err := os.Remove(lockFile); err != nil && !os.IsNotExist(err) {
-		return nil, fmt.Errorf("failed to remove existing lock file: %v", err)
-	}
+	return NewDatabaseWithOptions(path, DatabaseOptions{})
+}
 
+// NewDatabaseWithOptions opens the BadgerDB at path and runs its schema migrations.
+// Databases written by a newer schema are refused with ErrSchemaTooNew.
+func NewDatabaseWithOptions(path string, options DatabaseOptions) (*Database, error) {
 	d := &Database{}
 	var err error
 	d.once.Do(func() {
-		opts := badger.DefaultOptions(path).
-			WithLogger(nil).
-			WithSyncWrites(false).     // Disable sync for testing
-			WithDetectConflicts(false) // Disable conflict detection for testing
+		opts := badgerOptions(path)
 
 		// Remove the WithInMemory option and ensure the directory exists
-		if err := os.MkdirAll(path, 0755); err != nil {
-			err = fmt.Errorf("failed to create database directory: %v", err)
+		if mkdirErr := os.MkdirAll(path, 0755); mkdirErr != nil {
+			err = fmt.Errorf("failed to create database directory: %v", mkdirErr)
 			return
 		}
 
@@ -59,9 +60,42 @@ func NewDatabase(path string) (*Database, error) {
 		return nil, err
 	}
 
+	if options.SkipMigrations {
+		_, err = d.checkSchemaVersion()
+	} else {
+		_, err = d.Migrate(options.DryRunMigrations)
+	}
+	if err != nil {
+		d.Close()
+		return nil, err
+	}
 	return d, nil
 }
 
+// NewInMemoryDatabase returns a database held in memory, for tests and simulations.
+// It runs the same Badger engine with the same options as NewDatabase, so reads,
+// writes and transaction isolation behave identically, but nothing is written to disk
+// and the data is gone once it is closed.
+func NewInMemoryDatabase() (*Database, error) {
+	db, err := badger.Open(badgerOptions("").WithInMemory(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open in-memory Badger database: %v", err)
+	}
+	d := &Database{db: db}
+	if _, err := d.Migrate(false); err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func badgerOptions(path string) badger.Options {
+	return badger.DefaultOptions(path).
+		WithLogger(nil).
+		WithSyncWrites(false).     // Disable sync for testing
+		WithDetectConflicts(false) // Disable conflict detection for testing
+}
+
 // GetDB returns the underlying BadgerDB instance
 func (d *Database) GetDB() *badger.DB {
 	return d.db
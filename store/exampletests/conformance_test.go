@@ -0,0 +1,427 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/store"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// storeBackends are the stores every types.Store implementation must behave the same on.
+var storeBackends = []struct {
+	name string
+	open func(t *testing.T) types.Store
+}{
+	{"badger", func(t *testing.T) types.Store {
+		database, err := store.NewDatabase(t.TempDir())
+		return openDatabaseStore(t, database, err)
+	}},
+	{"in-memory", func(t *testing.T) types.Store {
+		database, err := store.NewInMemoryDatabase()
+		return openDatabaseStore(t, database, err)
+	}},
+}
+
+func openDatabaseStore(t *testing.T, database *store.Database, err error) types.Store {
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	s, err := store.NewStore(database, make([]byte, 32))
+	require.NoError(t, err)
+	return s
+}
+
+// TestStoreConformance runs the same checks against the store on every backend.
+func TestStoreConformance(t *testing.T) {
+	checks := []struct {
+		name  string
+		check func(t *testing.T, s types.Store)
+	}{
+		{"UTXOs", checkStoreUTXOs},
+		{"TransactionIsolation", checkStoreTransactionIsolation},
+		{"Transactions", checkStoreTransactions},
+		{"PendingTransactions", checkStorePendingTransactions},
+		{"Blocks", checkStoreBlocks},
+		{"Headers", checkStoreHeaders},
+		{"Recovery", checkStoreRecovery},
+		{"PublicKeys", checkStorePublicKeys},
+	}
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			for _, c := range checks {
+				t.Run(c.name, func(t *testing.T) {
+					c.check(t, backend.open(t))
+				})
+			}
+		})
+	}
+}
+
+func checkStoreUTXOs(t *testing.T, s types.Store) {
+	utxo := types.UTXO{TransactionID: "tx-1", Index: 0, OwnerAddress: "owner", Amount: 100}
+
+	ctx, err := s.BeginTransaction()
+	require.NoError(t, err)
+	require.NoError(t, s.AddNewUTXO(ctx, utxo))
+	require.NoError(t, s.CommitTransaction(ctx))
+
+	utxos, err := s.GetUTXOsForAddress("owner")
+	require.NoError(t, err)
+	require.Equal(t, []types.UTXO{utxo}, utxos)
+
+	ctx, err = s.BeginTransaction()
+	require.NoError(t, err)
+	require.NoError(t, s.MarkUTXOAsSpent(ctx, utxo))
+	require.NoError(t, s.CommitTransaction(ctx))
+
+	utxos, err = s.GetUTXOsForAddress("owner")
+	require.NoError(t, err)
+	require.Empty(t, utxos)
+
+	ctx, err = s.BeginTransaction()
+	require.NoError(t, err)
+	require.Error(t, s.MarkUTXOAsSpent(ctx, utxo), "an output can only be spent once")
+	require.NoError(t, s.RollbackTransaction(ctx))
+
+	change := types.UTXO{TransactionID: "tx-2", Index: 1, OwnerAddress: "other", Amount: 40}
+	require.NoError(t, s.UpdateUTXOs(nil, []types.UTXO{change}))
+	all, err := s.GetAllUTXOs()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]types.UTXO{"other": {change}}, all)
+}
+
+func checkStoreTransactionIsolation(t *testing.T, s types.Store) {
+	exists := func(ctx types.TransactionContext, id string) bool {
+		found, err := s.TransactionExists(ctx, id)
+		require.NoError(t, err)
+		return found
+	}
+
+	writer, err := s.BeginTransaction()
+	require.NoError(t, err)
+	before, err := s.BeginTransaction()
+	require.NoError(t, err)
+	defer before.Rollback()
+
+	require.NoError(t, s.SetTransaction(writer, []byte(store.PendingTransactionPrefix+"a"), []byte("a")))
+	require.True(t, exists(writer, "a"), "a transaction sees its own writes")
+	other, err := s.BeginTransaction()
+	require.NoError(t, err)
+	require.False(t, exists(other, "a"), "uncommitted writes are not visible")
+	require.NoError(t, s.RollbackTransaction(other))
+
+	require.NoError(t, s.CommitTransaction(writer))
+	require.False(t, exists(before, "a"), "transactions read the snapshot they started with")
+	after, err := s.BeginTransaction()
+	require.NoError(t, err)
+	require.True(t, exists(after, "a"))
+	require.NoError(t, s.RollbackTransaction(after))
+
+	discarded, err := s.BeginTransaction()
+	require.NoError(t, err)
+	require.NoError(t, s.SetTransaction(discarded, []byte(store.PendingTransactionPrefix+"b"), []byte("b")))
+	require.NoError(t, s.RollbackTransaction(discarded))
+	after, err = s.BeginTransaction()
+	require.NoError(t, err)
+	require.False(t, exists(after, "b"), "rolled back writes are discarded")
+	require.NoError(t, s.RollbackTransaction(after))
+}
+
+func checkStoreTransactions(t *testing.T, s types.Store) {
+	_, err := s.GetTransaction("missing")
+	require.Error(t, err)
+
+	tx := &types.Transaction{
+		ID:        "tx-1",
+		Timestamp: 1_700_000_000,
+		Outputs:   []types.UTXO{{TransactionID: "tx-1", OwnerAddress: "owner", Amount: 10}},
+		GasFee:    2,
+		Memo:      []byte("memo"),
+	}
+	require.NoError(t, s.SaveTransaction(tx))
+	stored, err := s.GetTransaction("tx-1")
+	require.NoError(t, err)
+	require.Equal(t, tx.ID, stored.ID)
+	require.Equal(t, tx.Outputs, stored.Outputs)
+	require.Equal(t, tx.GasFee, stored.GasFee)
+	require.Equal(t, tx.Memo, stored.Memo)
+}
+
+func checkStorePendingTransactions(t *testing.T, s types.Store) {
+	pendingIDs := func() ([]string, []string) {
+		txs, unreadable, err := s.GetPendingTransactions()
+		require.NoError(t, err)
+		var ids []string
+		for _, tx := range txs {
+			ids = append(ids, tx.ID)
+		}
+		return ids, unreadable
+	}
+	pending := func(id string) *types.Transaction {
+		return &types.Transaction{ID: id, Outputs: []types.UTXO{{TransactionID: id, OwnerAddress: "owner", Amount: 10}}, GasFee: 1}
+	}
+
+	require.NoError(t, s.SavePendingTransaction(pending("a"), nil))
+	require.NoError(t, s.SavePendingTransaction(pending("b"), nil))
+	ids, unreadable := pendingIDs()
+	require.ElementsMatch(t, []string{"a", "b"}, ids)
+	require.Empty(t, unreadable)
+
+	// A replacement is stored and the records it replaces are deleted together
+	require.NoError(t, s.SavePendingTransaction(pending("c"), []string{"a"}))
+	ids, _ = pendingIDs()
+	require.ElementsMatch(t, []string{"b", "c"}, ids)
+
+	// Records that do not decode to the transaction they are stored under are reported
+	dbTx, err := s.BeginTransaction()
+	require.NoError(t, err)
+	require.NoError(t, s.SetTransaction(dbTx, []byte(store.PendingTransactionPrefix+"garbage"), []byte("not a transaction")))
+	require.NoError(t, s.CommitTransaction(dbTx))
+	ids, unreadable = pendingIDs()
+	require.ElementsMatch(t, []string{"b", "c"}, ids)
+	require.Equal(t, []string{"garbage"}, unreadable)
+
+	require.NoError(t, s.DeletePendingTransactions([]string{"b", "c", "garbage"}))
+	ids, unreadable = pendingIDs()
+	require.Empty(t, ids)
+	require.Empty(t, unreadable)
+}
+
+// newConformanceBlock returns a block at index on top of parent, hashed by its index and
+// transaction count.
+func newConformanceBlock(key crypto.PrivateKey, index int64, parent *types.Block, txs ...*types.Transaction) *types.Block {
+	b := &types.Block{
+		Index:              index,
+		Timestamp:          1_700_000_000 + index,
+		Transactions:       txs,
+		ValidatorPublicKey: key.PublicKey(),
+		Validator:          "validator",
+	}
+	if parent != nil {
+		b.PrevHash = parent.Hash
+	}
+	b.Hash = hash.NewHash([]byte{byte(index), byte(len(txs))})
+	return b
+}
+
+// commitTransfer saves a genesis block and commits block 1 spending a funding output of
+// "sender" to "recipient".
+func commitTransfer(t *testing.T, s types.Store) (genesis, block *types.Block, undo *types.BlockUndo) {
+	key, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+
+	genesis = newConformanceBlock(key, 0, nil)
+	require.NoError(t, s.SaveBlock(genesis))
+	funding := types.UTXO{TransactionID: "funding", OwnerAddress: "sender", Amount: 50}
+	require.NoError(t, s.UpdateUTXOs(nil, []types.UTXO{funding}))
+
+	tx := &types.Transaction{
+		ID:      "transfer",
+		Inputs:  []types.UTXO{funding},
+		Outputs: []types.UTXO{{TransactionID: "transfer", OwnerAddress: "recipient", Amount: 50}},
+		Memo:    []byte("rent"),
+		Salt:    []byte("transfer salt"),
+	}
+	block = newConformanceBlock(key, 1, genesis, tx)
+	undo = &types.BlockUndo{BlockHash: block.Hash, Height: 1, Spent: tx.Inputs, Created: tx.Outputs}
+	require.NoError(t, s.CommitBlock(block, tx.Inputs, tx.Outputs, undo))
+	return genesis, block, undo
+}
+
+// requireTransferReverted checks that the block committed by commitTransfer is gone.
+func requireTransferReverted(t *testing.T, s types.Store) {
+	_, err := s.GetBlock(1)
+	require.Error(t, err)
+	_, err = s.GetBlock(0)
+	require.NoError(t, err)
+	number, err := s.GetLastBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, 0, number)
+
+	_, err = s.GetTransactionLocation("transfer")
+	require.Error(t, err)
+	_, err = s.GetTransactionLocationBySalt([]byte("transfer salt"))
+	require.Error(t, err)
+	history, err := s.GetAddressHistory("recipient", 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, history)
+	byMemo, err := s.GetTransactionsByMemo([]byte("rent"), 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, byMemo)
+
+	utxos, err := s.GetUTXOsForAddress("sender")
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	utxos, err = s.GetUTXOsForAddress("recipient")
+	require.NoError(t, err)
+	require.Empty(t, utxos)
+}
+
+func checkStoreBlocks(t *testing.T, s types.Store) {
+	_, err := s.GetLastBlock()
+	require.ErrorIs(t, err, store.ErrNoBlocks)
+	_, err = s.GetLastBlockNumber()
+	require.ErrorIs(t, err, store.ErrNoBlocks)
+
+	genesis, block, undo := commitTransfer(t, s)
+
+	stored, err := s.GetBlock(1)
+	require.NoError(t, err)
+	require.True(t, stored.Hash.Equal(block.Hash))
+	last, err := s.GetLastBlock()
+	require.NoError(t, err)
+	require.True(t, last.Hash.Equal(block.Hash))
+	number, err := s.GetLastBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, 1, number)
+	byHash, err := s.GetBlockByHash(block.Hash)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), byHash.Index)
+	header, err := s.GetHeader(1)
+	require.NoError(t, err)
+	require.True(t, header.PrevHash.Equal(genesis.Hash))
+	storedUndo, err := s.GetBlockUndo(block.Hash)
+	require.NoError(t, err)
+	require.Equal(t, undo.Height, storedUndo.Height)
+	require.Equal(t, undo.Spent, storedUndo.Spent)
+	require.Equal(t, undo.Created, storedUndo.Created)
+	_, err = s.GetBlockUndo(genesis.Hash)
+	require.Error(t, err)
+
+	location, err := s.GetTransactionLocation("transfer")
+	require.NoError(t, err)
+	require.True(t, location.BlockHash.Equal(block.Hash))
+	require.Equal(t, int64(1), location.BlockHeight)
+	history, err := s.GetAddressHistory("recipient", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	history, err = s.GetAddressHistory("recipient", 1, 10)
+	require.NoError(t, err)
+	require.Empty(t, history)
+	_, err = s.GetAddressHistory("recipient", 0, 0)
+	require.Error(t, err)
+	byMemo, err := s.GetTransactionsByMemo([]byte("rent"), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, byMemo, 1)
+	_, err = s.GetTransactionsByMemo(nil, 0, 10)
+	require.Error(t, err)
+	bySalt, err := s.GetTransactionLocationBySalt([]byte("transfer salt"))
+	require.NoError(t, err)
+	require.Equal(t, "transfer", bySalt.TxID)
+
+	utxos, err := s.GetUTXOsForAddress("sender")
+	require.NoError(t, err)
+	require.Empty(t, utxos)
+	utxos, err = s.GetUTXOsForAddress("recipient")
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+
+	// Disconnecting the block restores the outputs it spent and removes its indexes
+	require.NoError(t, s.DisconnectBlock(undo))
+	requireTransferReverted(t, s)
+	_, err = s.GetBlockByHash(block.Hash)
+	require.Error(t, err)
+	_, err = s.GetBlockUndo(block.Hash)
+	require.NoError(t, err, "the undo record is kept for a reorganization back to the block")
+
+	// Deleting a block removes it with its header
+	require.NoError(t, s.DeleteBlock(0))
+	_, err = s.GetHeader(0)
+	require.Error(t, err)
+	_, err = s.GetLastBlockNumber()
+	require.ErrorIs(t, err, store.ErrNoBlocks)
+}
+
+func checkStoreHeaders(t *testing.T, s types.Store) {
+	key, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	blocks := []*types.Block{newConformanceBlock(key, 0, nil)}
+	for i := int64(1); i < 12; i++ {
+		blocks = append(blocks, newConformanceBlock(key, i, blocks[i-1]))
+	}
+	for _, b := range blocks {
+		require.NoError(t, s.SaveBlock(b))
+	}
+
+	// Block numbers are compared as numbers, not as keys
+	number, err := s.GetLastBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, 11, number)
+	last, err := s.GetLastBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(11), last.Index)
+
+	headers, err := s.GetHeaderRange(2, 10)
+	require.NoError(t, err)
+	require.Len(t, headers, 9)
+	for i, header := range headers {
+		require.Equal(t, int64(i+2), header.Index)
+		require.True(t, header.PrevHash.Equal(blocks[i+1].Hash))
+	}
+	headers, err = s.GetHeaderRange(5, 5)
+	require.NoError(t, err)
+	require.Len(t, headers, 1)
+	_, err = s.GetHeaderRange(5, 4)
+	require.Error(t, err)
+	_, err = s.GetHeaderRange(10, 12)
+	require.Error(t, err, "the range ends past the last block")
+}
+
+// conformanceCommitMarker has the encoding of the marker CommitBlock writes first and
+// removes last.
+type conformanceCommitMarker struct {
+	Height    int64     `cbor:"1,keyasint"`
+	BlockHash hash.Hash `cbor:"2,keyasint"`
+}
+
+func checkStoreRecovery(t *testing.T, s types.Store) {
+	height, recovered, err := s.RecoverIncompleteBlock()
+	require.NoError(t, err)
+	require.False(t, recovered)
+	require.Zero(t, height)
+
+	_, block, _ := commitTransfer(t, s)
+	height, recovered, err = s.RecoverIncompleteBlock()
+	require.NoError(t, err)
+	require.False(t, recovered, "a completed commit leaves no marker")
+
+	// Leave the marker of block 1 behind as an interrupted commit would
+	marker, err := cbor.Marshal(&conformanceCommitMarker{Height: 1, BlockHash: block.Hash})
+	require.NoError(t, err)
+	ctx, err := s.BeginTransaction()
+	require.NoError(t, err)
+	require.NoError(t, s.SetTransaction(ctx, []byte(store.CommitMarkerKey), marker))
+	require.NoError(t, s.CommitTransaction(ctx))
+
+	height, recovered, err = s.RecoverIncompleteBlock()
+	require.NoError(t, err)
+	require.True(t, recovered)
+	require.Equal(t, int64(1), height)
+	requireTransferReverted(t, s)
+
+	_, recovered, err = s.RecoverIncompleteBlock()
+	require.NoError(t, err)
+	require.False(t, recovered)
+}
+
+func checkStorePublicKeys(t *testing.T, s types.Store) {
+	key, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	addr, err := key.PublicKey().Address()
+	require.NoError(t, err)
+
+	_, err = s.GetPublicKey(*addr)
+	require.Error(t, err)
+	require.NoError(t, s.SavePublicKey(key.PublicKey()))
+	stored, err := s.GetPublicKey(*addr)
+	require.NoError(t, err)
+	pub := key.PublicKey()
+	require.True(t, stored.Equal(&pub))
+
+	all, err := s.GetAllPublicKeys()
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"strconv"
+	"testing"
+
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/store"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func TestSchemaMigrations(t *testing.T) {
+	dir := t.TempDir()
+
+	// A new database is stamped with the current version
+	database, err := store.NewDatabase(dir)
+	require.NoError(t, err)
+	version, err := database.SchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, store.CurrentSchemaVersion, version)
+
+	// Turn it into a database written before versioning, with pool records under
+	// their old keys and a block without salt index entries
+	require.NoError(t, database.Delete([]byte(store.SchemaVersionKey)))
+	require.NoError(t, database.Set([]byte("transaction-a"), []byte("record a")))
+	require.NoError(t, database.Set([]byte("transaction-b"), []byte("record b")))
+	block := &types.Block{
+		Index:        1,
+		Hash:         hash.NewHash([]byte("block 1")),
+		Transactions: []*types.Transaction{{ID: "salted", Salt: []byte("salt")}, {ID: "unsalted"}},
+	}
+	blockData, err := block.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, database.Set([]byte(store.BlockPrefix+"1"), blockData))
+	require.NoError(t, database.Close())
+
+	// A dry run at open time leaves the database at its old version
+	database, err = store.NewDatabaseWithOptions(dir, store.DatabaseOptions{DryRunMigrations: true})
+	require.NoError(t, err)
+	version, err = database.SchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+	require.NoError(t, database.Close())
+
+	// A dry run reports the migration without writing anything
+	database, err = store.NewDatabaseWithOptions(dir, store.DatabaseOptions{SkipMigrations: true})
+	require.NoError(t, err)
+	results, err := database.Migrate(true)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, 1, results[0].Version)
+	require.Equal(t, 4, results[0].Keys)
+	require.Equal(t, 2, results[1].Version)
+	require.Equal(t, 1, results[1].Keys)
+	version, err = database.SchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+	_, err = database.Get([]byte("transaction-a"))
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	database, err = store.NewDatabase(dir)
+	require.NoError(t, err)
+	version, err = database.SchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, store.CurrentSchemaVersion, version)
+	_, err = database.Get([]byte("transaction-a"))
+	require.ErrorIs(t, err, badger.ErrKeyNotFound)
+	record, err := database.Get([]byte(store.PendingTransactionPrefix + "b"))
+	require.NoError(t, err)
+	require.Equal(t, "record b", string(record))
+	s, err := store.NewStore(database, make([]byte, 32))
+	require.NoError(t, err)
+	loc, err := s.GetTransactionLocationBySalt([]byte("salt"))
+	require.NoError(t, err)
+	require.Equal(t, "salted", loc.TxID)
+	require.True(t, loc.BlockHash.Equal(block.Hash))
+
+	// Databases written by a newer schema are refused
+	require.NoError(t, database.Set([]byte(store.SchemaVersionKey), []byte(strconv.Itoa(store.CurrentSchemaVersion+1))))
+	require.NoError(t, database.Close())
+	_, err = store.NewDatabase(dir)
+	require.ErrorIs(t, err, store.ErrSchemaTooNew)
+	_, err = store.NewDatabaseWithOptions(dir, store.DatabaseOptions{SkipMigrations: true})
+	require.ErrorIs(t, err, store.ErrSchemaTooNew)
+}
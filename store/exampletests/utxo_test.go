@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/thrylos-labs/thrylos/amount"
 	"github.com/thrylos-labs/thrylos/store"
 	"github.com/thrylos-labs/thrylos/types"
 )
@@ -65,7 +66,7 @@ func TestUTXOCache(t *testing.T) {
 			0,
 			"test-tx-1",
 			"addr1_valid_address_format",
-			100.0,
+			100,
 			false,
 		)
 		assert.NotNil(t, utxo)
@@ -107,7 +108,7 @@ func TestUTXOCache(t *testing.T) {
 				i,
 				fmt.Sprintf("tx-%d", i),
 				"addr1_valid_address_format",
-				float64(100*(i+1)),
+				amount.Amount(100*(i+1)),
 				false,
 			)
 			assert.NotNil(t, utxo)
@@ -154,7 +155,7 @@ func TestUTXOCacheAdvanced(t *testing.T) {
 						j,
 						fmt.Sprintf("tx-%d-%d", routineID, j),
 						"addr1_valid_address_format",
-						float64(100*(j+1)),
+						amount.Amount(100*(j+1)),
 						false,
 					)
 					key := utxo.Key()
@@ -192,7 +193,7 @@ func TestUTXOCacheAdvanced(t *testing.T) {
 				i,
 				fmt.Sprintf("tx-%d", i),
 				"addr1_valid_address_format",
-				float64(100*(i+1)),
+				amount.Amount(100*(i+1)),
 				false,
 			)
 			key := utxo.Key()
@@ -219,7 +220,7 @@ func TestUTXOCacheAdvanced(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Add and remove a UTXO
-		utxo := types.CreateUTXO("utxo-1", 0, "tx-1", "addr1_valid_address_format", 100.0, false)
+		utxo := types.CreateUTXO("utxo-1", 0, "tx-1", "addr1_valid_address_format", 100, false)
 		key := utxo.Key()
 
 		added := cache.Add(key, utxo)
@@ -229,7 +230,7 @@ func TestUTXOCacheAdvanced(t *testing.T) {
 		assert.True(t, removed)
 
 		// Try to add a new UTXO with the same key
-		newUTXO := types.CreateUTXO("utxo-2", 0, "tx-1", "addr1_valid_address_format", 200.0, false)
+		newUTXO := types.CreateUTXO("utxo-2", 0, "tx-1", "addr1_valid_address_format", 200, false)
 		added = cache.Add(key, newUTXO)
 		assert.True(t, added)
 
@@ -249,7 +250,7 @@ func TestUTXOCacheAdvanced(t *testing.T) {
 		assert.True(t, added, "Should handle nil UTXO")
 
 		// Test with empty key
-		utxo := types.CreateUTXO("utxo-1", 0, "", "addr1_valid_address_format", 100.0, false)
+		utxo := types.CreateUTXO("utxo-1", 0, "", "addr1_valid_address_format", 100, false)
 		key := utxo.Key()
 		added = cache.Add(key, utxo)
 		assert.True(t, added, "Should handle empty transaction ID")
@@ -279,7 +280,7 @@ func TestUTXOCacheAdvanced(t *testing.T) {
 				i,
 				fmt.Sprintf("tx-%d", i),
 				"addr1_valid_address_format",
-				float64(100*(i+1)),
+				amount.Amount(100*(i+1)),
 				false,
 			)
 			key := utxo.Key()
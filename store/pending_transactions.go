@@ -0,0 +1,80 @@
+package store
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+func pendingTransactionKey(txID string) []byte {
+	return []byte(PendingTransactionPrefix + txID)
+}
+
+// SavePendingTransaction stores the pool record of tx and deletes the records of the
+// pooled transactions in removed, in one database transaction.
+func (s *store) SavePendingTransaction(tx *types.Transaction, removed []string) error {
+	data, err := tx.Marshal()
+	if err != nil {
+		return fmt.Errorf("error marshaling transaction: %v", err)
+	}
+	return s.db.GetDB().Update(func(txn *badger.Txn) error {
+		if err := txn.Set(pendingTransactionKey(tx.ID), data); err != nil {
+			return fmt.Errorf("error storing transaction: %v", err)
+		}
+		return deletePendingTransactions(txn, removed)
+	})
+}
+
+// DeletePendingTransactions deletes the pool records of the given transactions.
+func (s *store) DeletePendingTransactions(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.db.GetDB().Update(func(txn *badger.Txn) error {
+		return deletePendingTransactions(txn, ids)
+	})
+}
+
+func deletePendingTransactions(txn *badger.Txn, ids []string) error {
+	for _, id := range ids {
+		if err := txn.Delete(pendingTransactionKey(id)); err != nil {
+			return fmt.Errorf("error deleting transaction %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// GetPendingTransactions returns the transactions of the stored pool records, and the
+// IDs of the records that no longer decode to the transaction they are stored under.
+func (s *store) GetPendingTransactions() ([]*types.Transaction, []string, error) {
+	var txs []*types.Transaction
+	var unreadable []string
+	err := s.db.GetDB().View(func(txn *badger.Txn) error {
+		prefix := []byte(PendingTransactionPrefix)
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.ValidForPrefix(prefix); it.Next() {
+			id := strings.TrimPrefix(string(it.Item().Key()), PendingTransactionPrefix)
+			tx := &types.Transaction{}
+			err := it.Item().Value(func(val []byte) error {
+				return tx.Unmarshal(val)
+			})
+			if err != nil || tx.ID != id {
+				log.Printf("Unreadable pool record for transaction %s: %v", id, err)
+				unreadable = append(unreadable, id)
+				continue
+			}
+			txs = append(txs, tx)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read pool records: %v", err)
+	}
+	return txs, unreadable, nil
+}
@@ -10,4 +10,17 @@ const (
 	PrivateKeyPrifx   = "pk-"
 	SignaturePrifx    = "sn-"
 	ValidatorPrefix   = "vd-"
+	BlockUndoPrefix   = "ud-" // Per-block UTXO undo records, keyed by block hash
+	HeaderPrefix      = "hd-" // Block headers, keyed by block number
+	CommitMarkerKey   = "cm-" // Block whose commit or disconnect is in progress
+	SchemaVersionKey  = "sv-" // Schema version the database was written with
+
+	PendingTransactionPrefix = "pt-" // Transactions waiting in the pool, keyed by transaction ID
+
+	// Secondary indexes, written together with the block they describe
+	BlockHashIndexPrefix  = "bh-" // Block hash -> block number
+	TxLocationIndexPrefix = "tl-" // Transaction ID -> location in the chain
+	AddressHistoryPrefix  = "ah-" // Address, block number and position -> location of a transaction
+	MemoIndexPrefix       = "mm-" // Memo hash, block number and position -> location of a transaction
+	SaltIndexPrefix       = "sa-" // Salt hash -> location of the transaction carrying it
 )
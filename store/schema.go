@@ -0,0 +1,295 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// CurrentSchemaVersion is the version of the key layout this node reads and writes.
+// A change to the layout of existing keys bumps it and registers a migration from the
+// previous version.
+const CurrentSchemaVersion = 2
+
+// ErrSchemaTooNew is returned when opening a database written by a newer node.
+var ErrSchemaTooNew = errors.New("database schema is newer than this node supports")
+
+// Pool records were keyed transaction-<id> before schema version 1.
+const legacyPendingTransactionPrefix = "transaction-"
+
+// migration upgrades a database from schema version version-1 to version. A migration
+// interrupted between batches runs again from the start at the next open, so it must
+// be safe to repeat.
+type migration struct {
+	version     int
+	description string
+	migrate     func(w *migrationWriter) error
+}
+
+// migrations holds one migration per schema version, in order.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "move pool records from transaction-<id> to pt-<id> keys",
+		migrate:     renamePrefix(legacyPendingTransactionPrefix, PendingTransactionPrefix),
+	},
+	{
+		version:     2,
+		description: "index the salts of stored transactions under sa-<salt hash>",
+		migrate:     indexStoredSalts,
+	},
+}
+
+// Keys a migration changes between progress log lines.
+const migrationProgressInterval = 10_000
+
+// MigrationResult reports a migration that was applied, or that a dry run found
+// pending.
+type MigrationResult struct {
+	Version     int
+	Description string
+	Keys        int // Keys written or deleted, or that would be in a dry run
+}
+
+// migrationWriter is handed to a migration to read the database and write its
+// changes. Writes are batched like block commits; in a dry run they are only counted.
+type migrationWriter struct {
+	db      *badger.DB
+	batch   *batchWriter // nil in a dry run
+	version int
+	keys    int
+}
+
+// View runs fn in a read-only transaction that sees the database as it was before the
+// migration.
+func (w *migrationWriter) View(fn func(txn *badger.Txn) error) error {
+	return w.db.View(fn)
+}
+
+func (w *migrationWriter) Set(key, val []byte) error {
+	if w.batch != nil {
+		if err := w.batch.Set(key, val); err != nil {
+			return err
+		}
+	}
+	w.progress()
+	return nil
+}
+
+func (w *migrationWriter) Delete(key []byte) error {
+	if w.batch != nil {
+		if err := w.batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	w.progress()
+	return nil
+}
+
+func (w *migrationWriter) progress() {
+	w.keys++
+	if w.keys%migrationProgressInterval == 0 {
+		log.Printf("Schema migration to version %d: %d keys", w.version, w.keys)
+	}
+}
+
+// renamePrefix returns a migration that moves every key starting with from to the same
+// key starting with to.
+func renamePrefix(from, to string) func(w *migrationWriter) error {
+	return func(w *migrationWriter) error {
+		return w.View(func(txn *badger.Txn) error {
+			opts := badger.DefaultIteratorOptions
+			opts.Prefix = []byte(from)
+			it := txn.NewIterator(opts)
+			defer it.Close()
+			for it.Rewind(); it.Valid(); it.Next() {
+				item := it.Item()
+				key := item.KeyCopy(nil)
+				val, err := item.ValueCopy(nil)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %v", key, err)
+				}
+				renamed := append([]byte(to), bytes.TrimPrefix(key, []byte(from))...)
+				if err := w.Set(renamed, val); err != nil {
+					return err
+				}
+				if err := w.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// indexStoredSalts writes the salt index entries of every stored block.
+func indexStoredSalts(w *migrationWriter) error {
+	return w.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(BlockPrefix)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			data, err := item.ValueCopy(nil)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %v", item.Key(), err)
+			}
+			var b types.Block
+			if err := b.Unmarshal(data); err != nil {
+				return fmt.Errorf("failed to decode %s: %v", item.Key(), err)
+			}
+			for position, tx := range b.Transactions {
+				if len(tx.Salt) == 0 {
+					continue
+				}
+				loc := &types.TransactionLocation{TxID: tx.ID, BlockHeight: b.Index, BlockHash: b.Hash, Position: position}
+				locData, err := loc.Marshal()
+				if err != nil {
+					return fmt.Errorf("error marshaling location of transaction %s: %v", tx.ID, err)
+				}
+				if err := w.Set(saltIndexKey(tx.Salt), locData); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// SchemaVersion returns the schema version recorded in the database, 0 for databases
+// written before versioning.
+func (d *Database) SchemaVersion() (int, error) {
+	version := 0
+	err := d.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(SchemaVersionKey))
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			version, err = strconv.Atoi(string(val))
+			return err
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %v", err)
+	}
+	return version, nil
+}
+
+// checkSchemaVersion returns the schema version of the database, or ErrSchemaTooNew
+// when it was written by a newer schema.
+func (d *Database) checkSchemaVersion() (int, error) {
+	version, err := d.SchemaVersion()
+	if err != nil {
+		return 0, err
+	}
+	if version > CurrentSchemaVersion {
+		return 0, fmt.Errorf("%w: database is at version %d, this node supports up to %d",
+			ErrSchemaTooNew, version, CurrentSchemaVersion)
+	}
+	return version, nil
+}
+
+// Migrate brings the database to CurrentSchemaVersion, running the pending migrations
+// in order and recording the version after each one. A new, empty database is only
+// stamped with the current version. In a dry run the migrations count the keys they
+// would change and nothing is written. Databases written by a newer schema are refused
+// with ErrSchemaTooNew.
+func (d *Database) Migrate(dryRun bool) ([]MigrationResult, error) {
+	if err := checkMigrations(); err != nil {
+		return nil, err
+	}
+	version, err := d.checkSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if version == CurrentSchemaVersion {
+		return nil, nil
+	}
+	if version == 0 {
+		empty, err := d.isEmpty()
+		if err != nil {
+			return nil, err
+		}
+		if empty {
+			if dryRun {
+				return nil, nil
+			}
+			return nil, d.Set([]byte(SchemaVersionKey), []byte(strconv.Itoa(CurrentSchemaVersion)))
+		}
+	}
+
+	var results []MigrationResult
+	for _, m := range migrations[version:] {
+		if dryRun {
+			log.Printf("Dry run of schema migration to version %d: %s", m.version, m.description)
+		} else {
+			log.Printf("Migrating database schema to version %d: %s", m.version, m.description)
+		}
+		w := &migrationWriter{db: d.db, version: m.version}
+		if !dryRun {
+			w.batch = newBatchWriter(d.db)
+		}
+		if err := d.runMigration(m, w); err != nil {
+			return results, fmt.Errorf("schema migration to version %d failed: %v", m.version, err)
+		}
+		results = append(results, MigrationResult{Version: m.version, Description: m.description, Keys: w.keys})
+		log.Printf("Schema migration to version %d done: %d keys", m.version, w.keys)
+	}
+	return results, nil
+}
+
+// runMigration runs m and, unless w is a dry run, commits its changes together with
+// the new schema version.
+func (d *Database) runMigration(m migration, w *migrationWriter) error {
+	if w.batch != nil {
+		defer w.batch.Discard()
+	}
+	if err := m.migrate(w); err != nil {
+		return err
+	}
+	if w.batch == nil {
+		return nil
+	}
+	if err := w.batch.Set([]byte(SchemaVersionKey), []byte(strconv.Itoa(m.version))); err != nil {
+		return err
+	}
+	return w.batch.Commit()
+}
+
+func (d *Database) isEmpty() (bool, error) {
+	empty := true
+	err := d.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		it.Rewind()
+		empty = !it.Valid()
+		return nil
+	})
+	return empty, err
+}
+
+// checkMigrations verifies that the registry holds one migration per version up to
+// CurrentSchemaVersion.
+func checkMigrations() error {
+	for i, m := range migrations {
+		if m.version != i+1 {
+			return fmt.Errorf("schema migration %d registered as version %d", i+1, m.version)
+		}
+	}
+	if len(migrations) != CurrentSchemaVersion {
+		return fmt.Errorf("%d schema migrations registered for schema version %d", len(migrations), CurrentSchemaVersion)
+	}
+	return nil
+}
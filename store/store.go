@@ -5,6 +5,7 @@ import (
 
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"regexp"
@@ -15,7 +16,6 @@ import (
 
 	badger "github.com/dgraph-io/badger/v3" // Note the /v3 suffix
 	"github.com/fxamacker/cbor/v2"
-	"github.com/thrylos-labs/thrylos"
 	"github.com/thrylos-labs/thrylos/amount"
 	"github.com/thrylos-labs/thrylos/crypto"
 	"github.com/thrylos-labs/thrylos/crypto/address"
@@ -23,7 +23,6 @@ import (
 	"github.com/thrylos-labs/thrylos/crypto/hash"
 	"github.com/thrylos-labs/thrylos/shared"
 	"github.com/thrylos-labs/thrylos/types"
-	"golang.org/x/crypto/blake2b"
 )
 
 type store struct {
@@ -70,7 +69,7 @@ func GetUTXO(txID string, index int) (*types.UTXO, error) {
 
 // UTXO
 
-func (s *store) CreateAndStoreUTXO(id, txID string, index int, owner string, amount float64) error {
+func (s *store) CreateAndStoreUTXO(id, txID string, index int, owner string, amount amount.Amount) error {
 	utxo := types.CreateUTXO(id, index, txID, owner, amount, false)
 	db := s.db.GetDB()
 
@@ -107,7 +106,7 @@ func GenerateUTXOKey(ownerAddress string, transactionID string, index int) strin
 	return fmt.Sprintf("utxo-%s-%s-%d", ownerAddress, transactionID, index)
 }
 
-func (s *store) CreateUTXO(id, txID string, index int, address string, amount float64) (types.UTXO, error) {
+func (s *store) CreateUTXO(id, txID string, index int, address string, amount amount.Amount) (types.UTXO, error) {
 	utxo := types.CreateUTXO(id, index, txID, address, amount, false)
 
 	// Marshal UTXO to JSON
@@ -565,7 +564,7 @@ func (s *store) GetUTXOsByAddress(address string) (map[string][]types.UTXO, erro
 func (s *store) RetrieveTransaction(txn *badger.Txn, transactionID string) (*types.Transaction, error) {
 	var tx types.Transaction
 
-	key := []byte("transaction-" + transactionID)
+	key := []byte(PendingTransactionPrefix + transactionID)
 
 	item, err := txn.Get(key)
 	if err != nil {
@@ -573,7 +572,7 @@ func (s *store) RetrieveTransaction(txn *badger.Txn, transactionID string) (*typ
 	}
 
 	err = item.Value(func(val []byte) error {
-		return json.Unmarshal(val, &tx)
+		return tx.Unmarshal(val)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshaling transaction: %v", err)
@@ -585,79 +584,67 @@ func (s *store) RetrieveTransaction(txn *badger.Txn, transactionID string) (*typ
 func (s *store) SendTransaction(fromAddress, toAddress string, amount int, privKey crypto.PrivateKey) (bool, error) {
 	db := s.db.GetDB()
 
-	// Step 1: Create transaction data
-	transactionData := map[string]interface{}{
-		"from":      fromAddress,
-		"to":        toAddress,
-		"amount":    amount,
-		"timestamp": time.Now().Unix(),
-	}
-
-	// Step 2: Serialize transaction data to JSON
-	jsonData, err := json.Marshal(transactionData)
+	transaction, err := sealTransaction(s.encryptionKey, fromAddress, toAddress, amount, privKey)
 	if err != nil {
-		return false, fmt.Errorf("error serializing transaction data: %v", err)
+		return false, err
 	}
 
-	// Step 3: Encrypt the transaction data
-	encryptedData, err := encryption.EncryptWithAES(s.encryptionKey, jsonData)
+	// Serialize the transaction
+	txData, err := transaction.Marshal()
 	if err != nil {
-		return false, fmt.Errorf("error encrypting transaction data: %v", err)
-	}
-
-	// Step 4: Create hash of the encrypted data
-	dataHash := hash.NewHash(encryptedData)
-
-	// Step 5: Sign the hashed data
-	signature := privKey.Sign(dataHash.Bytes())
-	if signature == nil {
-		return false, fmt.Errorf("error creating signature")
+		return false, fmt.Errorf("error marshaling transaction: %v", err)
 	}
 
-	// Step 6: Store the encrypted transaction and signature in the database atomically
+	// Store the transaction, which carries its sealed transfer data
 	txn := db.NewTransaction(true)
 	defer txn.Discard()
 
-	// Create transaction ID using hash of encrypted data
-	txID := dataHash.String()
-
-	// Create and store a new Transaction object
-	addr := address.NullAddress()
-	if err := addr.Unmarshal([]byte(fromAddress)); err != nil {
-		return false, fmt.Errorf("error creating address: %v", err)
+	if err := txn.Set([]byte("tx-"+transaction.ID), txData); err != nil {
+		return false, fmt.Errorf("error storing transaction data: %v", err)
 	}
 
-	// Create and store a new Transaction object
-	transaction := &types.Transaction{
-		ID:            txID,
-		Timestamp:     time.Now().Unix(),
-		SenderAddress: *addr, // Use the address pointer
-		Signature:     signature,
-		// Add other fields as needed
+	// Commit the transaction
+	if err := txn.Commit(); err != nil {
+		return false, fmt.Errorf("transaction commit failed: %v", err)
 	}
 
-	// Serialize the transaction
-	txData, err := transaction.Marshal()
+	return true, nil
+}
+
+// sealTransaction returns the signed transaction recording a transfer sent by
+// SendTransaction. The transfer is only carried in the encrypted fields, sealed for the
+// key pair derived from the store's encryption key, and the transaction is identified
+// by the hash of its envelopes.
+func sealTransaction(encryptionKey []byte, fromAddress, toAddress string, value int, privKey crypto.PrivateKey) (*types.Transaction, error) {
+	recipient, _, err := encryption.DeriveEncryptionKeyPair(encryptionKey)
 	if err != nil {
-		return false, fmt.Errorf("error marshaling transaction: %v", err)
+		return nil, fmt.Errorf("error deriving the encryption key pair: %v", err)
 	}
 
-	// Store transaction data
-	if err := txn.Set([]byte("tx-"+txID), txData); err != nil {
-		return false, fmt.Errorf("error storing transaction data: %v", err)
+	addr := address.NullAddress()
+	if err := addr.Unmarshal([]byte(fromAddress)); err != nil {
+		return nil, fmt.Errorf("error creating address: %v", err)
 	}
 
-	// Store encrypted payload
-	if err := txn.Set([]byte("tx-payload-"+txID), encryptedData); err != nil {
-		return false, fmt.Errorf("error storing encrypted payload: %v", err)
+	transaction := &types.Transaction{
+		Timestamp:     time.Now().Unix(),
+		SenderAddress: *addr,
+		Outputs:       []types.UTXO{{OwnerAddress: toAddress, Amount: amount.Amount(value)}},
 	}
-
-	// Commit the transaction
-	if err := txn.Commit(); err != nil {
-		return false, fmt.Errorf("transaction commit failed: %v", err)
+	if err := transaction.SealData(recipient); err != nil {
+		return nil, fmt.Errorf("error encrypting transaction data: %v", err)
 	}
+	transaction.Outputs = nil
 
-	return true, nil
+	// Identify and sign the transaction by the hash of its envelopes
+	envelopes := append(append([]byte(nil), transaction.EncryptedAESKey...), transaction.EncryptedInputs...)
+	dataHash := hash.NewHash(append(envelopes, transaction.EncryptedOutputs...))
+	transaction.Signature = privKey.Sign(dataHash.Bytes())
+	if transaction.Signature == nil {
+		return nil, fmt.Errorf("error creating signature")
+	}
+	transaction.ID = dataHash.String()
+	return transaction, nil
 }
 
 func (s *store) storeTransactionInTxn(txn *badger.Txn, encryptedData, signature []byte, fromAddress, toAddress string) error {
@@ -770,50 +757,12 @@ func (s *store) SetTransaction(txn types.TransactionContext, key []byte, value [
 	return badgerTxn.Set(key, value)
 }
 
-func (s *store) AddTransaction(tx *thrylos.Transaction) error {
-	db := s.db.GetDB()
-	txn := db.NewTransaction(true)
-	defer txn.Discard()
-
-	txJSON, err := json.Marshal(tx)
-	if err != nil {
-		return fmt.Errorf("error marshaling transaction: %v", err)
-	}
-
-	key := []byte("transaction-" + tx.Id)
-
-	if err := txn.Set(key, txJSON); err != nil {
-		return fmt.Errorf("error storing transaction in BadgerDB: %v", err)
-	}
-
-	if err := txn.Commit(); err != nil {
-		return fmt.Errorf("transaction commit failed: %v", err)
-	}
-
-	return nil
-}
-
-func (s *store) VerifyTransactionSignature(tx *types.Transaction, pubKey *crypto.PublicKey) error {
-	// Get the transaction bytes without signature
-	txBytes, err := shared.SerializeTransactionWithoutSignature(tx)
-	if err != nil {
-		return fmt.Errorf("error serializing transaction: %v", err)
-	}
-
-	// Hash the transaction bytes
-	hasher, err := blake2b.New256(nil)
-	if err != nil {
-		return fmt.Errorf("error creating hasher: %v", err)
-	}
-	hasher.Write(txBytes)
-	hashedTx := hasher.Sum(nil)
-
-	// Verify the signature using the public key
-	err = tx.Signature.Verify(pubKey, hashedTx)
-	if err != nil {
+// VerifyTransactionSignature checks the transaction signature against pubKey using the
+// signing preimage for chainID.
+func (s *store) VerifyTransactionSignature(tx *types.Transaction, pubKey *crypto.PublicKey, chainID string) error {
+	if err := tx.VerifySignature(*pubKey, chainID); err != nil {
 		return fmt.Errorf("invalid transaction signature: %v", err)
 	}
-
 	return nil
 }
 
@@ -828,7 +777,7 @@ func (s *store) TransactionExists(txContext types.TransactionContext, txID strin
 		return false, fmt.Errorf("invalid transaction context: nil badger transaction")
 	}
 
-	key := []byte("transaction-" + txID)
+	key := []byte(PendingTransactionPrefix + txID)
 	_, err := badgerTxn.Get(key)
 	if err == badger.ErrKeyNotFound {
 		return false, nil
@@ -928,36 +877,55 @@ func (s *store) GetLastBlockIndex() (int, error) {
 	return lastIndex, nil
 }
 
+// ErrNoBlocks is returned when the database does not contain any block yet.
+var ErrNoBlocks = errors.New("no blocks found in the database")
+
+// lastBlockNumber scans the block keys and returns the highest block number.
+// Block keys are not zero padded, so the lexicographic order of the keys can
+// not be used to find the tip.
+func lastBlockNumber(txn *badger.Txn) (int, error) {
+	lastIndex := -1
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	prefix := []byte(BlockPrefix)
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		blockNumberStr := strings.TrimPrefix(string(it.Item().Key()), BlockPrefix)
+		blockNumber, err := strconv.Atoi(blockNumberStr)
+		if err != nil {
+			log.Printf("Error parsing block number from key %s: %v", it.Item().Key(), err)
+			return -1, fmt.Errorf("error parsing block number: %v", err)
+		}
+		if blockNumber > lastIndex {
+			lastIndex = blockNumber
+		}
+	}
+	if lastIndex == -1 {
+		return -1, ErrNoBlocks
+	}
+	return lastIndex, nil
+}
+
 func (s *store) GetLastBlock() (*types.Block, error) {
 	var blockData []byte
-	var lastIndex int = -1
 	db := s.db.GetDB()
 	err := db.View(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		opts.Reverse = true
-		it := txn.NewIterator(opts)
-		defer it.Close()
-
-		for it.Rewind(); it.Valid(); it.Next() {
-			item := it.Item()
-			key := item.Key()
-			if strings.HasPrefix(string(key), BlockPrefix) {
-				blockNumberStr := strings.TrimPrefix(string(key), BlockPrefix)
-				var parseErr error
-				lastIndex, parseErr = strconv.Atoi(blockNumberStr)
-				if parseErr != nil {
-					log.Printf("Failed to parse block number: %v", parseErr)
-					return fmt.Errorf("error parsing block number: %v", parseErr)
-				}
-				blockData, parseErr = item.ValueCopy(nil)
-				if parseErr != nil {
-					log.Printf("Failed to retrieve block data: %v", parseErr)
-					return fmt.Errorf("error retrieving block data: %v", parseErr)
-				}
-				return nil
-			}
+		lastIndex, err := lastBlockNumber(txn)
+		if err != nil {
+			return err
 		}
-		return fmt.Errorf("no blocks found in the database")
+		item, err := txn.Get([]byte(fmt.Sprintf("%s%d", BlockPrefix, lastIndex)))
+		if err != nil {
+			return fmt.Errorf("error retrieving block data: %v", err)
+		}
+		blockData, err = item.ValueCopy(nil)
+		if err != nil {
+			log.Printf("Failed to retrieve block data: %v", err)
+			return fmt.Errorf("error retrieving block data: %v", err)
+		}
+		return nil
 	})
 
 	if err != nil {
@@ -965,11 +933,6 @@ func (s *store) GetLastBlock() (*types.Block, error) {
 		return nil, err
 	}
 
-	if lastIndex == -1 {
-		log.Printf("No blocks found in the database")
-		return nil, fmt.Errorf("no blocks found in the database")
-	}
-
 	var b types.Block
 	err = b.Unmarshal(blockData)
 	if err != nil {
@@ -981,29 +944,12 @@ func (s *store) GetLastBlock() (*types.Block, error) {
 }
 
 func (s *store) GetLastBlockNumber() (int, error) {
-	var lastIndex int = -1 // Default to -1 to indicate no blocks if none found
+	lastIndex := -1 // Default to -1 to indicate no blocks if none found
 	db := s.db.GetDB()
 	err := db.View(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		opts.Reverse = true // Iterate in reverse order to get the latest block first
-		it := txn.NewIterator(opts)
-		defer it.Close()
-
-		if it.Rewind(); it.Valid() {
-			item := it.Item()
-			key := item.Key()
-			if strings.HasPrefix(string(key), BlockPrefix) {
-				blockNumberStr := strings.TrimPrefix(string(key), BlockPrefix)
-				var parseErr error
-				lastIndex, parseErr = strconv.Atoi(blockNumberStr)
-				if parseErr != nil {
-					log.Printf("Error parsing block number from key %s: %v", key, parseErr)
-					return parseErr
-				}
-				return nil // Stop after the first (latest) block
-			}
-		}
-		return fmt.Errorf("no blocks found in the database")
+		var err error
+		lastIndex, err = lastBlockNumber(txn)
+		return err
 	})
 
 	if err != nil {
@@ -1012,7 +958,6 @@ func (s *store) GetLastBlockNumber() (int, error) {
 	}
 
 	return lastIndex, nil
-
 }
 
 func (s *store) GetBlock(blockNumber uint32) (*types.Block, error) {
@@ -1045,10 +990,15 @@ func (s *store) SaveBlock(b *types.Block) error {
 		log.Printf("Failed to marshal block %d: %v", b.Index, err)
 		return fmt.Errorf("error marshaling block %d: %v", b.Index, err)
 	}
+	headerData, err := b.Header().Marshal()
+	if err != nil {
+		log.Printf("Failed to marshal header of block %d: %v", b.Index, err)
+		return fmt.Errorf("error marshaling header of block %d: %v", b.Index, err)
+	}
 	db := s.db.GetDB()
 	err = db.Update(func(txn *badger.Txn) error {
 		log.Printf("Storing data at key: %s", key)
-		return txn.Set([]byte(key), blockData)
+		return putBlock(badgerWriter{txn}, b, blockData, headerData)
 	})
 	if err != nil {
 		log.Printf("Error inserting block %d: %v", b.Index, err)
@@ -1058,6 +1008,109 @@ func (s *store) SaveBlock(b *types.Block) error {
 	return nil
 }
 
+// DeleteBlock removes the block stored at the given height, used when a reorganization
+// switches to a shorter branch.
+func (s *store) DeleteBlock(blockNumber uint32) error {
+	db := s.db.GetDB()
+	err := db.Update(func(txn *badger.Txn) error {
+		return deleteBlock(badgerWriter{txn}, blockNumber)
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting block %d: %v", blockNumber, err)
+	}
+	return nil
+}
+
+// putBlock writes a marshaled block, its header and its indexes at the block height.
+func putBlock(w txnWriter, b *types.Block, blockData, headerData []byte) error {
+	// A block replaced by a reorganization takes its index entries with it
+	if err := removeBlockIndexes(w, uint32(b.Index)); err != nil {
+		return err
+	}
+	if err := w.Set([]byte(fmt.Sprintf("%s%d", BlockPrefix, b.Index)), blockData); err != nil {
+		return err
+	}
+	if err := w.Set([]byte(fmt.Sprintf("%s%d", HeaderPrefix, b.Index)), headerData); err != nil {
+		return err
+	}
+	return putBlockIndexes(w, b)
+}
+
+// deleteBlock removes the block at the given height with its header and indexes.
+func deleteBlock(w txnWriter, blockNumber uint32) error {
+	if err := removeBlockIndexes(w, blockNumber); err != nil {
+		return err
+	}
+	if err := w.Delete([]byte(fmt.Sprintf("%s%d", BlockPrefix, blockNumber))); err != nil {
+		return err
+	}
+	return w.Delete([]byte(fmt.Sprintf("%s%d", HeaderPrefix, blockNumber)))
+}
+
+// GetHeader retrieves the header of the block at the given height. Blocks stored before
+// headers were kept separately have their header derived from the full block.
+func (s *store) GetHeader(blockNumber uint32) (*types.BlockHeader, error) {
+	data, err := s.db.Get([]byte(fmt.Sprintf("%s%d", HeaderPrefix, blockNumber)))
+	if err == badger.ErrKeyNotFound {
+		block, err := s.GetBlock(blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		return block.Header(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve header %d: %v", blockNumber, err)
+	}
+
+	var header types.BlockHeader
+	if err := header.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal header %d: %v", blockNumber, err)
+	}
+	return &header, nil
+}
+
+// GetHeaderRange retrieves the headers from start to end inclusive, in height order.
+func (s *store) GetHeaderRange(start, end uint32) ([]*types.BlockHeader, error) {
+	if end < start {
+		return nil, fmt.Errorf("invalid header range %d-%d", start, end)
+	}
+	headers := make([]*types.BlockHeader, 0, end-start+1)
+	for number := start; number <= end; number++ {
+		header, err := s.GetHeader(number)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// SaveBlockUndo stores the undo record of a block under the block hash.
+func (s *store) SaveBlockUndo(undo *types.BlockUndo) error {
+	data, err := undo.Marshal()
+	if err != nil {
+		return fmt.Errorf("error marshaling undo record for block %d: %v", undo.Height, err)
+	}
+	key := []byte(BlockUndoPrefix + undo.BlockHash.String())
+	if err := s.db.Set(key, data); err != nil {
+		return fmt.Errorf("error storing undo record for block %d: %v", undo.Height, err)
+	}
+	return nil
+}
+
+// GetBlockUndo retrieves the undo record of a block by its hash.
+func (s *store) GetBlockUndo(blockHash hash.Hash) (*types.BlockUndo, error) {
+	data, err := s.db.Get([]byte(BlockUndoPrefix + blockHash.String()))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving undo record for block %s: %v", blockHash.String(), err)
+	}
+	var undo types.BlockUndo
+	if err := undo.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("error unmarshaling undo record for block %s: %v", blockHash.String(), err)
+	}
+	return &undo, nil
+}
+
 // StoreBlock stores serialized block data.
 func (s *store) StoreBlock(blockData []byte, blockNumber int) error {
 	db := s.db.GetDB()
@@ -1108,8 +1161,7 @@ func (s *store) GetPublicKey(addr address.Address) (crypto.PublicKey, error) {
 		log.Printf("Failed to retrieve public key: %v", err)
 		return nil, fmt.Errorf("error retrieving public key: %v", err)
 	}
-	var pub crypto.PublicKey
-	err = pub.Unmarshal(data)
+	pub, err := decodePublicKey(data)
 	if err != nil {
 		log.Printf("Failed to unmarshal public key: %v", err)
 		return nil, fmt.Errorf("error unmarshaling public key: %v", err)
@@ -1117,6 +1169,47 @@ func (s *store) GetPublicKey(addr address.Address) (crypto.PublicKey, error) {
 	return pub, nil
 }
 
+// GetAllPublicKeys returns every stored public key indexed by its address.
+func (s *store) GetAllPublicKeys() (map[string]crypto.PublicKey, error) {
+	pubKeys := make(map[string]crypto.PublicKey)
+	db := s.db.GetDB()
+	err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		prefix := []byte(PublicKeyPrefix)
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			addr := strings.TrimPrefix(string(item.Key()), PublicKeyPrefix)
+			err := item.Value(func(val []byte) error {
+				pub, err := decodePublicKey(val)
+				if err != nil {
+					return fmt.Errorf("error unmarshaling public key for %s: %v", addr, err)
+				}
+				pubKeys[addr] = pub
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pubKeys, nil
+}
+
+// decodePublicKey reverses crypto.PublicKey.Marshal, which wraps the raw key in a CBOR byte string.
+func decodePublicKey(data []byte) (crypto.PublicKey, error) {
+	var raw []byte
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return crypto.NewPublicKeyFromBytes(raw)
+}
+
 // StoreValidatorPublicKey stores a validator's ML-DSA44 public key
 func (s *store) SavePublicKey(pubKey crypto.PublicKey) error {
 	db := s.db.GetDB()
@@ -1146,11 +1239,21 @@ func (s *store) SavePublicKey(pubKey crypto.PublicKey) error {
 	})
 }
 
+// Close closes the underlying database.
+func (s *store) Close() error {
+	return s.db.Close()
+}
+
 // BALANCE
 
 // GetBalance calculates the total balance for a given address based on its UTXOs.
 // This function is useful for determining the spendable balance of a blockchain account.
 func (s *store) GetBalance(address string, utxos map[string][]types.UTXO) (amount.Amount, error) {
+	return balanceOf(address, utxos)
+}
+
+// balanceOf sums the unspent outputs of address in utxos.
+func balanceOf(address string, utxos map[string][]types.UTXO) (amount.Amount, error) {
 	var balance amount.Amount
 	userUTXOs, ok := utxos[address]
 	if !ok {
@@ -1162,9 +1265,12 @@ func (s *store) GetBalance(address string, utxos map[string][]types.UTXO) (amoun
 	for i, utxo := range userUTXOs {
 		utxoKey := generateUTXOKey(address, utxo.TransactionID, utxo.Index)
 		if !utxo.IsSpent {
-			balance += utxo.Amount
-			log.Printf("UTXO %d [%s]: Amount=%d nanoTHRYLOS (%.7f THRYLOS) IsSpent=%v",
-				i, utxoKey, utxo.Amount, float64(utxo.Amount)/1e7, utxo.IsSpent)
+			var err error
+			if balance, err = balance.Add(utxo.Amount); err != nil {
+				return 0, fmt.Errorf("balance of %s: %v", address, err)
+			}
+			log.Printf("UTXO %d [%s]: Amount=%d nanoTHRYLOS (%s) IsSpent=%v",
+				i, utxoKey, utxo.Amount, utxo.Amount, utxo.IsSpent)
 		} else {
 			log.Printf("Skipping spent UTXO %d [%s]: Amount=%d IsSpent=%v",
 				i, utxoKey, utxo.Amount, utxo.IsSpent)
@@ -1172,8 +1278,8 @@ func (s *store) GetBalance(address string, utxos map[string][]types.UTXO) (amoun
 
 	}
 
-	log.Printf("Final balance for %s: %d nanoTHRYLOS (%.7f THRYLOS)",
-		address, balance, float64(balance)/1e7)
+	log.Printf("Final balance for %s: %d nanoTHRYLOS (%s)",
+		address, balance, balance)
 	return balance, nil
 }
 
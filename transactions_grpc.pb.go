@@ -31,6 +31,7 @@ const (
 	BlockchainService_GetBlockByIndex_FullMethodName           = "/thrylos.BlockchainService/GetBlockByIndex"
 	BlockchainService_SubscribeToBalanceUpdates_FullMethodName = "/thrylos.BlockchainService/SubscribeToBalanceUpdates"
 	BlockchainService_StreamBalance_FullMethodName             = "/thrylos.BlockchainService/StreamBalance"
+	BlockchainService_GetTransactionsByMemo_FullMethodName     = "/thrylos.BlockchainService/GetTransactionsByMemo"
 )
 
 // BlockchainServiceClient is the client API for BlockchainService service.
@@ -49,6 +50,7 @@ type BlockchainServiceClient interface {
 	GetBlockByIndex(ctx context.Context, in *GetBlockByIndexRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	SubscribeToBalanceUpdates(ctx context.Context, in *BalanceSubscriptionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BalanceMessage], error)
 	StreamBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BalanceResponse], error)
+	GetTransactionsByMemo(ctx context.Context, in *GetTransactionsByMemoRequest, opts ...grpc.CallOption) (*TransactionsResponse, error)
 }
 
 type blockchainServiceClient struct {
@@ -197,6 +199,16 @@ func (c *blockchainServiceClient) StreamBalance(ctx context.Context, in *GetBala
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type BlockchainService_StreamBalanceClient = grpc.ServerStreamingClient[BalanceResponse]
 
+func (c *blockchainServiceClient) GetTransactionsByMemo(ctx context.Context, in *GetTransactionsByMemoRequest, opts ...grpc.CallOption) (*TransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionsResponse)
+	err := c.cc.Invoke(ctx, BlockchainService_GetTransactionsByMemo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlockchainServiceServer is the server API for BlockchainService service.
 // All implementations must embed UnimplementedBlockchainServiceServer
 // for forward compatibility.
@@ -213,6 +225,7 @@ type BlockchainServiceServer interface {
 	GetBlockByIndex(context.Context, *GetBlockByIndexRequest) (*BlockResponse, error)
 	SubscribeToBalanceUpdates(*BalanceSubscriptionRequest, grpc.ServerStreamingServer[BalanceMessage]) error
 	StreamBalance(*GetBalanceRequest, grpc.ServerStreamingServer[BalanceResponse]) error
+	GetTransactionsByMemo(context.Context, *GetTransactionsByMemoRequest) (*TransactionsResponse, error)
 	mustEmbedUnimplementedBlockchainServiceServer()
 }
 
@@ -259,6 +272,9 @@ func (UnimplementedBlockchainServiceServer) SubscribeToBalanceUpdates(*BalanceSu
 func (UnimplementedBlockchainServiceServer) StreamBalance(*GetBalanceRequest, grpc.ServerStreamingServer[BalanceResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamBalance not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetTransactionsByMemo(context.Context, *GetTransactionsByMemoRequest) (*TransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionsByMemo not implemented")
+}
 func (UnimplementedBlockchainServiceServer) mustEmbedUnimplementedBlockchainServiceServer() {}
 func (UnimplementedBlockchainServiceServer) testEmbeddedByValue()                           {}
 
@@ -482,6 +498,24 @@ func _BlockchainService_StreamBalance_Handler(srv interface{}, stream grpc.Serve
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type BlockchainService_StreamBalanceServer = grpc.ServerStreamingServer[BalanceResponse]
 
+func _BlockchainService_GetTransactionsByMemo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsByMemoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetTransactionsByMemo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainService_GetTransactionsByMemo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetTransactionsByMemo(ctx, req.(*GetTransactionsByMemoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BlockchainService_ServiceDesc is the grpc.ServiceDesc for BlockchainService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -529,6 +563,10 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockByIndex",
 			Handler:    _BlockchainService_GetBlockByIndex_Handler,
 		},
+		{
+			MethodName: "GetTransactionsByMemo",
+			Handler:    _BlockchainService_GetTransactionsByMemo_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -23,6 +23,65 @@ type Block struct {
 	Signature          crypto.Signature `cbor:"9,keyasint,omitempty"`
 	Salt               []byte           `cbor:"10,keyasint"`
 	Validator          string           `cbor:"11,keyasint"`
+	Version            uint32           `cbor:"12,keyasint,omitempty"`
+}
+
+// blockCBOR mirrors Block on the wire, keeping the validator key and signature
+// as raw bytes so they can be rebuilt into their crypto types after decoding.
+type blockCBOR struct {
+	Index              int64          `cbor:"1,keyasint"`
+	Timestamp          int64          `cbor:"2,keyasint"`
+	VerkleRoot         []byte         `cbor:"3,keyasint"`
+	PrevHash           hash.Hash      `cbor:"4,keyasint"`
+	Hash               hash.Hash      `cbor:"5,keyasint,omitempty"`
+	Transactions       []*Transaction `cbor:"6,keyasint"`
+	Data               string         `cbor:"7,keyasint,omitempty"`
+	ValidatorPublicKey []byte         `cbor:"8,keyasint"`
+	Signature          []byte         `cbor:"9,keyasint,omitempty"`
+	Salt               []byte         `cbor:"10,keyasint"`
+	Validator          string         `cbor:"11,keyasint"`
+	Version            uint32         `cbor:"12,keyasint,omitempty"`
+}
+
+// UnmarshalCBOR decodes a block, restoring its validator public key and signature.
+func (b *Block) UnmarshalCBOR(data []byte) error {
+	var raw blockCBOR
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	pubKey, err := decodePublicKey(raw.ValidatorPublicKey)
+	if err != nil {
+		return err
+	}
+	*b = Block{
+		Index:              raw.Index,
+		Timestamp:          raw.Timestamp,
+		VerkleRoot:         raw.VerkleRoot,
+		PrevHash:           raw.PrevHash,
+		Hash:               raw.Hash,
+		Transactions:       raw.Transactions,
+		Data:               raw.Data,
+		ValidatorPublicKey: pubKey,
+		Signature:          decodeSignature(raw.Signature),
+		Salt:               raw.Salt,
+		Validator:          raw.Validator,
+		Version:            raw.Version,
+	}
+	return nil
+}
+
+// Header returns the hashed part of the block, without its transactions.
+func (b *Block) Header() *BlockHeader {
+	return &BlockHeader{
+		Version:    b.Version,
+		Index:      b.Index,
+		Timestamp:  b.Timestamp,
+		PrevHash:   b.PrevHash,
+		VerkleRoot: b.VerkleRoot,
+		Validator:  b.Validator,
+		Data:       b.Data,
+		Signature:  b.Signature,
+	}
 }
 
 // Basic methods that don't require chain-specific logic
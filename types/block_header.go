@@ -0,0 +1,79 @@
+package types
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+)
+
+// BlockVersion is the version of the block format produced by this node.
+const BlockVersion uint32 = 1
+
+// BlockHeader holds the fields of a block that are covered by its hash. Transactions are
+// committed to through VerkleRoot, so headers can be synced and verified without bodies.
+type BlockHeader struct {
+	Version    uint32           `cbor:"1,keyasint"`
+	Index      int64            `cbor:"2,keyasint"`
+	Timestamp  int64            `cbor:"3,keyasint"`
+	PrevHash   hash.Hash        `cbor:"4,keyasint"`
+	VerkleRoot []byte           `cbor:"5,keyasint"`
+	Validator  string           `cbor:"6,keyasint"`
+	Data       string           `cbor:"7,keyasint,omitempty"`
+	Signature  crypto.Signature `cbor:"8,keyasint,omitempty"`
+}
+
+// blockHeaderCBOR mirrors BlockHeader on the wire with the signature as raw bytes.
+type blockHeaderCBOR struct {
+	Version    uint32    `cbor:"1,keyasint"`
+	Index      int64     `cbor:"2,keyasint"`
+	Timestamp  int64     `cbor:"3,keyasint"`
+	PrevHash   hash.Hash `cbor:"4,keyasint"`
+	VerkleRoot []byte    `cbor:"5,keyasint"`
+	Validator  string    `cbor:"6,keyasint"`
+	Data       string    `cbor:"7,keyasint,omitempty"`
+	Signature  []byte    `cbor:"8,keyasint,omitempty"`
+}
+
+// UnmarshalCBOR decodes a header, restoring its signature.
+func (h *BlockHeader) UnmarshalCBOR(data []byte) error {
+	var raw blockHeaderCBOR
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*h = BlockHeader{
+		Version:    raw.Version,
+		Index:      raw.Index,
+		Timestamp:  raw.Timestamp,
+		PrevHash:   raw.PrevHash,
+		VerkleRoot: raw.VerkleRoot,
+		Validator:  raw.Validator,
+		Data:       raw.Data,
+		Signature:  decodeSignature(raw.Signature),
+	}
+	return nil
+}
+
+func (h *BlockHeader) Marshal() ([]byte, error) {
+	return cbor.Marshal(h)
+}
+
+func (h *BlockHeader) Unmarshal(data []byte) error {
+	return cbor.Unmarshal(data, h)
+}
+
+// SigningBytes returns the encoding of the header without its signature, which is
+// what the block hash is computed over.
+func (h *BlockHeader) SigningBytes() ([]byte, error) {
+	headerCopy := *h
+	headerCopy.Signature = nil
+	return headerCopy.Marshal()
+}
+
+// Hash returns the header hash, which is also the hash of the block.
+func (h *BlockHeader) Hash() (hash.Hash, error) {
+	data, err := h.SigningBytes()
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return hash.NewHash(data), nil
+}
@@ -0,0 +1,23 @@
+package types
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+)
+
+// BlockUndo records the UTXO changes made when a block was connected to the chain,
+// so they can be reverted when the block is disconnected during a reorganization.
+type BlockUndo struct {
+	BlockHash hash.Hash `cbor:"1,keyasint"`
+	Height    int64     `cbor:"2,keyasint"`
+	Spent     []UTXO    `cbor:"3,keyasint"` // Outputs consumed by the block's transactions
+	Created   []UTXO    `cbor:"4,keyasint"` // Outputs created by the block's transactions
+}
+
+func (u *BlockUndo) Marshal() ([]byte, error) {
+	return cbor.Marshal(u)
+}
+
+func (u *BlockUndo) Unmarshal(data []byte) error {
+	return cbor.Unmarshal(data, u)
+}
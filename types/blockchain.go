@@ -3,8 +3,11 @@ package types
 import (
 	"math/big"
 	"sync"
+	"time"
 
 	thrylos "github.com/thrylos-labs/thrylos"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/config"
 	"github.com/thrylos-labs/thrylos/crypto"
 )
 
@@ -21,6 +24,9 @@ type Blockchain struct {
 	// is the foundation of the blockchain, with no preceding block.
 	Genesis *Block
 
+	// ChainID identifies the network the chain belongs to, as fixed by the genesis file.
+	ChainID string
+
 	// Adding transactions to the pending transactions pool
 	PendingTransactions []*thrylos.Transaction
 
@@ -29,6 +35,12 @@ type Blockchain struct {
 	// new blocks based on the size of their stake
 	Stakeholders map[string]int64 // Maps validator addresses to their respective stakes
 
+	// BondedStake holds the stake bonded to each validator by stake and delegate
+	// transactions, and Delegations the part of it each address contributed. A
+	// validator's own stake is recorded as a delegation to itself.
+	BondedStake map[string]amount.Amount
+	Delegations map[string]map[string]amount.Amount // delegator -> validator -> amount
+
 	// UTXOs tracks unspent transaction outputs, which represent the current state of ownership
 	// of the blockchain's assets. It is a key component in preventing double spending.
 	UTXOs map[string][]*thrylos.UTXO
@@ -46,6 +58,9 @@ type Blockchain struct {
 	// blocks are added in chronological order, preserving the integrity of the blockchain's timeline.
 	LastTimestamp int64
 
+	// MaxBlockTimeDrift limits how far a block timestamp may be ahead of the local clock.
+	MaxBlockTimeDrift time.Duration
+
 	// Database provides an abstraction over the underlying database technology used to persist
 	// blockchain data, facilitating operations like adding blocks and retrieving blockchain state
 	Database Store // Updated the type to interface
@@ -77,8 +92,8 @@ type Blockchain struct {
 
 // // Fork structure representing a fork in the blockchain
 type Fork struct {
-	Index  int
-	Blocks []*Block
+	Index  int      // Height of the first block of the branch, where it diverges from the canonical chain
+	Blocks []*Block // Branch blocks in height order
 }
 
 // NewTransaction creates a new transaction
@@ -89,9 +104,12 @@ type Stakeholder struct {
 
 type BlockchainConfig struct {
 	DataDir           string
+	InMemory          bool // Keep the database in memory instead of DataDir, for tests and simulations
 	AESKey            []byte
 	GenesisAccount    crypto.PrivateKey
 	TestMode          bool
 	DisableBackground bool
+	Genesis           *config.Genesis // When set, block 0 is derived from the genesis file and checked at startup
+	MaxBlockTimeDrift time.Duration   // Zero uses config.MaxBlockTimeDriftSeconds
 	// StateManager      *types.StateManager
 }
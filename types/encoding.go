@@ -0,0 +1,28 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/thrylos-labs/thrylos/crypto"
+)
+
+// The crypto interfaces cannot be decoded by CBOR directly, so structures that
+// embed them decode those fields as raw bytes and rebuild them with these helpers.
+
+func decodePublicKey(raw []byte) (crypto.PublicKey, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	pubKey, err := crypto.NewPublicKeyFromBytes(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %v", err)
+	}
+	return pubKey, nil
+}
+
+func decodeSignature(raw []byte) crypto.Signature {
+	if len(raw) == 0 {
+		return nil
+	}
+	return crypto.NewSignature(raw)
+}
@@ -43,6 +43,7 @@ const (
 	GetPendingTransactionCount MessageType = "GET_PENDING_TX_COUNT"
 	GetPendingTransactionBatch MessageType = "GET_PENDING_TX_BATCH"
 	UpdateProcessorState       MessageType = "UPDATE_PROCESSOR_STATE"
+	TransactionReplaced        MessageType = "TRANSACTION_REPLACED"
 
 	// Block related
 	ProcessBlock      MessageType = "PROCESS_BLOCK"
@@ -94,6 +95,13 @@ type PendingTransactionBatchResponse struct {
 	Error        error
 }
 
+// TransactionReplacedEvent is published when a pooled transaction is replaced by a
+// conflicting transaction paying a higher fee.
+type TransactionReplacedEvent struct {
+	ReplacedID    string
+	ReplacementID string
+}
+
 type IsCounterNodeResponse struct {
 	IsCounter bool
 	Error     error
@@ -103,9 +111,12 @@ type UTXORequest struct {
 	Address string
 }
 
+// UTXOResponse answers a GetUTXOs request. Height is the height of the next block,
+// at which the locks of the outputs are evaluated.
 type UTXOResponse struct {
-	UTXOs []UTXO
-	Error error
+	UTXOs  []UTXO
+	Height int64
+	Error  error
 }
 
 type AddUTXORequest struct {
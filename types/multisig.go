@@ -0,0 +1,183 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/address"
+)
+
+// MultisigPolicy is the key set and threshold behind a multisig address. A
+// transaction spending from a multisig address carries the policy and at least
+// Threshold signatures over its signing preimage instead of a single signature.
+type MultisigPolicy struct {
+	Threshold  int                `cbor:"1,keyasint"`
+	PublicKeys []crypto.PublicKey `cbor:"2,keyasint"`
+}
+
+// MultisigSignature is one signer's signature, naming the policy key it was made with.
+type MultisigSignature struct {
+	KeyIndex  int              `cbor:"1,keyasint"`
+	Signature crypto.Signature `cbor:"2,keyasint"`
+}
+
+// NewMultisigPolicy builds a policy from pubKeys in canonical, sorted order so that
+// key indexes agree between all signers.
+func NewMultisigPolicy(threshold int, pubKeys []crypto.PublicKey) (*MultisigPolicy, error) {
+	keys := append([]crypto.PublicKey(nil), pubKeys...)
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) < 0 })
+	policy := &MultisigPolicy{Threshold: threshold, PublicKeys: keys}
+	if _, err := policy.Address(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Address returns the multisig address the policy controls.
+func (p *MultisigPolicy) Address() (*address.Address, error) {
+	return crypto.MultisigAddress(p.Threshold, p.PublicKeys)
+}
+
+func (p *MultisigPolicy) keyIndex(pubKey crypto.PublicKey) int {
+	for i, key := range p.PublicKeys {
+		if bytes.Equal(key.Bytes(), pubKey.Bytes()) {
+			return i
+		}
+	}
+	return -1
+}
+
+type multisigPolicyCBOR struct {
+	Threshold  int      `cbor:"1,keyasint"`
+	PublicKeys [][]byte `cbor:"2,keyasint"`
+}
+
+// UnmarshalCBOR decodes a policy, restoring its public keys.
+func (p *MultisigPolicy) UnmarshalCBOR(data []byte) error {
+	var raw multisigPolicyCBOR
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	keys := make([]crypto.PublicKey, len(raw.PublicKeys))
+	for i, rawKey := range raw.PublicKeys {
+		pubKey, err := decodePublicKey(rawKey)
+		if err != nil {
+			return err
+		}
+		if pubKey == nil {
+			return errors.New("multisig policy has an empty public key")
+		}
+		keys[i] = pubKey
+	}
+	*p = MultisigPolicy{Threshold: raw.Threshold, PublicKeys: keys}
+	return nil
+}
+
+type multisigSignatureCBOR struct {
+	KeyIndex  int    `cbor:"1,keyasint"`
+	Signature []byte `cbor:"2,keyasint"`
+}
+
+// UnmarshalCBOR decodes a multisig signature, restoring its signature.
+func (s *MultisigSignature) UnmarshalCBOR(data []byte) error {
+	var raw multisigSignatureCBOR
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = MultisigSignature{KeyIndex: raw.KeyIndex, Signature: decodeSignature(raw.Signature)}
+	return nil
+}
+
+// SignMultisig returns key's partial signature of the transaction for the given
+// chain. The transaction is left untouched so signers can work on separate copies
+// and hand their signatures to whoever collects them with AddMultisigSignature.
+func (tx *Transaction) SignMultisig(key crypto.PrivateKey, chainID string) (MultisigSignature, error) {
+	if tx.Multisig == nil {
+		return MultisigSignature{}, errors.New("transaction has no multisig policy")
+	}
+	index := tx.Multisig.keyIndex(key.PublicKey())
+	if index < 0 {
+		return MultisigSignature{}, errors.New("key is not part of the multisig policy")
+	}
+	preimage, err := tx.SigningBytes(chainID)
+	if err != nil {
+		return MultisigSignature{}, err
+	}
+	return MultisigSignature{KeyIndex: index, Signature: key.Sign(preimage)}, nil
+}
+
+// AddMultisigSignature checks a partial signature and adds it to the transaction,
+// keeping signatures ordered by key index. Adding a second signature for the same
+// key is an error.
+func (tx *Transaction) AddMultisigSignature(sig MultisigSignature, chainID string) error {
+	if tx.Multisig == nil {
+		return errors.New("transaction has no multisig policy")
+	}
+	if err := tx.verifyMultisigSignature(sig, chainID); err != nil {
+		return err
+	}
+	pos := sort.Search(len(tx.MultisigSignatures), func(i int) bool {
+		return tx.MultisigSignatures[i].KeyIndex >= sig.KeyIndex
+	})
+	if pos < len(tx.MultisigSignatures) && tx.MultisigSignatures[pos].KeyIndex == sig.KeyIndex {
+		return fmt.Errorf("multisig key %d has already signed", sig.KeyIndex)
+	}
+	tx.MultisigSignatures = append(tx.MultisigSignatures, MultisigSignature{})
+	copy(tx.MultisigSignatures[pos+1:], tx.MultisigSignatures[pos:])
+	tx.MultisigSignatures[pos] = sig
+	return nil
+}
+
+// MultisigSignaturesNeeded returns how many more signatures the transaction needs
+// to reach its policy threshold.
+func (tx *Transaction) MultisigSignaturesNeeded() int {
+	if tx.Multisig == nil || len(tx.MultisigSignatures) >= tx.Multisig.Threshold {
+		return 0
+	}
+	return tx.Multisig.Threshold - len(tx.MultisigSignatures)
+}
+
+// VerifyMultisig checks that the transaction carries at least the policy threshold
+// of valid signatures from distinct policy keys for the given chain. It does not
+// check that the policy belongs to the sender address.
+func (tx *Transaction) VerifyMultisig(chainID string) error {
+	if tx.Multisig == nil {
+		return errors.New("transaction has no multisig policy")
+	}
+	signed := make(map[int]bool, len(tx.MultisigSignatures))
+	for _, sig := range tx.MultisigSignatures {
+		if signed[sig.KeyIndex] {
+			return fmt.Errorf("multisig key %d signed more than once", sig.KeyIndex)
+		}
+		if err := tx.verifyMultisigSignature(sig, chainID); err != nil {
+			return err
+		}
+		signed[sig.KeyIndex] = true
+	}
+	if len(signed) < tx.Multisig.Threshold {
+		return fmt.Errorf("transaction has %d of the %d signatures its multisig policy requires", len(signed), tx.Multisig.Threshold)
+	}
+	return nil
+}
+
+func (tx *Transaction) verifyMultisigSignature(sig MultisigSignature, chainID string) error {
+	if sig.KeyIndex < 0 || sig.KeyIndex >= len(tx.Multisig.PublicKeys) {
+		return fmt.Errorf("multisig key index %d is out of range", sig.KeyIndex)
+	}
+	if sig.Signature == nil {
+		return fmt.Errorf("multisig key %d has an empty signature", sig.KeyIndex)
+	}
+	preimage, err := tx.SigningBytes(chainID)
+	if err != nil {
+		return err
+	}
+	pubKey := tx.Multisig.PublicKeys[sig.KeyIndex]
+	if err := sig.Signature.Verify(&pubKey, preimage); err != nil {
+		return fmt.Errorf("invalid signature from multisig key %d: %v", sig.KeyIndex, err)
+	}
+	return nil
+}
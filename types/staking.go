@@ -1,10 +1,13 @@
 package types
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/thrylos-labs/thrylos/amount"
+)
 
 type StakingManager interface {
 	GetPoolStats() map[string]interface{}
-	CreateStake(userAddress string, amount int64) (*Stake, error)
 	UnstakeTokens(userAddress string, amount int64) error
 	DelegateToPool(delegator string, amount int64) error
 	UndelegateFromPool(delegator string, amount int64) error
@@ -23,16 +26,16 @@ type StakingPool struct {
 }
 
 type Stake struct {
-	UserAddress            string  `json:"userAddress"`
-	Amount                 int64   `json:"amount"`
-	StartTime              int64   `json:"startTime"`
-	LastStakeUpdateTime    int64   `json:"lastStakeUpdateTime"` // Last time stake was updated
-	StakeTimeSum           float64 `json:"stakeTimeSum"`        // Accumulated stake-time (stake * duration)
-	StakeTimeAverage       float64 `json:"stakeTimeAverage"`    // Moving average of stake-time
-	TotalStakeRewards      float64 `json:"totalStakeRewards"`
-	TotalDelegationRewards float64 `json:"totalDelegationRewards"`
-	IsActive               bool    `json:"isActive"`
-	ValidatorRole          bool    `json:"validatorRole"`
+	UserAddress            string        `json:"userAddress"`
+	Amount                 int64         `json:"amount"`
+	StartTime              int64         `json:"startTime"`
+	LastStakeUpdateTime    int64         `json:"lastStakeUpdateTime"` // Last time stake was updated
+	StakeTimeSum           float64       `json:"stakeTimeSum"`        // Accumulated stake-time (stake * duration)
+	StakeTimeAverage       float64       `json:"stakeTimeAverage"`    // Moving average of stake-time
+	TotalStakeRewards      amount.Amount `json:"totalStakeRewards"`
+	TotalDelegationRewards amount.Amount `json:"totalDelegationRewards"`
+	IsActive               bool          `json:"isActive"`
+	ValidatorRole          bool          `json:"validatorRole"`
 }
 
 type StakingService struct {
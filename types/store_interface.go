@@ -1,10 +1,10 @@
 package types
 
 import (
-	thrylos "github.com/thrylos-labs/thrylos"
 	"github.com/thrylos-labs/thrylos/amount"
 	"github.com/thrylos-labs/thrylos/crypto"
 	"github.com/thrylos-labs/thrylos/crypto/address"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
 )
 
 type Store interface {
@@ -13,8 +13,8 @@ type Store interface {
 	AddUTXO(utxo UTXO) error
 	GetAllUTXOs() (map[string][]UTXO, error)
 	AddNewUTXO(txContext TransactionContext, utxo UTXO) error
-	CreateAndStoreUTXO(id, txID string, index int, owner string, amount float64) error
-	CreateUTXO(id, txID string, index int, address string, amount float64) (UTXO, error)
+	CreateAndStoreUTXO(id, txID string, index int, owner string, amount amount.Amount) error
+	CreateUTXO(id, txID string, index int, address string, amount amount.Amount) (UTXO, error)
 	UpdateUTXOs(inputs []UTXO, outputs []UTXO) error
 	GetUTXOsForAddress(address string) ([]UTXO, error)
 	GetUTXOsForUser(address string) ([]UTXO, error)
@@ -29,8 +29,12 @@ type Store interface {
 
 	TransactionExists(txContext TransactionContext, txID string) (bool, error)
 
+	//Pool
+	SavePendingTransaction(tx *Transaction, removed []string) error
+	DeletePendingTransactions(ids []string) error
+	GetPendingTransactions() ([]*Transaction, []string, error)
+
 	CommitTransaction(ctx TransactionContext) error
-	AddTransaction(tx *thrylos.Transaction) error
 	SendTransaction(fromAddress, toAddress string, amount int, privKey crypto.PrivateKey) (bool, error)
 	BeginTransaction() (TransactionContext, error)
 	RollbackTransaction(txn TransactionContext) error
@@ -43,6 +47,19 @@ type Store interface {
 	GetLastBlockData() ([]byte, error)
 	GetLastBlockIndex() (int, error)
 	StoreBlock(blockData []byte, blockNumber int) error
+	DeleteBlock(blockNumber uint32) error
+	SaveBlockUndo(undo *BlockUndo) error
+	GetBlockUndo(blockHash hash.Hash) (*BlockUndo, error)
+	CommitBlock(blk *Block, spent []UTXO, created []UTXO, undo *BlockUndo) error
+	DisconnectBlock(undo *BlockUndo) error
+	RecoverIncompleteBlock() (int64, bool, error)
+	GetHeader(blockNumber uint32) (*BlockHeader, error)
+	GetHeaderRange(start, end uint32) ([]*BlockHeader, error)
+	GetBlockByHash(blockHash hash.Hash) (*Block, error)
+	GetTransactionLocation(txID string) (*TransactionLocation, error)
+	GetTransactionLocationBySalt(salt []byte) (*TransactionLocation, error)
+	GetAddressHistory(address string, offset, limit int) ([]*TransactionLocation, error)
+	GetTransactionsByMemo(memo []byte, offset, limit int) ([]*TransactionLocation, error)
 
 	//Validator
 	// GetValidator(addr address.Address) (*Validator, error)
@@ -51,6 +68,7 @@ type Store interface {
 	//PublicKey
 	GetPublicKey(addr address.Address) (crypto.PublicKey, error)
 	SavePublicKey(pubKey crypto.PublicKey) error
+	GetAllPublicKeys() (map[string]crypto.PublicKey, error)
 
 	//Balance
 	GetBalance(address string, utxos map[string][]UTXO) (amount.Amount, error)
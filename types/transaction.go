@@ -24,6 +24,13 @@ type Transaction struct {
 	BlockHash        string           `cbor:"13,keyasint,omitempty"`
 	Salt             []byte           `cbor:"14,keyasint,omitempty"`
 	Status           string           `cbor:"15,keyasint,omitempty"`
+	Type             TransactionType  `cbor:"16,keyasint,omitempty"`
+	Payload          []byte           `cbor:"17,keyasint,omitempty"` // CBOR encoded payload of the transaction type
+	// Multisig and MultisigSignatures replace SenderPublicKey and Signature when the
+	// sender is a multisig address
+	Multisig           *MultisigPolicy     `cbor:"18,keyasint,omitempty"`
+	MultisigSignatures []MultisigSignature `cbor:"19,keyasint,omitempty"`
+	Memo               []byte              `cbor:"20,keyasint,omitempty"` // Free-form data of at most config.MaxMemoSize bytes
 }
 
 // TransactionContext interface defines the methods that must be implemented
@@ -38,6 +45,67 @@ type TransactionContext interface {
 	GetBadgerTxn() *badger.Txn // Add this new method
 }
 
+// transactionCBOR mirrors Transaction on the wire, keeping the sender key and
+// signature as raw bytes so they can be rebuilt into their crypto types.
+type transactionCBOR struct {
+	ID               string          `cbor:"1,keyasint"`
+	Timestamp        int64           `cbor:"2,keyasint"`
+	Inputs           []UTXO          `cbor:"3,keyasint"`
+	Outputs          []UTXO          `cbor:"4,keyasint"`
+	EncryptedInputs  []byte          `cbor:"5,keyasint,omitempty"`
+	EncryptedOutputs []byte          `cbor:"6,keyasint,omitempty"`
+	EncryptedAESKey  []byte          `cbor:"7,keyasint"`
+	PreviousTxIds    []string        `cbor:"8,keyasint"`
+	SenderAddress    address.Address `cbor:"9,keyasint"`
+	SenderPublicKey  []byte          `cbor:"10,keyasint"`
+	Signature        []byte          `cbor:"11,keyasint,omitempty"`
+	GasFee           int             `cbor:"12,keyasint"`
+	BlockHash        string          `cbor:"13,keyasint,omitempty"`
+	Salt             []byte          `cbor:"14,keyasint,omitempty"`
+	Status           string          `cbor:"15,keyasint,omitempty"`
+	Type             TransactionType `cbor:"16,keyasint,omitempty"`
+	Payload          []byte          `cbor:"17,keyasint,omitempty"`
+	// MultisigPolicy and MultisigSignature decode their own keys and signatures
+	Multisig           *MultisigPolicy     `cbor:"18,keyasint,omitempty"`
+	MultisigSignatures []MultisigSignature `cbor:"19,keyasint,omitempty"`
+	Memo               []byte              `cbor:"20,keyasint,omitempty"`
+}
+
+// UnmarshalCBOR decodes a transaction, restoring its sender public key and signature.
+func (tx *Transaction) UnmarshalCBOR(data []byte) error {
+	var raw transactionCBOR
+	if err := cbor.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	pubKey, err := decodePublicKey(raw.SenderPublicKey)
+	if err != nil {
+		return err
+	}
+	*tx = Transaction{
+		ID:                 raw.ID,
+		Timestamp:          raw.Timestamp,
+		Inputs:             raw.Inputs,
+		Outputs:            raw.Outputs,
+		EncryptedInputs:    raw.EncryptedInputs,
+		EncryptedOutputs:   raw.EncryptedOutputs,
+		EncryptedAESKey:    raw.EncryptedAESKey,
+		PreviousTxIds:      raw.PreviousTxIds,
+		SenderAddress:      raw.SenderAddress,
+		SenderPublicKey:    pubKey,
+		Signature:          decodeSignature(raw.Signature),
+		GasFee:             raw.GasFee,
+		BlockHash:          raw.BlockHash,
+		Salt:               raw.Salt,
+		Status:             raw.Status,
+		Type:               raw.Type,
+		Payload:            raw.Payload,
+		Multisig:           raw.Multisig,
+		MultisigSignatures: raw.MultisigSignatures,
+		Memo:               raw.Memo,
+	}
+	return nil
+}
+
 // Marshal serializes the transaction into CBOR format
 func (tx *Transaction) Marshal() ([]byte, error) {
 	return cbor.Marshal(tx)
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/crypto/encryption"
+)
+
+// SealData encrypts the inputs and outputs of tx for recipient into its
+// EncryptedAESKey, EncryptedInputs and EncryptedOutputs envelopes. The envelopes are
+// part of the signed fields, so a transaction is sealed before it is signed.
+func (tx *Transaction) SealData(recipient *mlkem768.PublicKey) error {
+	inputs, err := cbor.Marshal(tx.Inputs)
+	if err != nil {
+		return fmt.Errorf("failed to encode inputs: %v", err)
+	}
+	outputs, err := cbor.Marshal(tx.Outputs)
+	if err != nil {
+		return fmt.Errorf("failed to encode outputs: %v", err)
+	}
+	key, encryptedInputs, encryptedOutputs, err := encryption.SealTransactionData(recipient, inputs, outputs)
+	if err != nil {
+		return err
+	}
+	tx.EncryptedAESKey, tx.EncryptedInputs, tx.EncryptedOutputs = key, encryptedInputs, encryptedOutputs
+	return nil
+}
+
+// OpenData decrypts the inputs and outputs sealed into tx by SealData with the
+// recipient's key.
+func (tx *Transaction) OpenData(recipient *mlkem768.PrivateKey) (inputs, outputs []UTXO, err error) {
+	inputData, outputData, err := encryption.OpenTransactionData(recipient, tx.EncryptedAESKey, tx.EncryptedInputs, tx.EncryptedOutputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cbor.Unmarshal(inputData, &inputs); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode inputs: %v", err)
+	}
+	if err := cbor.Unmarshal(outputData, &outputs); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode outputs: %v", err)
+	}
+	return inputs, outputs, nil
+}
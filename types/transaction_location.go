@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+)
+
+// TransactionLocation identifies where a transaction was included in the chain.
+type TransactionLocation struct {
+	TxID        string    `cbor:"1,keyasint"`
+	BlockHeight int64     `cbor:"2,keyasint"`
+	BlockHash   hash.Hash `cbor:"3,keyasint"`
+	Position    int       `cbor:"4,keyasint"` // Index of the transaction within the block
+}
+
+func (l *TransactionLocation) Marshal() ([]byte, error) {
+	return cbor.Marshal(l)
+}
+
+func (l *TransactionLocation) Unmarshal(data []byte) error {
+	return cbor.Unmarshal(data, l)
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrylos-labs/thrylos/config"
+)
+
+// ErrMemoTooLarge is returned for transactions whose memo exceeds config.MaxMemoSize.
+var ErrMemoTooLarge = errors.New("transaction memo exceeds the maximum size")
+
+// ValidateMemo checks that the memo fits in config.MaxMemoSize bytes. The memo is
+// covered by the signature and counts towards the encoded size the fee is charged on,
+// so no other check is needed.
+func (tx *Transaction) ValidateMemo() error {
+	if len(tx.Memo) > config.MaxMemoSize {
+		return fmt.Errorf("%w: %d bytes, limit %d", ErrMemoTooLarge, len(tx.Memo), config.MaxMemoSize)
+	}
+	return nil
+}
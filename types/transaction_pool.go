@@ -12,9 +12,11 @@ type TransactionPropagator struct {
 type TxPool interface {
 	AddTransaction(tx *Transaction) error
 	RemoveTransaction(tx *Transaction) error
+	RemoveBlockTransactions(block *Block) error
 	GetTransaction(txID string) (*Transaction, error)
 	GetFirstTransaction() (*Transaction, error)
 	GetAllTransactions() ([]*Transaction, error)
+	GetBatchForBlock(maxBytes int) ([]*Transaction, error)
 	BroadcastTransaction(tx *Transaction) error
 	GetActiveValidators(tx *Transaction) error
 	Size() int
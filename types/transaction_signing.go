@@ -0,0 +1,151 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/crypto"
+)
+
+// Transaction signatures cover a single preimage:
+//
+//	TransactionSigningDomain || 0x00 || version || deterministic CBOR of the signed fields
+//
+// The domain tag keeps transaction signatures apart from block and other signatures,
+// and the chain ID inside the signed fields stops a transaction signed for one
+// network from being replayed on another.
+const (
+	TransactionSigningDomain  = "THRYLOS_TX"
+	TransactionSigningVersion = 1
+)
+
+// signedInput identifies an output spent by the transaction.
+type signedInput struct {
+	TransactionID string `cbor:"1,keyasint"`
+	Index         int    `cbor:"2,keyasint"`
+	OwnerAddress  string `cbor:"3,keyasint"`
+	Amount        int64  `cbor:"4,keyasint"`
+}
+
+// signedOutput is an output created by the transaction; its index is its position.
+// Locks are omitted when unset so outputs without them keep their version 1 encoding.
+type signedOutput struct {
+	OwnerAddress    string `cbor:"1,keyasint"`
+	Amount          int64  `cbor:"2,keyasint"`
+	LockUntilHeight int64  `cbor:"3,keyasint,omitempty"`
+	LockUntilTime   int64  `cbor:"4,keyasint,omitempty"`
+}
+
+// transactionSigningFields are the transaction fields covered by the signature. The
+// signature itself, the block hash and the status are excluded, and so is the sender
+// public key, which the sender address already commits to. The memo is omitted when
+// empty so transactions without one keep their version 1 encoding.
+type transactionSigningFields struct {
+	ChainID          string          `cbor:"1,keyasint"`
+	ID               string          `cbor:"2,keyasint"`
+	Type             TransactionType `cbor:"3,keyasint"`
+	Timestamp        int64           `cbor:"4,keyasint"`
+	Sender           string          `cbor:"5,keyasint"`
+	Inputs           []signedInput   `cbor:"6,keyasint"`
+	Outputs          []signedOutput  `cbor:"7,keyasint"`
+	GasFee           int64           `cbor:"8,keyasint"`
+	Payload          []byte          `cbor:"9,keyasint"`
+	Salt             []byte          `cbor:"10,keyasint"`
+	PreviousTxIds    []string        `cbor:"11,keyasint"`
+	EncryptedInputs  []byte          `cbor:"12,keyasint"`
+	EncryptedOutputs []byte          `cbor:"13,keyasint"`
+	EncryptedAESKey  []byte          `cbor:"14,keyasint"`
+	Memo             []byte          `cbor:"15,keyasint,omitempty"`
+}
+
+var signingEncMode = func() cbor.EncMode {
+	mode, err := cbor.CoreDetEncOptions().EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}()
+
+// SigningBytes returns the preimage the sender signs for the given chain.
+func (tx *Transaction) SigningBytes(chainID string) ([]byte, error) {
+	fields := transactionSigningFields{
+		ChainID:          chainID,
+		ID:               tx.ID,
+		Type:             tx.Type,
+		Timestamp:        tx.Timestamp,
+		Sender:           tx.SenderAddress.String(),
+		Inputs:           make([]signedInput, len(tx.Inputs)),
+		Outputs:          make([]signedOutput, len(tx.Outputs)),
+		GasFee:           int64(tx.GasFee),
+		Payload:          nilIfEmpty(tx.Payload),
+		Salt:             nilIfEmpty(tx.Salt),
+		PreviousTxIds:    tx.PreviousTxIds,
+		EncryptedInputs:  nilIfEmpty(tx.EncryptedInputs),
+		EncryptedOutputs: nilIfEmpty(tx.EncryptedOutputs),
+		EncryptedAESKey:  nilIfEmpty(tx.EncryptedAESKey),
+		Memo:             nilIfEmpty(tx.Memo),
+	}
+	if len(tx.PreviousTxIds) == 0 {
+		fields.PreviousTxIds = nil
+	}
+	for i, input := range tx.Inputs {
+		fields.Inputs[i] = signedInput{
+			TransactionID: input.TransactionID,
+			Index:         input.Index,
+			OwnerAddress:  input.OwnerAddress,
+			Amount:        int64(input.Amount),
+		}
+	}
+	for i, output := range tx.Outputs {
+		fields.Outputs[i] = signedOutput{
+			OwnerAddress:    output.OwnerAddress,
+			Amount:          int64(output.Amount),
+			LockUntilHeight: output.LockUntilHeight,
+			LockUntilTime:   output.LockUntilTime,
+		}
+	}
+
+	encoded, err := signingEncMode.Marshal(&fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction for signing: %v", err)
+	}
+	preimage := make([]byte, 0, len(TransactionSigningDomain)+2+len(encoded))
+	preimage = append(preimage, TransactionSigningDomain...)
+	preimage = append(preimage, 0x00, TransactionSigningVersion)
+	return append(preimage, encoded...), nil
+}
+
+// nilIfEmpty maps empty byte fields to nil so a field encodes the same whether it
+// was never set or lost its backing array in a proto or CBOR round trip.
+func nilIfEmpty(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+// Sign signs the transaction for the given chain with the sender's key.
+func (tx *Transaction) Sign(key crypto.PrivateKey, chainID string) error {
+	preimage, err := tx.SigningBytes(chainID)
+	if err != nil {
+		return err
+	}
+	tx.Signature = key.Sign(preimage)
+	return nil
+}
+
+// VerifySignature checks the transaction signature against pubKey for the given chain.
+func (tx *Transaction) VerifySignature(pubKey crypto.PublicKey, chainID string) error {
+	if tx.Signature == nil {
+		return errors.New("transaction is not signed")
+	}
+	if pubKey == nil {
+		return errors.New("no public key to verify the transaction with")
+	}
+	preimage, err := tx.SigningBytes(chainID)
+	if err != nil {
+		return err
+	}
+	return tx.Signature.Verify(&pubKey, preimage)
+}
@@ -0,0 +1,148 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/crypto"
+)
+
+// TransactionType selects how a transaction is processed when its block is applied.
+// The values match the TransactionType enum of transactions.proto.
+type TransactionType uint8
+
+const (
+	TransactionTypeTransfer          TransactionType = iota // Moves value between outputs
+	TransactionTypeStake                                    // Bonds stake for the sender
+	TransactionTypeUnstake                                  // Releases bonded or delegated stake
+	TransactionTypeDelegate                                 // Bonds stake for another validator
+	TransactionTypeRegisterValidator                        // Adds the sender to the active validators
+)
+
+// StakingPoolAddress owns the outputs holding bonded stake. Stake and delegate
+// transactions pay into it and unstake transactions spend from it.
+const StakingPoolAddress = "staking_pool"
+
+func (t TransactionType) String() string {
+	switch t {
+	case TransactionTypeTransfer:
+		return "transfer"
+	case TransactionTypeStake:
+		return "stake"
+	case TransactionTypeUnstake:
+		return "unstake"
+	case TransactionTypeDelegate:
+		return "delegate"
+	case TransactionTypeRegisterValidator:
+		return "register_validator"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// StakePayload is the payload of a stake transaction. The transaction pays Amount
+// to StakingPoolAddress.
+type StakePayload struct {
+	Amount amount.Amount `cbor:"1,keyasint"`
+}
+
+// UnstakePayload is the payload of an unstake transaction. Without a validator the
+// sender's own stake is released, otherwise stake it delegated to the validator.
+type UnstakePayload struct {
+	Validator string        `cbor:"1,keyasint,omitempty"`
+	Amount    amount.Amount `cbor:"2,keyasint"`
+}
+
+// DelegatePayload is the payload of a delegate transaction. The transaction pays
+// Amount to StakingPoolAddress.
+type DelegatePayload struct {
+	Validator string        `cbor:"1,keyasint"`
+	Amount    amount.Amount `cbor:"2,keyasint"`
+}
+
+// RegisterValidatorPayload is the payload of a validator registration. PublicKey is
+// the ML-DSA-44 key the validator signs blocks with and must match the sender address.
+type RegisterValidatorPayload struct {
+	PublicKey []byte `cbor:"1,keyasint"`
+}
+
+// SetPayload encodes payload into the transaction.
+func (tx *Transaction) SetPayload(payload interface{}) error {
+	data, err := cbor.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s payload: %v", tx.Type, err)
+	}
+	tx.Payload = data
+	return nil
+}
+
+// DecodePayload decodes the transaction payload into v.
+func (tx *Transaction) DecodePayload(v interface{}) error {
+	if len(tx.Payload) == 0 {
+		return fmt.Errorf("%s transaction has no payload", tx.Type)
+	}
+	if err := cbor.Unmarshal(tx.Payload, v); err != nil {
+		return fmt.Errorf("invalid %s payload: %v", tx.Type, err)
+	}
+	return nil
+}
+
+// ValidatePayload checks that the payload matches the transaction type. Checks that
+// need the chain state are made when the transaction's block is applied.
+func (tx *Transaction) ValidatePayload() error {
+	switch tx.Type {
+	case TransactionTypeTransfer:
+		if len(tx.Payload) != 0 {
+			return errors.New("transfer transaction must not carry a payload")
+		}
+		return nil
+	case TransactionTypeStake:
+		var p StakePayload
+		if err := tx.DecodePayload(&p); err != nil {
+			return err
+		}
+		if p.Amount <= 0 {
+			return fmt.Errorf("invalid stake amount: %d", p.Amount)
+		}
+	case TransactionTypeUnstake:
+		var p UnstakePayload
+		if err := tx.DecodePayload(&p); err != nil {
+			return err
+		}
+		if p.Amount <= 0 {
+			return fmt.Errorf("invalid unstake amount: %d", p.Amount)
+		}
+	case TransactionTypeDelegate:
+		var p DelegatePayload
+		if err := tx.DecodePayload(&p); err != nil {
+			return err
+		}
+		if p.Validator == "" {
+			return errors.New("delegate transaction has no validator")
+		}
+		if p.Amount <= 0 {
+			return fmt.Errorf("invalid delegation amount: %d", p.Amount)
+		}
+	case TransactionTypeRegisterValidator:
+		var p RegisterValidatorPayload
+		if err := tx.DecodePayload(&p); err != nil {
+			return err
+		}
+		pubKey, err := crypto.NewPublicKeyFromBytes(p.PublicKey)
+		if err != nil {
+			return fmt.Errorf("invalid validator public key: %v", err)
+		}
+		addr, err := pubKey.Address()
+		if err != nil {
+			return fmt.Errorf("invalid validator public key: %v", err)
+		}
+		if addr.String() != tx.SenderAddress.String() {
+			return fmt.Errorf("validator public key belongs to %s, not the sender %s", addr.String(), tx.SenderAddress.String())
+		}
+	default:
+		return fmt.Errorf("unknown transaction type %d", uint8(tx.Type))
+	}
+	return nil
+}
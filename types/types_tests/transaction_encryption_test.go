@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/encryption"
+)
+
+func TestTransactionSealedData(t *testing.T) {
+	recipientPub, recipientKey, err := encryption.GenerateEncryptionKeyPair()
+	require.NoError(t, err)
+	signer, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	tx := loadSigningVectors(t)[0].transaction(t)
+
+	// The envelopes are sealed before signing and covered by the signature
+	require.NoError(t, tx.SealData(recipientPub))
+	require.NoError(t, tx.Sign(signer, "tl1"))
+	require.NoError(t, tx.VerifySignature(signer.PublicKey(), "tl1"))
+
+	inputs, outputs, err := tx.OpenData(recipientKey)
+	require.NoError(t, err)
+	require.Equal(t, tx.Inputs, inputs)
+	require.Equal(t, tx.Outputs, outputs)
+
+	// Only the recipient can open them
+	_, otherKey, err := encryption.GenerateEncryptionKeyPair()
+	require.NoError(t, err)
+	_, _, err = tx.OpenData(otherKey)
+	require.True(t, errors.Is(err, encryption.ErrDecryptionFailed), "Unexpected error: %v", err)
+
+	// Swapping the envelopes breaks both decryption and the signature
+	tx.EncryptedInputs, tx.EncryptedOutputs = tx.EncryptedOutputs, tx.EncryptedInputs
+	_, _, err = tx.OpenData(recipientKey)
+	require.True(t, errors.Is(err, encryption.ErrDecryptionFailed), "Unexpected error: %v", err)
+	require.Error(t, tx.VerifySignature(signer.PublicKey(), "tl1"))
+}
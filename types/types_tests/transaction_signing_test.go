@@ -0,0 +1,119 @@
+package shared
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/address"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// signingVector is one entry of testdata/transaction_signing_vectors.json. The
+// sender is always the null address; byte fields are hex encoded.
+type signingVector struct {
+	Name        string `json:"name"`
+	ChainID     string `json:"chainId"`
+	Transaction struct {
+		ID        string `json:"id"`
+		Type      uint8  `json:"type"`
+		Timestamp int64  `json:"timestamp"`
+		Inputs    []struct {
+			TransactionID string `json:"transactionId"`
+			Index         int    `json:"index"`
+			OwnerAddress  string `json:"ownerAddress"`
+			Amount        int64  `json:"amount"`
+		} `json:"inputs"`
+		Outputs []struct {
+			OwnerAddress    string `json:"ownerAddress"`
+			Amount          int64  `json:"amount"`
+			LockUntilHeight int64  `json:"lockUntilHeight"`
+			LockUntilTime   int64  `json:"lockUntilTime"`
+		} `json:"outputs"`
+		GasFee        int      `json:"gasFee"`
+		Payload       string   `json:"payload"`
+		Salt          string   `json:"salt"`
+		PreviousTxIds []string `json:"previousTxIds"`
+		Memo          string   `json:"memo"`
+	} `json:"transaction"`
+	Preimage string `json:"preimage"`
+}
+
+func (v signingVector) transaction(t *testing.T) *types.Transaction {
+	payload, err := hex.DecodeString(v.Transaction.Payload)
+	require.NoError(t, err)
+	salt, err := hex.DecodeString(v.Transaction.Salt)
+	require.NoError(t, err)
+	memo, err := hex.DecodeString(v.Transaction.Memo)
+	require.NoError(t, err)
+
+	tx := &types.Transaction{
+		ID:            v.Transaction.ID,
+		Type:          types.TransactionType(v.Transaction.Type),
+		Timestamp:     v.Transaction.Timestamp,
+		SenderAddress: *address.NullAddress(),
+		GasFee:        v.Transaction.GasFee,
+		Payload:       payload,
+		Salt:          salt,
+		PreviousTxIds: v.Transaction.PreviousTxIds,
+		Memo:          memo,
+	}
+	for _, in := range v.Transaction.Inputs {
+		tx.Inputs = append(tx.Inputs, types.UTXO{
+			TransactionID: in.TransactionID,
+			Index:         in.Index,
+			OwnerAddress:  in.OwnerAddress,
+			Amount:        amount.Amount(in.Amount),
+		})
+	}
+	for i, out := range v.Transaction.Outputs {
+		tx.Outputs = append(tx.Outputs, types.UTXO{
+			TransactionID:   v.Transaction.ID,
+			Index:           i,
+			OwnerAddress:    out.OwnerAddress,
+			Amount:          amount.Amount(out.Amount),
+			LockUntilHeight: out.LockUntilHeight,
+			LockUntilTime:   out.LockUntilTime,
+		})
+	}
+	return tx
+}
+
+func loadSigningVectors(t *testing.T) []signingVector {
+	data, err := os.ReadFile("testdata/transaction_signing_vectors.json")
+	require.NoError(t, err)
+	var vectors []signingVector
+	require.NoError(t, json.Unmarshal(data, &vectors))
+	require.NotEmpty(t, vectors)
+	return vectors
+}
+
+func TestTransactionSigningVectors(t *testing.T) {
+	for _, v := range loadSigningVectors(t) {
+		t.Run(v.Name, func(t *testing.T) {
+			preimage, err := v.transaction(t).SigningBytes(v.ChainID)
+			require.NoError(t, err)
+			require.Equal(t, v.Preimage, hex.EncodeToString(preimage))
+		})
+	}
+}
+
+func TestTransactionSignatureIsChainBound(t *testing.T) {
+	key, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	tx := loadSigningVectors(t)[0].transaction(t)
+
+	require.NoError(t, tx.Sign(key, "tl1"))
+	require.NoError(t, tx.VerifySignature(key.PublicKey(), "tl1"))
+	require.Error(t, tx.VerifySignature(key.PublicKey(), "tl1-testnet"))
+
+	// Fields outside the preimage do not affect the signature; signed fields do
+	tx.Status = "confirmed"
+	require.NoError(t, tx.VerifySignature(key.PublicKey(), "tl1"))
+	tx.Outputs[0].Amount++
+	require.Error(t, tx.VerifySignature(key.PublicKey(), "tl1"))
+}
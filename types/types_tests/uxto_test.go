@@ -20,7 +20,7 @@ func TestUTXO(t *testing.T) {
 			0,
 			"test-tx-1",
 			validAddress,
-			100.0,
+			100,
 			false,
 		)
 		assert.NotNil(t, utxo)
@@ -36,7 +36,7 @@ func TestUTXO(t *testing.T) {
 			0,
 			"test-tx-2",
 			validAddress,
-			-100.0,
+			-100,
 			false,
 		)
 		assert.NotNil(t, negativeUtxo)
@@ -49,7 +49,7 @@ func TestUTXO(t *testing.T) {
 			0,
 			"test-tx-1",
 			validAddress,
-			100.0,
+			100,
 			false,
 		)
 		err := validUtxo.Validate()
@@ -61,7 +61,7 @@ func TestUTXO(t *testing.T) {
 			0,
 			"test-tx-2",
 			"invalid_address",
-			100.0,
+			100,
 			false,
 		)
 		assert.NotNil(t, invalidUtxo)
@@ -76,7 +76,7 @@ func TestUTXO(t *testing.T) {
 			5,
 			"test-tx-1",
 			validAddress,
-			100.0,
+			100,
 			false,
 		)
 		key := utxo.Key()
@@ -90,7 +90,7 @@ func TestUTXO(t *testing.T) {
 			0,
 			"test-tx-1",
 			validAddress,
-			100.0,
+			100,
 			false,
 		)
 
@@ -121,7 +121,7 @@ func TestUTXO(t *testing.T) {
 			0,
 			"test-tx-1",
 			validAddress,
-			100.0,
+			100,
 			false,
 		)
 		utxos[utxo.ID] = *utxo
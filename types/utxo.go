@@ -1,7 +1,9 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/fxamacker/cbor/v2"
 	"github.com/thrylos-labs/thrylos/amount"
@@ -16,6 +18,82 @@ type UTXO struct {
 	OwnerAddress  string        `cbor:"4,keyasint"`
 	Amount        amount.Amount `cbor:"5,keyasint"`
 	IsSpent       bool          `cbor:"6,keyasint"`
+	// An output can only be spent in a block at or above LockUntilHeight whose
+	// timestamp, in Unix seconds, is at or after LockUntilTime. Zero means no lock.
+	LockUntilHeight int64 `cbor:"7,keyasint,omitempty"`
+	LockUntilTime   int64 `cbor:"8,keyasint,omitempty"`
+}
+
+// ErrOutputLocked is returned when a transaction spends an output before its lock expires.
+var ErrOutputLocked = errors.New("output is locked")
+
+// IsLocked reports whether the output is still locked for a block at height with the
+// given timestamp.
+func (u *UTXO) IsLocked(height, blockTime int64) bool {
+	return height < u.LockUntilHeight || blockTime < u.LockUntilTime
+}
+
+// CheckLock returns an error wrapping ErrOutputLocked when the output cannot be spent
+// in a block at height with the given timestamp.
+func (u *UTXO) CheckLock(height, blockTime int64) error {
+	if height < u.LockUntilHeight {
+		return fmt.Errorf("%w: %s is locked until height %d", ErrOutputLocked, u.Key(), u.LockUntilHeight)
+	}
+	if blockTime < u.LockUntilTime {
+		return fmt.Errorf("%w: %s is locked until %s", ErrOutputLocked, u.Key(),
+			time.Unix(u.LockUntilTime, 0).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Balance splits the balance of an address into the outputs it can spend in the next
+// block and those still held by a height or time lock.
+type Balance struct {
+	Spendable amount.Amount
+	Locked    amount.Amount
+}
+
+// Total returns the spendable and locked amounts together. NewBalance checks that it
+// fits an Amount.
+func (b Balance) Total() amount.Amount {
+	return b.Spendable + b.Locked
+}
+
+// NewBalance sums the unspent outputs in utxos for a block at height with the given
+// timestamp.
+func NewBalance(utxos []UTXO, height, blockTime int64) (Balance, error) {
+	var balance Balance
+	var err error
+	for _, utxo := range utxos {
+		if utxo.IsSpent {
+			continue
+		}
+		if utxo.IsLocked(height, blockTime) {
+			balance.Locked, err = balance.Locked.Add(utxo.Amount)
+		} else {
+			balance.Spendable, err = balance.Spendable.Add(utxo.Amount)
+		}
+		if err != nil {
+			return Balance{}, err
+		}
+	}
+	if _, err := balance.Spendable.Add(balance.Locked); err != nil {
+		return Balance{}, err
+	}
+	return balance, nil
+}
+
+// SumUTXOs returns the total amount of utxos, or an error wrapping amount.ErrOverflow
+// when it does not fit an Amount.
+func SumUTXOs(utxos []UTXO) (amount.Amount, error) {
+	var total amount.Amount
+	for _, utxo := range utxos {
+		var err error
+		if total, err = total.Add(utxo.Amount); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
 }
 
 // UTXO methods stay with the type
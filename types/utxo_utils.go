@@ -4,17 +4,13 @@ package types
 import "github.com/thrylos-labs/thrylos/amount"
 
 // CreateUTXO creates a new UTXO instance
-func CreateUTXO(id string, index int, txID string, owner string, coinAmount float64, isSpent bool) *UTXO {
-	formatedAmount, err := amount.NewAmount(coinAmount)
-	if err != nil {
-		return nil
-	}
+func CreateUTXO(id string, index int, txID string, owner string, coinAmount amount.Amount, isSpent bool) *UTXO {
 	return &UTXO{
 		ID:            id,
 		Index:         index,
 		TransactionID: txID,
 		OwnerAddress:  owner,
-		Amount:        formatedAmount,
+		Amount:        coinAmount,
 		IsSpent:       isSpent,
 	}
 }
@@ -1,8 +1,12 @@
 package utils
 
 import (
+	"fmt"
+
 	"github.com/thrylos-labs/thrylos"
 	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/address"
 	"github.com/thrylos-labs/thrylos/types"
 )
 
@@ -28,11 +32,13 @@ func ConvertToSharedTransaction(tx *thrylos.Transaction) *types.Transaction {
 	outputs := make([]types.UTXO, len(tx.Outputs))
 	for i, output := range tx.Outputs {
 		outputs[i] = types.UTXO{
-			TransactionID: output.TransactionId,
-			Index:         int(output.Index),
-			OwnerAddress:  output.OwnerAddress,
-			Amount:        amount.Amount(output.Amount),
-			IsSpent:       output.IsSpent,
+			TransactionID:   output.TransactionId,
+			Index:           int(output.Index),
+			OwnerAddress:    output.OwnerAddress,
+			Amount:          amount.Amount(output.Amount),
+			IsSpent:         output.IsSpent,
+			LockUntilHeight: output.LockUntilHeight,
+			LockUntilTime:   output.LockUntilTime,
 		}
 	}
 
@@ -49,7 +55,81 @@ func ConvertToSharedTransaction(tx *thrylos.Transaction) *types.Transaction {
 		BlockHash:        string(tx.BlockHash),
 		Salt:             tx.Salt,
 		Status:           tx.Status,
+		Type:             types.TransactionType(tx.Type),
+		Payload:          tx.Payload,
+		Memo:             tx.Memo,
+	}
+
+	// Sender, key and signature are needed to check the signature; malformed values
+	// are left empty so verification fails
+	if addr, err := address.FromString(tx.Sender); err == nil {
+		sharedTx.SenderAddress = *addr
+	}
+	if len(tx.SenderPublicKey) > 0 {
+		if pubKey, err := crypto.NewPublicKeyFromBytes(tx.SenderPublicKey); err == nil {
+			sharedTx.SenderPublicKey = pubKey
+		}
+	}
+	if len(tx.Signature) > 0 {
+		sharedTx.Signature = crypto.NewSignature(tx.Signature)
+	}
+	if tx.Multisig != nil {
+		if policy, sigs, err := ConvertMultisigFromProto(tx.Multisig, tx.MultisigSignatures); err == nil {
+			sharedTx.Multisig = policy
+			sharedTx.MultisigSignatures = sigs
+		}
 	}
 
 	return sharedTx
 }
+
+// ConvertMultisigToProto converts a multisig policy and its signatures to their protobuf form
+func ConvertMultisigToProto(policy *types.MultisigPolicy, sigs []types.MultisigSignature) (*thrylos.MultisigPolicy, []*thrylos.MultisigSignature) {
+	if policy == nil {
+		return nil, nil
+	}
+	protoPolicy := &thrylos.MultisigPolicy{
+		Threshold:  int32(policy.Threshold),
+		PublicKeys: make([][]byte, len(policy.PublicKeys)),
+	}
+	for i, pubKey := range policy.PublicKeys {
+		protoPolicy.PublicKeys[i] = pubKey.Bytes()
+	}
+	protoSigs := make([]*thrylos.MultisigSignature, 0, len(sigs))
+	for _, sig := range sigs {
+		if sig.Signature == nil {
+			continue
+		}
+		protoSigs = append(protoSigs, &thrylos.MultisigSignature{
+			KeyIndex:  int32(sig.KeyIndex),
+			Signature: sig.Signature.Bytes(),
+		})
+	}
+	return protoPolicy, protoSigs
+}
+
+// ConvertMultisigFromProto converts a protobuf multisig policy and its signatures
+func ConvertMultisigFromProto(policy *thrylos.MultisigPolicy, sigs []*thrylos.MultisigSignature) (*types.MultisigPolicy, []types.MultisigSignature, error) {
+	if policy == nil {
+		return nil, nil, nil
+	}
+	localPolicy := &types.MultisigPolicy{
+		Threshold:  int(policy.Threshold),
+		PublicKeys: make([]crypto.PublicKey, len(policy.PublicKeys)),
+	}
+	for i, keyData := range policy.PublicKeys {
+		pubKey, err := crypto.NewPublicKeyFromBytes(keyData)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid multisig public key %d: %v", i, err)
+		}
+		localPolicy.PublicKeys[i] = pubKey
+	}
+	localSigs := make([]types.MultisigSignature, len(sigs))
+	for i, sig := range sigs {
+		localSigs[i] = types.MultisigSignature{
+			KeyIndex:  int(sig.KeyIndex),
+			Signature: crypto.NewSignature(sig.Signature),
+		}
+	}
+	return localPolicy, localSigs, nil
+}
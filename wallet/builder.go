@@ -0,0 +1,307 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/config"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/crypto/address"
+	"github.com/thrylos-labs/thrylos/crypto/hash"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+var (
+	// ErrInsufficientFunds is returned when the spendable outputs of the sender do not
+	// cover the destinations and the fee.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrFeeRateTooLow is returned for fee rates below the minimum relay fee.
+	ErrFeeRateTooLow = errors.New("fee rate is below the minimum relay fee")
+)
+
+// UTXOSource returns the unspent outputs of an address. types.Store and
+// chain.BlockchainImpl both implement it.
+type UTXOSource interface {
+	GetUTXOsForAddress(address string) ([]types.UTXO, error)
+}
+
+// Destination is an address and the amount to pay it.
+type Destination struct {
+	Address string
+	Amount  amount.Amount
+}
+
+// Request describes a transfer for the builder to fund.
+type Request struct {
+	Sender       crypto.PublicKey // Key the transaction will be signed with
+	Destinations []Destination
+	FeePerByte   float64 // nanoTHRYLOS per byte of CBOR encoded transaction, as in chain.FeeSuggestion
+	Strategy     Strategy
+	// Multisig replaces Sender to spend the outputs of a multisig address. The
+	// transaction is then signed by the policy keys with SignMultisig.
+	Multisig *types.MultisigPolicy
+	// ChangeAddress receives the change, the sender address when empty
+	ChangeAddress string
+	Memo          []byte
+	// Outputs locked at the height or time of the next block are not spent. A zero
+	// BlockTime means now.
+	Height    int64
+	BlockTime int64
+}
+
+// Builder funds transfers from the unspent outputs of the sender.
+type Builder struct {
+	utxos UTXOSource
+}
+
+func NewBuilder(utxos UTXOSource) *Builder {
+	return &Builder{utxos: utxos}
+}
+
+// Build selects outputs of the sender with the requested strategy, adds a change output
+// when the change is worth more than it costs to spend, and returns the unsigned
+// transaction. Its fee is FeePerByte times the encoded size of the signed transaction,
+// and any change too small for an output is added to the fee. The transaction carries
+// a fresh salt, so it only needs to be signed: with Sign for a sender key, or with
+// SignMultisig and AddMultisigSignature for a multisig policy.
+func (b *Builder) Build(req *Request) (*types.Transaction, error) {
+	if req.Sender == nil && req.Multisig == nil {
+		return nil, errors.New("no sender key or multisig policy")
+	}
+	if req.Sender != nil && req.Multisig != nil {
+		return nil, errors.New("a transfer has either a sender key or a multisig policy, not both")
+	}
+	if len(req.Destinations) == 0 {
+		return nil, errors.New("no destinations")
+	}
+	if req.FeePerByte < config.MinRelayFeePerByte {
+		return nil, fmt.Errorf("%w: %v, minimum %d", ErrFeeRateTooLow, req.FeePerByte, config.MinRelayFeePerByte)
+	}
+	sender, err := senderAddress(req)
+	if err != nil {
+		return nil, err
+	}
+	changeAddress := req.ChangeAddress
+	if changeAddress == "" {
+		changeAddress = sender.String()
+	}
+
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %v", err)
+	}
+	id := hash.NewHash(salt)
+	tx := &types.Transaction{
+		ID:              id.String(),
+		Timestamp:       time.Now().Unix(),
+		SenderAddress:   *sender,
+		SenderPublicKey: req.Sender,
+		Multisig:        req.Multisig,
+		Salt:            salt,
+		Memo:            req.Memo,
+	}
+	var total amount.Amount
+	for _, dest := range req.Destinations {
+		if dest.Amount <= 0 {
+			return nil, fmt.Errorf("invalid amount %d for %s", dest.Amount, dest.Address)
+		}
+		tx.Outputs = append(tx.Outputs, types.UTXO{
+			TransactionID: tx.ID,
+			Index:         len(tx.Outputs),
+			OwnerAddress:  dest.Address,
+			Amount:        dest.Amount,
+		})
+		if total, err = total.Add(dest.Amount); err != nil {
+			return nil, fmt.Errorf("destinations: %w", err)
+		}
+	}
+
+	candidates, err := b.spendable(sender.String(), req)
+	if err != nil {
+		return nil, err
+	}
+	change := types.UTXO{TransactionID: tx.ID, Index: len(tx.Outputs), OwnerAddress: changeAddress}
+	budget, err := newFeeBudget(tx, change, req.FeePerByte)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectCoins(req.Strategy, candidates, total, budget)
+	if err != nil {
+		return nil, err
+	}
+	maxFee := budget.base
+	for _, c := range selected {
+		tx.Inputs = append(tx.Inputs, c.utxo)
+		maxFee += c.fee
+	}
+	in, err := types.SumUTXOs(tx.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("inputs: %w", err)
+	}
+	if err := settleFee(tx, in-total, maxFee, change, budget); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// senderAddress returns the address of the sender key or of the multisig policy.
+func senderAddress(req *Request) (*address.Address, error) {
+	if req.Multisig != nil {
+		addr, err := req.Multisig.Address()
+		if err != nil {
+			return nil, fmt.Errorf("invalid multisig policy: %v", err)
+		}
+		return addr, nil
+	}
+	addr, err := req.Sender.Address()
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender key: %v", err)
+	}
+	return addr, nil
+}
+
+// spendable returns the outputs of address that can be spent in the next block.
+func (b *Builder) spendable(address string, req *Request) ([]types.UTXO, error) {
+	utxos, err := b.utxos.GetUTXOsForAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the outputs of %s: %v", address, err)
+	}
+	blockTime := req.BlockTime
+	if blockTime == 0 {
+		blockTime = time.Now().Unix()
+	}
+	var spendable []types.UTXO
+	for _, utxo := range utxos {
+		if utxo.IsSpent || utxo.Amount <= 0 || utxo.IsLocked(req.Height, blockTime) {
+			continue
+		}
+		spendable = append(spendable, utxo)
+	}
+	return spendable, nil
+}
+
+// feeBudget holds upper bounds of the fees a transaction pays for its fixed part, for
+// each input and for a change output. Selection works with these bounds, and the exact
+// fee is settled once the inputs are known.
+type feeBudget struct {
+	rate float64
+	base amount.Amount // Transaction without inputs or change
+	// changeOutput is the fee for the change output, dust the smallest change worth
+	// an output: the fee of spending it later
+	changeOutput amount.Amount
+	dust         amount.Amount
+}
+
+// Bytes added for the length of the inputs array, enough for 65535 inputs.
+const inputsHeaderSize = 2
+
+func newFeeBudget(tx *types.Transaction, change types.UTXO, rate float64) (feeBudget, error) {
+	// Fees and amounts are measured at their widest encoding so the bounds hold for
+	// whatever values they end up with
+	bound := *tx
+	bound.GasFee = math.MaxInt64
+	size, err := signedSize(&bound)
+	if err != nil {
+		return feeBudget{}, err
+	}
+	change.Amount = math.MaxInt64
+	changeSize, err := encodedSize(change)
+	if err != nil {
+		return feeBudget{}, err
+	}
+	budget := feeBudget{rate: rate}
+	budget.base = budget.feeFor(size + inputsHeaderSize)
+	budget.changeOutput = budget.feeFor(changeSize)
+	if budget.dust, err = budget.inputFee(change); err != nil {
+		return feeBudget{}, err
+	}
+	return budget, nil
+}
+
+func (f feeBudget) feeFor(size int) amount.Amount {
+	return amount.Amount(math.Ceil(f.rate * float64(size)))
+}
+
+// inputFee returns the fee for spending utxo.
+func (f feeBudget) inputFee(utxo types.UTXO) (amount.Amount, error) {
+	size, err := encodedSize(utxo)
+	if err != nil {
+		return 0, err
+	}
+	return f.feeFor(size), nil
+}
+
+// settleFee sets the fee of tx to the fee for its signed size and pays the rest of
+// excess, the inputs left over after the destinations, as change when it is worth an
+// output. Without change the whole excess is the fee. maxFee bounds the fee of tx
+// without change.
+func settleFee(tx *types.Transaction, excess, maxFee amount.Amount, change types.UTXO, budget feeBudget) error {
+	outputs := tx.Outputs
+	withChange := excess >= maxFee+budget.changeOutput+budget.dust
+	if withChange {
+		tx.Outputs = append(outputs, change)
+	}
+	// The fee and change change the encoded size only by a few bytes, so this settles
+	// in a couple of rounds
+	fee := amount.Amount(0)
+	for round := 0; round < 4; round++ {
+		if withChange {
+			tx.Outputs[len(outputs)].Amount = excess - fee
+		} else {
+			fee = excess
+		}
+		tx.GasFee = int(fee)
+		size, err := signedSize(tx)
+		if err != nil {
+			return err
+		}
+		needed := budget.feeFor(size)
+		if needed <= fee {
+			return nil
+		}
+		if !withChange || excess-needed < budget.dust {
+			return fmt.Errorf("%w: fee of %d exceeds the %d left after the destinations", ErrInsufficientFunds, needed, excess)
+		}
+		fee = needed
+	}
+	return errors.New("transaction fee did not settle")
+}
+
+// signedSize returns the size the pool charges tx for once it is signed: its CBOR
+// encoding with an ML-DSA-44 signature, or the threshold of multisig signatures, and
+// the pending status the pool sets.
+func signedSize(tx *types.Transaction) (int, error) {
+	signed := *tx
+	if tx.Multisig != nil {
+		signed.MultisigSignatures = make([]types.MultisigSignature, tx.Multisig.Threshold)
+		for i := range signed.MultisigSignatures {
+			signed.MultisigSignatures[i] = types.MultisigSignature{
+				KeyIndex:  len(tx.Multisig.PublicKeys) - 1,
+				Signature: crypto.NewSignature(make([]byte, mldsa44.SignatureSize)),
+			}
+		}
+	} else {
+		signed.Signature = crypto.NewSignature(make([]byte, mldsa44.SignatureSize))
+	}
+	signed.Status = "pending"
+	data, err := signed.Marshal()
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode transaction: %v", err)
+	}
+	return len(data), nil
+}
+
+func encodedSize(utxo types.UTXO) (int, error) {
+	data, err := cbor.Marshal(utxo)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode output: %v", err)
+	}
+	return len(data), nil
+}
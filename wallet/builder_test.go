@@ -0,0 +1,209 @@
+package wallet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/crypto"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+type testUTXOs map[string][]types.UTXO
+
+func (u testUTXOs) GetUTXOsForAddress(address string) ([]types.UTXO, error) {
+	return u[address], nil
+}
+
+func newTestWallet(t *testing.T, amounts ...amount.Amount) (crypto.PrivateKey, string, testUTXOs) {
+	key, err := crypto.NewPrivateKey()
+	require.NoError(t, err)
+	addr, err := key.PublicKey().Address()
+	require.NoError(t, err)
+	utxos := testUTXOs{}
+	for i, amt := range amounts {
+		utxos[addr.String()] = append(utxos[addr.String()], types.UTXO{
+			TransactionID: fmt.Sprintf("funding-%d", i),
+			OwnerAddress:  addr.String(),
+			Amount:        amt,
+		})
+	}
+	return key, addr.String(), utxos
+}
+
+// requireValidFee signs tx and checks that it balances and pays rate for its size.
+func requireValidFee(t *testing.T, key crypto.PrivateKey, tx *types.Transaction, rate float64) {
+	var in, out amount.Amount
+	for _, input := range tx.Inputs {
+		in += input.Amount
+	}
+	for _, output := range tx.Outputs {
+		out += output.Amount
+	}
+	require.Equal(t, in, out+amount.Amount(tx.GasFee))
+
+	require.NoError(t, tx.Sign(key, "tl1"))
+	require.NoError(t, tx.VerifySignature(key.PublicKey(), "tl1"))
+	tx.Status = "pending"
+	data, err := tx.Marshal()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, float64(tx.GasFee), rate*float64(len(data)))
+}
+
+func TestBuildLargestFirstWithChange(t *testing.T) {
+	key, sender, utxos := newTestWallet(t, 1_000_000, 5_000_000, 3_000_000)
+	tx, err := NewBuilder(utxos).Build(&Request{
+		Sender:       key.PublicKey(),
+		Destinations: []Destination{{Address: "tl1recipient", Amount: 4_000_000}},
+		FeePerByte:   2,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, tx.Inputs, 1)
+	require.Equal(t, amount.Amount(5_000_000), tx.Inputs[0].Amount)
+	require.Len(t, tx.Outputs, 2)
+	require.Equal(t, sender, tx.Outputs[1].OwnerAddress)
+	require.Len(t, tx.Salt, 32)
+	requireValidFee(t, key, tx, 2)
+}
+
+func TestBuildSkipsLockedOutputsAndChecksFunds(t *testing.T) {
+	key, sender, utxos := newTestWallet(t, 2_000_000)
+	utxos[sender] = append(utxos[sender], types.UTXO{
+		TransactionID: "vesting", OwnerAddress: sender, Amount: 50_000_000, LockUntilHeight: 100,
+	})
+	builder := NewBuilder(utxos)
+	req := &Request{
+		Sender:       key.PublicKey(),
+		Destinations: []Destination{{Address: "tl1recipient", Amount: 3_000_000}},
+		FeePerByte:   1,
+		Height:       10,
+	}
+	_, err := builder.Build(req)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	req.Height = 100
+	tx, err := builder.Build(req)
+	require.NoError(t, err)
+	require.Equal(t, "vesting", tx.Inputs[0].TransactionID)
+
+	req.FeePerByte = 0.5
+	_, err = builder.Build(req)
+	require.ErrorIs(t, err, ErrFeeRateTooLow)
+}
+
+func TestBuildAddsDustToFee(t *testing.T) {
+	key, _, utxos := newTestWallet(t, 1_000_000)
+	builder := NewBuilder(utxos)
+	req := &Request{
+		Sender:       key.PublicKey(),
+		Destinations: []Destination{{Address: "tl1recipient", Amount: 500_000}},
+		FeePerByte:   1,
+	}
+	tx, err := builder.Build(req)
+	require.NoError(t, err)
+	require.Len(t, tx.Outputs, 2)
+	fee := tx.GasFee
+
+	// Leave a little more than that fee, too little for a change output
+	req.Destinations[0].Amount = amount.Amount(1_000_000 - fee - 50)
+	tx, err = builder.Build(req)
+	require.NoError(t, err)
+	require.Len(t, tx.Outputs, 1)
+	require.Equal(t, fee+50, tx.GasFee)
+	requireValidFee(t, key, tx, 1)
+}
+
+func TestBranchAndBoundAvoidsChange(t *testing.T) {
+	coins := []coin{{effective: 5}, {effective: 3}, {effective: 2}, {effective: 2}}
+
+	require.Len(t, largestFirst(coins, 4), 1)
+	exact := branchAndBound(coins, 4, 0)
+	require.Len(t, exact, 2)
+	require.Equal(t, amount.Amount(4), exact[0].effective+exact[1].effective)
+	require.Nil(t, branchAndBound(coins, 13, 0))
+
+	// Without an exact match the builder falls back to largest first
+	key, _, utxos := newTestWallet(t, 5_000_000, 3_000_000)
+	tx, err := NewBuilder(utxos).Build(&Request{
+		Sender:       key.PublicKey(),
+		Destinations: []Destination{{Address: "tl1recipient", Amount: 1_000_000}},
+		FeePerByte:   1,
+		Strategy:     BranchAndBound,
+	})
+	require.NoError(t, err)
+	require.Len(t, tx.Inputs, 1)
+	require.Len(t, tx.Outputs, 2)
+	requireValidFee(t, key, tx, 1)
+}
+
+func TestPrivacyPreservingSpendsLinkedOutputsTogether(t *testing.T) {
+	key, sender, utxos := newTestWallet(t)
+	for i := 0; i < 6; i++ {
+		utxos[sender] = append(utxos[sender], types.UTXO{
+			TransactionID: fmt.Sprintf("funding-%d", i/2),
+			Index:         i % 2,
+			OwnerAddress:  sender,
+			Amount:        2_000_000,
+		})
+	}
+	builder := NewBuilder(utxos)
+	for round := 0; round < 10; round++ {
+		tx, err := builder.Build(&Request{
+			Sender:       key.PublicKey(),
+			Destinations: []Destination{{Address: "tl1recipient", Amount: 1_000_000}},
+			FeePerByte:   1,
+			Strategy:     PrivacyPreserving,
+		})
+		require.NoError(t, err)
+		require.Len(t, tx.Inputs, 2)
+		require.Equal(t, tx.Inputs[0].TransactionID, tx.Inputs[1].TransactionID)
+		requireValidFee(t, key, tx, 1)
+	}
+}
+
+func TestBuildMultisig(t *testing.T) {
+	keys := make([]crypto.PrivateKey, 3)
+	pubKeys := make([]crypto.PublicKey, 3)
+	for i := range keys {
+		key, err := crypto.NewPrivateKey()
+		require.NoError(t, err)
+		keys[i], pubKeys[i] = key, key.PublicKey()
+	}
+	policy, err := types.NewMultisigPolicy(2, pubKeys)
+	require.NoError(t, err)
+	addr, err := policy.Address()
+	require.NoError(t, err)
+	utxos := testUTXOs{addr.String(): {{TransactionID: "funding-0", OwnerAddress: addr.String(), Amount: 5_000_000}}}
+
+	builder := NewBuilder(utxos)
+	_, err = builder.Build(&Request{
+		Sender:       pubKeys[0],
+		Multisig:     policy,
+		Destinations: []Destination{{Address: "tl1recipient", Amount: 1_000_000}},
+		FeePerByte:   1,
+	})
+	require.Error(t, err)
+
+	tx, err := builder.Build(&Request{
+		Multisig:     policy,
+		Destinations: []Destination{{Address: "tl1recipient", Amount: 1_000_000}},
+		FeePerByte:   1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, addr.String(), tx.SenderAddress.String())
+	require.Len(t, tx.Inputs, 1)
+
+	// Two of the three policy keys sign, and the fee covers both signatures
+	for _, key := range keys[:2] {
+		sig, err := tx.SignMultisig(key, "tl1")
+		require.NoError(t, err)
+		require.NoError(t, tx.AddMultisigSignature(sig, "tl1"))
+	}
+	require.NoError(t, tx.VerifyMultisig("tl1"))
+	tx.Status = "pending"
+	data, err := tx.Marshal()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, float64(tx.GasFee), float64(len(data)))
+}
@@ -0,0 +1,203 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/thrylos-labs/thrylos/amount"
+	"github.com/thrylos-labs/thrylos/types"
+)
+
+// Strategy selects the outputs that fund a transaction.
+type Strategy int
+
+const (
+	// LargestFirst spends the largest outputs first, keeping the input count and the
+	// fee low.
+	LargestFirst Strategy = iota
+	// BranchAndBound searches for a set of outputs that covers the payment closely
+	// enough to need no change output, and falls back to LargestFirst when there is none.
+	BranchAndBound
+	// PrivacyPreserving spends outputs in random order and spends the outputs of one
+	// transaction together, since they are already linked on chain, so the inputs
+	// reveal as little as possible about the wallet beyond what the chain shows.
+	PrivacyPreserving
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case LargestFirst:
+		return "largest_first"
+	case BranchAndBound:
+		return "branch_and_bound"
+	case PrivacyPreserving:
+		return "privacy_preserving"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// Search steps after which branch and bound gives up.
+const maxBranchAndBoundTries = 100_000
+
+// coin is a spendable output, the fee for spending it, and its effective value, what
+// it adds to the transaction once that fee is paid.
+type coin struct {
+	utxo      types.UTXO
+	fee       amount.Amount
+	effective amount.Amount
+}
+
+// selectCoins picks outputs whose effective value covers total and the fixed part of
+// the fee. Outputs worth less than the fee for spending them are never picked.
+func selectCoins(strategy Strategy, utxos []types.UTXO, total amount.Amount, budget feeBudget) ([]coin, error) {
+	var coins []coin
+	var available amount.Amount
+	for _, utxo := range utxos {
+		fee, err := budget.inputFee(utxo)
+		if err != nil {
+			return nil, err
+		}
+		if utxo.Amount <= fee {
+			continue
+		}
+		coins = append(coins, coin{utxo: utxo, fee: fee, effective: utxo.Amount - fee})
+		if available, err = available.Add(utxo.Amount - fee); err != nil {
+			return nil, fmt.Errorf("spendable outputs: %w", err)
+		}
+	}
+	target, err := total.Add(budget.base)
+	if err != nil {
+		return nil, err
+	}
+	if available < target {
+		return nil, fmt.Errorf("%w: %d spendable after fees, need %d", ErrInsufficientFunds, available, target)
+	}
+
+	switch strategy {
+	case LargestFirst:
+		return largestFirst(coins, target), nil
+	case BranchAndBound:
+		if selected := branchAndBound(coins, target, budget.changeOutput+budget.dust); selected != nil {
+			return selected, nil
+		}
+		return largestFirst(coins, target), nil
+	case PrivacyPreserving:
+		return privacyPreserving(coins, target)
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy %s", strategy)
+	}
+}
+
+// largestFirst picks coins by decreasing value until they cover target.
+func largestFirst(coins []coin, target amount.Amount) []coin {
+	sorted := append([]coin(nil), coins...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].effective > sorted[j].effective })
+	var selected []coin
+	var sum amount.Amount
+	for _, c := range sorted {
+		if sum >= target {
+			break
+		}
+		selected = append(selected, c)
+		sum += c.effective
+	}
+	return selected
+}
+
+// branchAndBound searches depth first for the coins whose effective value exceeds
+// target by the least, and by less than costOfChange, so the excess can go to the fee
+// instead of a change output. It returns nil when no such set is found.
+func branchAndBound(coins []coin, target, costOfChange amount.Amount) []coin {
+	sorted := append([]coin(nil), coins...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].effective > sorted[j].effective })
+	// remaining[i] is the value of the coins from i on, to prune branches that cannot
+	// reach target
+	remaining := make([]amount.Amount, len(sorted)+1)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + sorted[i].effective
+	}
+
+	var best []int
+	bestWaste := costOfChange + 1
+	picked := make([]int, 0, len(sorted))
+	tries := 0
+	var search func(i int, sum amount.Amount)
+	search = func(i int, sum amount.Amount) {
+		tries++
+		if tries > maxBranchAndBoundTries || bestWaste == 0 || sum > target+costOfChange {
+			return
+		}
+		if sum >= target {
+			if waste := sum - target; waste < bestWaste {
+				best = append(best[:0], picked...)
+				bestWaste = waste
+			}
+			return
+		}
+		if i == len(sorted) || sum+remaining[i] < target {
+			return
+		}
+		picked = append(picked, i)
+		search(i+1, sum+sorted[i].effective)
+		picked = picked[:len(picked)-1]
+		search(i+1, sum)
+	}
+	search(0, 0)
+
+	if best == nil {
+		return nil
+	}
+	selected := make([]coin, len(best))
+	for i, index := range best {
+		selected[i] = sorted[index]
+	}
+	return selected
+}
+
+// privacyPreserving groups coins by the transaction that created them and picks whole
+// groups in random order until they cover target.
+func privacyPreserving(coins []coin, target amount.Amount) ([]coin, error) {
+	groupOf := make(map[string]int)
+	var groups [][]coin
+	for _, c := range coins {
+		index, ok := groupOf[c.utxo.TransactionID]
+		if !ok {
+			index = len(groups)
+			groupOf[c.utxo.TransactionID] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], c)
+	}
+	if err := shuffle(len(groups), func(i, j int) { groups[i], groups[j] = groups[j], groups[i] }); err != nil {
+		return nil, err
+	}
+
+	var selected []coin
+	var sum amount.Amount
+	for _, group := range groups {
+		if sum >= target {
+			break
+		}
+		for _, c := range group {
+			selected = append(selected, c)
+			sum += c.effective
+		}
+	}
+	return selected, nil
+}
+
+// shuffle is a Fisher-Yates shuffle driven by crypto/rand, so the order of the inputs
+// cannot be predicted.
+func shuffle(n int, swap func(i, j int)) error {
+	for i := n - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return fmt.Errorf("failed to shuffle outputs: %v", err)
+		}
+		swap(i, int(j.Int64()))
+	}
+	return nil
+}